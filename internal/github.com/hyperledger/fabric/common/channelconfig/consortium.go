@@ -0,0 +1,17 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+/*
+Notice: This file has been modified for Hyperledger Fabric SDK Go usage.
+Please review third_party pinning scripts and patches for more details.
+*/
+
+package channelconfig
+
+const (
+	// ChannelCreationPolicyKey is the key used in the consortium config to denote the policy
+	// to be used in evaluating whether a channel creation request is authorized
+	ChannelCreationPolicyKey = "ChannelCreationPolicy"
+)
@@ -0,0 +1,16 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+/*
+Notice: This file has been modified for Hyperledger Fabric SDK Go usage.
+Please review third_party pinning scripts and patches for more details.
+*/
+
+package channelconfig
+
+const (
+	// ConsortiumsGroupKey is the group name for the consortiums config
+	ConsortiumsGroupKey = "Consortiums"
+)
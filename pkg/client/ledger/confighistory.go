@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/chconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// ConfigBlock contains a channel configuration along with the number of the block in which it was committed
+type ConfigBlock struct {
+	BlockNumber uint64
+	Config      *common.Config
+	ChannelCfg  fab.ChannelCfg
+}
+
+type blockQueryFunc func(blockNumber uint64) (*common.Block, error)
+
+// QueryConfigHistory walks back through the LastConfig metadata of the channel's blocks and
+// returns every configuration block of the channel, starting with the genesis block.
+//  Parameters:
+//  options hold optional request options
+//
+//  Returns:
+//  the configuration blocks of the channel, ordered by block number
+func (c *Client) QueryConfigHistory(options ...RequestOption) ([]*ConfigBlock, error) {
+	info, err := c.QueryInfo(options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "QueryConfigHistory failed to query blockchain info")
+	}
+	if info.BCI.Height == 0 {
+		return nil, errors.New("QueryConfigHistory: ledger is empty")
+	}
+
+	return configHistory(c.ctx.ChannelID(), info.BCI.Height-1, c.blockQuerier(options...))
+}
+
+// QueryConfigAt returns the channel configuration that was in effect at the given block height,
+// i.e. the configuration referenced by the LastConfig metadata of the given block.
+//  Parameters:
+//  blockNumber is required block number
+//  options hold optional request options
+//
+//  Returns:
+//  the configuration block in effect at the given block
+func (c *Client) QueryConfigAt(blockNumber uint64, options ...RequestOption) (*ConfigBlock, error) {
+	return configAt(c.ctx.ChannelID(), blockNumber, c.blockQuerier(options...))
+}
+
+// QueryConfigDiff computes the differences between the channel configurations that were in effect
+// at the given block heights.
+//  Parameters:
+//  fromBlock is the block number of the original configuration
+//  toBlock is the block number of the updated configuration
+//  options hold optional request options
+//
+//  Returns:
+//  the differences between the two configurations
+func (c *Client) QueryConfigDiff(fromBlock, toBlock uint64, options ...RequestOption) (*chconfig.ConfigDiff, error) {
+	from, err := c.QueryConfigAt(fromBlock, options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "QueryConfigDiff failed to query 'from' config")
+	}
+
+	to, err := c.QueryConfigAt(toBlock, options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "QueryConfigDiff failed to query 'to' config")
+	}
+
+	return chconfig.Diff(from.Config, to.Config)
+}
+
+func (c *Client) blockQuerier(options ...RequestOption) blockQueryFunc {
+	return func(blockNumber uint64) (*common.Block, error) {
+		return c.QueryBlock(blockNumber, options...)
+	}
+}
+
+func configHistory(channelID string, blockNumber uint64, queryBlock blockQueryFunc) ([]*ConfigBlock, error) {
+	var history []*ConfigBlock
+	for {
+		configBlock, err := configAt(channelID, blockNumber, queryBlock)
+		if err != nil {
+			return nil, err
+		}
+
+		history = append([]*ConfigBlock{configBlock}, history...)
+
+		if configBlock.BlockNumber == 0 {
+			return history, nil
+		}
+
+		// The block preceding a config block references the previous config block
+		blockNumber = configBlock.BlockNumber - 1
+	}
+}
+
+func configAt(channelID string, blockNumber uint64, queryBlock blockQueryFunc) (*ConfigBlock, error) {
+	block, err := queryBlock(blockNumber)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to query block")
+	}
+
+	lastConfig, err := resource.GetLastConfigFromBlock(block)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get last config from block")
+	}
+
+	if lastConfig.Index > blockNumber {
+		return nil, errors.Errorf("invalid last config index %d for block %d", lastConfig.Index, blockNumber)
+	}
+
+	if lastConfig.Index != blockNumber {
+		block, err = queryBlock(lastConfig.Index)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to query config block")
+		}
+	}
+
+	return newConfigBlock(channelID, block)
+}
+
+func newConfigBlock(channelID string, block *common.Block) (*ConfigBlock, error) {
+	if block.Data == nil || len(block.Data.Data) != 1 {
+		return nil, errors.New("config block must contain one transaction")
+	}
+
+	configEnvelope, err := resource.CreateConfigEnvelope(block.Data.Data[0])
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to extract config envelope from block")
+	}
+
+	channelCfg, err := chconfig.ExtractConfigFromBlock(channelID, block)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to extract channel config from block")
+	}
+
+	return &ConfigBlock{
+		BlockNumber: block.Header.Number,
+		Config:      configEnvelope.Config,
+		ChannelCfg:  channelCfg,
+	}, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigHistory(t *testing.T) {
+	// Config blocks at 0, 2 and 4; blocks 1, 3 and 5 are regular blocks
+	blocks := map[uint64]*common.Block{
+		0: newMockConfigBlock(0, "localhost:7050"),
+		1: newMockBlock(t, 1, 0),
+		2: newMockConfigBlock(2, "localhost:8050"),
+		3: newMockBlock(t, 3, 2),
+		4: newMockConfigBlock(4, "localhost:9050"),
+		5: newMockBlock(t, 5, 4),
+	}
+
+	var queried []uint64
+	queryBlock := func(blockNumber uint64) (*common.Block, error) {
+		queried = append(queried, blockNumber)
+		block, ok := blocks[blockNumber]
+		if !ok {
+			return nil, errors.Errorf("block %d not found", blockNumber)
+		}
+		return block, nil
+	}
+
+	history, err := configHistory(channelID, 5, queryBlock)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	assert.Equal(t, uint64(0), history[0].BlockNumber)
+	assert.Equal(t, []string{"localhost:7050"}, history[0].ChannelCfg.Orderers())
+	assert.Equal(t, uint64(2), history[1].BlockNumber)
+	assert.Equal(t, []string{"localhost:8050"}, history[1].ChannelCfg.Orderers())
+	assert.Equal(t, uint64(4), history[2].BlockNumber)
+	assert.Equal(t, []string{"localhost:9050"}, history[2].ChannelCfg.Orderers())
+	assert.NotNil(t, history[2].Config)
+
+	queried = nil
+	configBlock, err := configAt(channelID, 3, queryBlock)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), configBlock.BlockNumber)
+	assert.Equal(t, []uint64{3, 2}, queried)
+
+	queried = nil
+	configBlock, err = configAt(channelID, 2, queryBlock)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), configBlock.BlockNumber)
+	assert.Equal(t, []uint64{2}, queried)
+
+	_, err = configAt(channelID, 6, queryBlock)
+	assert.Error(t, err)
+
+	// Invalid last config index
+	blocks[6] = newMockBlock(t, 6, 7)
+	_, err = configAt(channelID, 6, queryBlock)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid last config index")
+
+	// Last config refers to a block that isn't a config block
+	blocks[7] = newMockBlock(t, 7, 5)
+	_, err = configAt(channelID, 7, queryBlock)
+	assert.Error(t, err)
+}
+
+func newMockConfigBlock(blockNumber uint64, ordererAddress string) *common.Block {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP"},
+			OrdererAddress: ordererAddress,
+		},
+		Index:           blockNumber,
+		LastConfigIndex: blockNumber,
+	}
+	return builder.Build()
+}
+
+func newMockBlock(t *testing.T, blockNumber, lastConfigIndex uint64) *common.Block {
+	lastConfigBytes, err := proto.Marshal(&common.LastConfig{Index: lastConfigIndex})
+	require.NoError(t, err)
+	metadataBytes, err := proto.Marshal(&common.Metadata{Value: lastConfigBytes})
+	require.NoError(t, err)
+
+	metadata := make([][]byte, len(common.BlockMetadataIndex_name))
+	metadata[common.BlockMetadataIndex_LAST_CONFIG] = metadataBytes
+
+	return &common.Block{
+		Header:   &common.BlockHeader{Number: blockNumber},
+		Data:     &common.BlockData{Data: [][]byte{[]byte("data")}},
+		Metadata: &common.BlockMetadata{Metadata: metadata},
+	}
+}
@@ -0,0 +1,276 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	reqContext "context"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/pkg/errors"
+)
+
+// PeerHealthStatus is the health status of a peer's copy of the ledger
+type PeerHealthStatus string
+
+const (
+	// PeerStatusOK indicates that the peer responded and agrees with the other peers at its height
+	PeerStatusOK PeerHealthStatus = "OK"
+	// PeerStatusForked indicates that the peer reported a different block hash than another peer at the same height
+	PeerStatusForked PeerHealthStatus = "FORKED"
+	// PeerStatusNotJoined indicates that the peer is reachable but has not joined the channel
+	PeerStatusNotJoined PeerHealthStatus = "NOT_JOINED"
+	// PeerStatusUnreachable indicates that the peer could not be queried
+	PeerStatusUnreachable PeerHealthStatus = "UNREACHABLE"
+	// PeerStatusUnknown indicates that a peer of another organization failed to return blockchain info. Its
+	// joined channels can't be queried with the client's identity, so the reason of the failure is unknown.
+	PeerStatusUnknown PeerHealthStatus = "UNKNOWN"
+)
+
+// PeerLedgerStatus contains the ledger height and current block hash reported by a single peer
+type PeerLedgerStatus struct {
+	URL              string           `json:"url"`
+	MSPID            string           `json:"mspId"`
+	Status           PeerHealthStatus `json:"status"`
+	Height           uint64           `json:"height"`
+	CurrentBlockHash string           `json:"currentBlockHash,omitempty"`
+	// Lag is the number of blocks that the peer is behind the highest peer
+	Lag   uint64 `json:"lag"`
+	Error string `json:"error,omitempty"`
+}
+
+// Fork describes a height at which peers reported different block hashes
+type Fork struct {
+	Height uint64 `json:"height"`
+	// Peers contains the URLs of the peers at this height, keyed by the (hex-encoded) block hash they reported
+	Peers map[string][]string `json:"peers"`
+}
+
+// HealthReport contains the ledger status of every peer of a channel
+type HealthReport struct {
+	ChannelID string              `json:"channelId"`
+	Timestamp time.Time           `json:"timestamp"`
+	MaxHeight uint64              `json:"maxHeight"`
+	Peers     []*PeerLedgerStatus `json:"peers"`
+	Forks     []*Fork             `json:"forks,omitempty"`
+}
+
+// IsHealthy returns true if every peer responded and no forks were detected
+func (r *HealthReport) IsHealthy() bool {
+	for _, p := range r.Peers {
+		if p.Status != PeerStatusOK {
+			return false
+		}
+	}
+	return true
+}
+
+// Lagging returns the responsive peers that are more than maxLag blocks behind the highest peer
+func (r *HealthReport) Lagging(maxLag uint64) []*PeerLedgerStatus {
+	var lagging []*PeerLedgerStatus
+	for _, p := range r.Peers {
+		if (p.Status == PeerStatusOK || p.Status == PeerStatusForked) && p.Lag > maxLag {
+			lagging = append(lagging, p)
+		}
+	}
+	return lagging
+}
+
+// WithStatus returns the peers with the given status
+func (r *HealthReport) WithStatus(status PeerHealthStatus) []*PeerLedgerStatus {
+	var peers []*PeerLedgerStatus
+	for _, p := range r.Peers {
+		if p.Status == status {
+			peers = append(peers, p)
+		}
+	}
+	return peers
+}
+
+// QueryHealthReport queries every known peer of the channel in parallel for its blockchain info
+// and reports each peer's height, current block hash and lag behind the highest peer. Peers at the
+// same height that report different block hashes are flagged as forked. Peers of the client's
+// organization that fail to respond are queried for the channels they have joined in order to
+// distinguish peers that have not joined the channel from peers that are unreachable. Peers of other
+// organizations only accept this query from their own admins, so their status is reported as unknown.
+//
+// Unlike the other queries, the default MSP filter of the client is not applied and MaxTargets and
+// MinTargets are ignored: all peers returned by discovery (or the provided targets) are queried.
+//  Parameters:
+//  options hold optional request options
+//
+//  Returns:
+//  the health report of the channel's peers
+func (c *Client) QueryHealthReport(options ...RequestOption) (*HealthReport, error) {
+	opts, err := c.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "QueryHealthReport failed to get opts")
+	}
+
+	targets, err := c.healthTargets(opts)
+	if err != nil {
+		return nil, errors.WithMessage(err, "QueryHealthReport failed to determine target peers")
+	}
+
+	reqCtx, cancel := c.createRequestContext(&opts)
+	defer cancel()
+
+	statuses := make([]*PeerLedgerStatus, len(targets))
+
+	var wg sync.WaitGroup
+	wg.Add(len(targets))
+	for i, target := range targets {
+		go func(i int, target fab.Peer) {
+			defer wg.Done()
+			statuses[i] = c.peerLedgerStatus(reqCtx, target)
+		}(i, target)
+	}
+	wg.Wait()
+
+	return newHealthReport(c.ctx.ChannelID(), statuses), nil
+}
+
+func (c *Client) healthTargets(opts requestOptions) ([]fab.Peer, error) {
+	if opts.Targets != nil && opts.TargetFilter != nil {
+		return nil, errors.New("If targets are provided, filter cannot be provided")
+	}
+
+	targets := opts.Targets
+	if targets == nil {
+		var err error
+		targets, err = c.discovery.GetPeers()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.TargetFilter != nil {
+		targets = filterTargets(targets, opts.TargetFilter)
+	}
+
+	if len(targets) == 0 {
+		return nil, errors.WithStack(status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "no targets available", nil))
+	}
+
+	return targets, nil
+}
+
+func (c *Client) peerLedgerStatus(reqCtx reqContext.Context, peer fab.Peer) *PeerLedgerStatus {
+	peerStatus := &PeerLedgerStatus{
+		URL:   peer.URL(),
+		MSPID: peer.MSPID(),
+	}
+
+	responses, err := c.ledger.QueryInfo(reqCtx, []fab.ProposalProcessor{peer}, c.verifier)
+	if len(responses) == 0 {
+		if err == nil {
+			err = errors.New("no response")
+		}
+
+		peerStatus.Status = c.unresponsiveStatus(reqCtx, peer)
+		peerStatus.Error = err.Error()
+		return peerStatus
+	}
+
+	bci := responses[0].BCI
+	peerStatus.Status = PeerStatusOK
+	peerStatus.Height = bci.Height
+	peerStatus.CurrentBlockHash = hex.EncodeToString(bci.CurrentBlockHash)
+
+	return peerStatus
+}
+
+// unresponsiveStatus determines whether a peer that failed to return blockchain info
+// has not joined the channel or is unreachable
+func (c *Client) unresponsiveStatus(reqCtx reqContext.Context, peer fab.Peer) PeerHealthStatus {
+	if peer.MSPID() != c.ctx.Identifier().MSPID {
+		// Peers only return their joined channels to (admins of) their own organization
+		return PeerStatusUnknown
+	}
+
+	response, err := resource.QueryChannels(reqCtx, peer)
+	if err != nil {
+		return PeerStatusUnreachable
+	}
+
+	for _, channel := range response.Channels {
+		if channel.ChannelId == c.ctx.ChannelID() {
+			// The peer has joined the channel but failed to return blockchain info
+			return PeerStatusUnreachable
+		}
+	}
+
+	return PeerStatusNotJoined
+}
+
+func newHealthReport(channelID string, statuses []*PeerLedgerStatus) *HealthReport {
+	report := &HealthReport{
+		ChannelID: channelID,
+		Timestamp: time.Now(),
+		Peers:     statuses,
+	}
+
+	heights, maxHeight := groupByHeight(statuses)
+	report.MaxHeight = maxHeight
+
+	for _, s := range statuses {
+		if s.Status == PeerStatusOK {
+			s.Lag = report.MaxHeight - s.Height
+		}
+	}
+
+	for height, hashes := range heights {
+		if len(hashes) < 2 {
+			continue
+		}
+		report.Forks = append(report.Forks, newFork(height, hashes))
+	}
+
+	sort.Slice(report.Forks, func(i, j int) bool { return report.Forks[i].Height < report.Forks[j].Height })
+	sort.Slice(report.Peers, func(i, j int) bool { return report.Peers[i].URL < report.Peers[j].URL })
+
+	return report
+}
+
+// groupByHeight returns the peers (that responded) keyed by height and then by block hash, and the maximum height
+func groupByHeight(statuses []*PeerLedgerStatus) (map[uint64]map[string][]*PeerLedgerStatus, uint64) {
+	var maxHeight uint64
+	heights := make(map[uint64]map[string][]*PeerLedgerStatus)
+	for _, s := range statuses {
+		if s.Status != PeerStatusOK {
+			continue
+		}
+		if s.Height > maxHeight {
+			maxHeight = s.Height
+		}
+		hashes, ok := heights[s.Height]
+		if !ok {
+			hashes = make(map[string][]*PeerLedgerStatus)
+			heights[s.Height] = hashes
+		}
+		hashes[s.CurrentBlockHash] = append(hashes[s.CurrentBlockHash], s)
+	}
+	return heights, maxHeight
+}
+
+// newFork returns the fork at the given height and marks the peers as forked
+func newFork(height uint64, hashes map[string][]*PeerLedgerStatus) *Fork {
+	fork := &Fork{Height: height, Peers: make(map[string][]string)}
+	for hash, peers := range hashes {
+		for _, p := range peers {
+			p.Status = PeerStatusForked
+			fork.Peers[hash] = append(fork.Peers[hash], p.URL)
+		}
+		sort.Strings(fork.Peers[hash])
+	}
+	return fork
+}
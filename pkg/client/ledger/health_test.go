@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	reqContext "context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryHealthReport(t *testing.T) {
+	peer1 := newInfoPeer(t, "http://peer1.com", 10, []byte("hash10"))
+	peer2 := newInfoPeer(t, "http://peer2.com", 10, []byte("hash10"))
+	peer3 := newInfoPeer(t, "http://peer3.com", 8, []byte("hash8"))
+	peer4 := newInfoPeer(t, "http://peer4.com", 8, []byte("hash8-fork"))
+
+	// peer5 (of the client's organization) fails to return blockchain info and hasn't joined the channel
+	peer5 := &healthTestPeer{MockPeer: mocks.NewMockPeer("Peer5", "http://peer5.com")}
+	peer5.SetMSPID("test")
+	peer5.responses = []*pb.Response{{Status: 500, Message: "channel not found"}, channelsResponse(t, "otherchannel")}
+
+	// peer6 (of the client's organization) fails to return blockchain info and channels
+	peer6 := &healthTestPeer{MockPeer: mocks.NewMockPeer("Peer6", "http://peer6.com")}
+	peer6.SetMSPID("test")
+	peer6.responses = []*pb.Response{{Status: 500, Message: "unavailable"}, {Status: 500, Message: "unavailable"}}
+
+	// peer7 belongs to another organization and fails to return blockchain info, so its channels aren't queried
+	peer7 := &healthTestPeer{MockPeer: mocks.NewMockPeer("Peer7", "http://peer7.com")}
+	peer7.SetMSPID("Org2MSP")
+	peer7.responses = []*pb.Response{{Status: 500, Message: "access denied"}, channelsResponse(t, "otherchannel")}
+
+	lc := setupLedgerClient([]fab.Peer{peer1, peer2, peer3, peer4, peer5, peer6, peer7}, t)
+
+	report, err := lc.QueryHealthReport()
+	require.NoError(t, err)
+
+	assert.Equal(t, channelID, report.ChannelID)
+	assert.Equal(t, uint64(10), report.MaxHeight)
+	assert.False(t, report.IsHealthy())
+	require.Len(t, report.Peers, 7)
+
+	statuses := make(map[string]*PeerLedgerStatus)
+	for _, s := range report.Peers {
+		statuses[s.URL] = s
+	}
+
+	assert.Equal(t, PeerStatusOK, statuses["http://peer1.com"].Status)
+	assert.Equal(t, uint64(0), statuses["http://peer1.com"].Lag)
+	assert.Equal(t, hex.EncodeToString([]byte("hash10")), statuses["http://peer1.com"].CurrentBlockHash)
+	assert.Equal(t, PeerStatusOK, statuses["http://peer2.com"].Status)
+	assert.Equal(t, PeerStatusForked, statuses["http://peer3.com"].Status)
+	assert.Equal(t, uint64(2), statuses["http://peer3.com"].Lag)
+	assert.Equal(t, PeerStatusForked, statuses["http://peer4.com"].Status)
+	assert.Equal(t, PeerStatusNotJoined, statuses["http://peer5.com"].Status)
+	assert.NotEmpty(t, statuses["http://peer5.com"].Error)
+	assert.Equal(t, PeerStatusUnreachable, statuses["http://peer6.com"].Status)
+	assert.Equal(t, PeerStatusUnknown, statuses["http://peer7.com"].Status)
+	assert.Equal(t, 1, peer7.calls, "channels shouldn't be queried on peers of other organizations")
+
+	require.Len(t, report.Forks, 1)
+	assert.Equal(t, uint64(8), report.Forks[0].Height)
+	assert.Equal(t, map[string][]string{
+		hex.EncodeToString([]byte("hash8")):      {"http://peer3.com"},
+		hex.EncodeToString([]byte("hash8-fork")): {"http://peer4.com"},
+	}, report.Forks[0].Peers)
+
+	assert.Len(t, report.Lagging(1), 2)
+	assert.Empty(t, report.Lagging(2))
+	assert.Len(t, report.WithStatus(PeerStatusForked), 2)
+
+	// Only the provided targets are queried
+	report, err = lc.QueryHealthReport(WithTargets(peer1, peer2))
+	require.NoError(t, err)
+	assert.Len(t, report.Peers, 2)
+	assert.True(t, report.IsHealthy())
+	assert.Empty(t, report.Forks)
+
+	report, err = lc.QueryHealthReport(WithTargetFilter(&urlFilter{url: "http://peer3.com"}))
+	require.NoError(t, err)
+	require.Len(t, report.Peers, 1)
+	assert.Equal(t, "http://peer3.com", report.Peers[0].URL)
+
+	_, err = lc.QueryHealthReport(WithTargets(peer1), WithTargetFilter(&urlFilter{url: "http://peer1.com"}))
+	assert.Error(t, err)
+
+	_, err = lc.QueryHealthReport(WithTargetFilter(&urlFilter{url: "http://unknown.com"}))
+	assert.Error(t, err)
+}
+
+type urlFilter struct {
+	url string
+}
+
+func (f *urlFilter) Accept(peer fab.Peer) bool {
+	return peer.URL() == f.url
+}
+
+// healthTestPeer returns the configured responses in order, one per proposal
+type healthTestPeer struct {
+	*mocks.MockPeer
+	responses []*pb.Response
+	calls     int
+}
+
+func (p *healthTestPeer) ProcessTransactionProposal(ctx reqContext.Context, tp fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	p.RWLock.Lock()
+	defer p.RWLock.Unlock()
+
+	response := p.responses[p.calls%len(p.responses)]
+	p.calls++
+
+	return &fab.TransactionProposalResponse{
+		Endorser: p.MockURL,
+		Status:   response.Status,
+		ProposalResponse: &pb.ProposalResponse{
+			Response:    response,
+			Endorsement: &pb.Endorsement{Signature: []byte("signature")},
+		},
+	}, nil
+}
+
+func newInfoPeer(t *testing.T, url string, height uint64, currentBlockHash []byte) *mocks.MockPeer {
+	payload, err := proto.Marshal(&common.BlockchainInfo{Height: height, CurrentBlockHash: currentBlockHash})
+	require.NoError(t, err)
+
+	peer := mocks.NewMockPeer(url, url)
+	peer.Payload = payload
+	return peer
+}
+
+func channelsResponse(t *testing.T, channelIDs ...string) *pb.Response {
+	response := &pb.ChannelQueryResponse{}
+	for _, id := range channelIDs {
+		response.Channels = append(response.Channels, &pb.ChannelInfo{ChannelId: id})
+	}
+	payload, err := proto.Marshal(response)
+	require.NoError(t, err)
+	return &pb.Response{Status: 200, Payload: payload}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyhistory
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	ledgerutil "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// kvWrite is a write to a key extracted from a block
+type kvWrite struct {
+	namespace string
+	key       string
+	mod       *KeyModification
+}
+
+// extractWrites returns the writes of all valid endorser transactions in the block
+func extractWrites(block *common.Block) ([]*kvWrite, error) {
+	if block.Data == nil {
+		return nil, nil
+	}
+
+	var txFilter ledgerutil.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txFilter = ledgerutil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	var writes []*kvWrite
+	for i, data := range block.Data.Data {
+		if i >= len(txFilter) || !txFilter.IsValid(i) {
+			logger.Debugf("Skipping invalid transaction %d in block %d", i, block.Header.Number)
+			continue
+		}
+
+		txWrites, err := extractTxWrites(data, block.Header.Number, uint64(i))
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to extract writes from transaction")
+		}
+		writes = append(writes, txWrites...)
+	}
+
+	return writes, nil
+}
+
+func extractTxWrites(data []byte, blockNum, txNum uint64) ([]*kvWrite, error) {
+	payload, channelHeader, err := getTxPayload(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if common.HeaderType(channelHeader.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+		return nil, nil
+	}
+
+	var timestamp time.Time
+	if channelHeader.Timestamp != nil {
+		timestamp, err = ptypes.Timestamp(channelHeader.Timestamp)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid transaction timestamp")
+		}
+	}
+
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling transaction payload")
+	}
+
+	txMod := KeyModification{
+		BlockNum:  blockNum,
+		TxNum:     txNum,
+		TxID:      channelHeader.TxId,
+		Timestamp: timestamp,
+	}
+
+	var writes []*kvWrite
+	for _, action := range tx.Actions {
+		txRWSet, err := getTxRWSet(action.Payload)
+		if err != nil {
+			return nil, err
+		}
+		writes = append(writes, rwSetWrites(txRWSet, txMod)...)
+	}
+
+	return writes, nil
+}
+
+// getTxPayload returns the payload and channel header of the transaction envelope
+func getTxPayload(data []byte) (*common.Payload, *common.ChannelHeader, error) {
+	env, err := utils.GetEnvelopeFromBlock(data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error extracting Envelope from block")
+	}
+
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error extracting Payload from envelope")
+	}
+	if payload.Header == nil {
+		return nil, nil, errors.New("payload header is nil")
+	}
+
+	channelHeader, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error extracting ChannelHeader from payload")
+	}
+
+	return payload, channelHeader, nil
+}
+
+// rwSetWrites returns the writes of the read-write set. txMod holds the transaction details of the modifications.
+func rwSetWrites(txRWSet *rwsetutil.TxRwSet, txMod KeyModification) []*kvWrite {
+	var writes []*kvWrite
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.KvRwSet == nil {
+			continue
+		}
+		for _, w := range nsRWSet.KvRwSet.Writes {
+			mod := txMod
+			mod.Value = w.Value
+			mod.IsDelete = w.IsDelete
+			writes = append(writes, &kvWrite{
+				namespace: nsRWSet.NameSpace,
+				key:       w.Key,
+				mod:       &mod,
+			})
+		}
+	}
+	return writes
+}
+
+func getTxRWSet(actionPayload []byte) (*rwsetutil.TxRwSet, error) {
+	chaincodeActionPayload, err := utils.GetChaincodeActionPayload(actionPayload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chaincode action payload")
+	}
+	if chaincodeActionPayload.Action == nil {
+		return nil, errors.New("chaincode endorsed action is nil")
+	}
+
+	propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling proposal response payload")
+	}
+
+	ccAction, err := utils.GetChaincodeAction(propRespPayload.Extension)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chaincode action")
+	}
+
+	txRWSet := &rwsetutil.TxRwSet{}
+	if len(ccAction.Results) == 0 {
+		return txRWSet, nil
+	}
+
+	if err := txRWSet.FromProtoBytes(ccAction.Results); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling read-write set")
+	}
+
+	return txRWSet, nil
+}
@@ -0,0 +1,297 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package keyhistory maintains a local, queryable index of key modifications on a channel.
+// The index is built from the write sets of valid endorser transactions contained in blocks, which
+// may be supplied from a block range (using the ledger client) or live (using the event client).
+// It allows the full history of a key, and the state of a namespace at any block height, to be
+// retrieved without running chaincode history queries against the peers.
+//
+//  Basic Flow:
+//  1) Create an indexer
+//  2) Index a range of blocks and/or listen for new block events
+//  3) Query key history and state
+package keyhistory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/ledger"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/client")
+
+// KeyModification describes a single write (or delete) of a key by a valid transaction
+type KeyModification struct {
+	BlockNum  uint64
+	TxNum     uint64
+	TxID      string
+	Timestamp time.Time
+	Value     []byte
+	IsDelete  bool
+}
+
+// BlockQuerier retrieves blocks by number (implemented by ledger.Client)
+type BlockQuerier interface {
+	QueryBlock(blockNumber uint64, options ...ledger.RequestOption) (*common.Block, error)
+}
+
+// BlockEventSource delivers block events (implemented by event.Client)
+type BlockEventSource interface {
+	RegisterBlockEvent(filter ...fab.BlockFilter) (fab.Registration, <-chan *fab.BlockEvent, error)
+	Unregister(reg fab.Registration)
+}
+
+// Option configures the indexer
+type Option func(*Indexer)
+
+// WithNamespaces restricts the index to the given namespaces (chaincode names).
+// By default the writes of all namespaces are indexed.
+func WithNamespaces(namespaces ...string) Option {
+	return func(ix *Indexer) {
+		if ix.namespaceFilter == nil {
+			ix.namespaceFilter = make(map[string]bool)
+		}
+		for _, ns := range namespaces {
+			ix.namespaceFilter[ns] = true
+		}
+	}
+}
+
+// Indexer maintains a history of key modifications per namespace
+type Indexer struct {
+	mutex           sync.RWMutex
+	namespaceFilter map[string]bool
+	history         map[string]map[string][]*KeyModification
+	indexedBlocks   map[uint64]bool
+	lastBlockNum    uint64
+}
+
+// New returns a new, empty key history indexer
+func New(opts ...Option) *Indexer {
+	ix := &Indexer{
+		history:       make(map[string]map[string][]*KeyModification),
+		indexedBlocks: make(map[uint64]bool),
+	}
+	for _, opt := range opts {
+		opt(ix)
+	}
+	return ix
+}
+
+// IndexBlock adds the writes of all valid endorser transactions in the given block to the index.
+// Blocks may be indexed in any order; a block that has already been indexed is ignored.
+func (ix *Indexer) IndexBlock(block *common.Block) error {
+	if block == nil || block.Header == nil {
+		return errors.New("block is nil or has no header")
+	}
+
+	writes, err := extractWrites(block)
+	if err != nil {
+		return errors.WithMessage(err, "failed to extract writes from block")
+	}
+
+	ix.mutex.Lock()
+	defer ix.mutex.Unlock()
+
+	blockNum := block.Header.Number
+	if ix.indexedBlocks[blockNum] {
+		logger.Debugf("Block %d has already been indexed", blockNum)
+		return nil
+	}
+
+	for _, w := range writes {
+		if ix.namespaceFilter != nil && !ix.namespaceFilter[w.namespace] {
+			continue
+		}
+		ix.add(w.namespace, w.key, w.mod)
+	}
+
+	ix.indexedBlocks[blockNum] = true
+	if blockNum > ix.lastBlockNum {
+		ix.lastBlockNum = blockNum
+	}
+
+	return nil
+}
+
+// IndexRange retrieves the blocks from 'from' to 'to' (inclusive) using the given querier and indexes them.
+func (ix *Indexer) IndexRange(querier BlockQuerier, from, to uint64, options ...ledger.RequestOption) error {
+	if from > to {
+		return errors.Errorf("invalid block range [%d, %d]", from, to)
+	}
+
+	for blockNum := from; blockNum <= to; blockNum++ {
+		if ix.IsIndexed(blockNum) {
+			continue
+		}
+
+		block, err := querier.QueryBlock(blockNum, options...)
+		if err != nil {
+			return errors.WithMessage(err, "failed to query block")
+		}
+
+		if err := ix.IndexBlock(block); err != nil {
+			return errors.WithMessage(err, "failed to index block")
+		}
+	}
+
+	return nil
+}
+
+// Listen registers for block events with the given source and indexes each block as it is received.
+// Note that the source must permit block events (see event.WithBlockEvents). The returned function
+// stops listening and must be called when the indexer is no longer needed.
+func (ix *Indexer) Listen(source BlockEventSource) (stop func(), err error) {
+	reg, eventch, err := source.RegisterBlockEvent()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to register for block events")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range eventch {
+			if err := ix.IndexBlock(event.Block); err != nil {
+				logger.Warnf("Error indexing block from [%s]: %s", event.SourceURL, err)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			source.Unregister(reg)
+			<-done
+		})
+	}, nil
+}
+
+// IsIndexed returns true if the given block has been indexed
+func (ix *Indexer) IsIndexed(blockNum uint64) bool {
+	ix.mutex.RLock()
+	defer ix.mutex.RUnlock()
+
+	return ix.indexedBlocks[blockNum]
+}
+
+// LastBlockNum returns the highest block number that has been indexed. False is returned
+// if no blocks have been indexed.
+func (ix *Indexer) LastBlockNum() (uint64, bool) {
+	ix.mutex.RLock()
+	defer ix.mutex.RUnlock()
+
+	return ix.lastBlockNum, len(ix.indexedBlocks) > 0
+}
+
+// Namespaces returns the (sorted) namespaces that have at least one indexed write
+func (ix *Indexer) Namespaces() []string {
+	ix.mutex.RLock()
+	defer ix.mutex.RUnlock()
+
+	var namespaces []string
+	for ns := range ix.history {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// Keys returns the (sorted) keys of the given namespace that have at least one indexed write
+func (ix *Indexer) Keys(namespace string) []string {
+	ix.mutex.RLock()
+	defer ix.mutex.RUnlock()
+
+	var keys []string
+	for key := range ix.history[namespace] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// History returns all indexed modifications of the given key in commit order
+func (ix *Indexer) History(namespace, key string) []KeyModification {
+	ix.mutex.RLock()
+	defer ix.mutex.RUnlock()
+
+	mods := ix.history[namespace][key]
+	history := make([]KeyModification, len(mods))
+	for i, mod := range mods {
+		history[i] = *mod
+	}
+	return history
+}
+
+// ValueAt returns the value of the given key as of the end of the given block.
+// False is returned if the key did not exist (or had been deleted) at that height.
+func (ix *Indexer) ValueAt(namespace, key string, blockNum uint64) ([]byte, bool) {
+	ix.mutex.RLock()
+	defer ix.mutex.RUnlock()
+
+	mod := latestAt(ix.history[namespace][key], blockNum)
+	if mod == nil || mod.IsDelete {
+		return nil, false
+	}
+	return mod.Value, true
+}
+
+// StateAt reconstructs the state of the given namespace as of the end of the given block
+func (ix *Indexer) StateAt(namespace string, blockNum uint64) map[string][]byte {
+	ix.mutex.RLock()
+	defer ix.mutex.RUnlock()
+
+	state := make(map[string][]byte)
+	for key, mods := range ix.history[namespace] {
+		mod := latestAt(mods, blockNum)
+		if mod != nil && !mod.IsDelete {
+			state[key] = mod.Value
+		}
+	}
+	return state
+}
+
+// add inserts the modification, keeping the key's history ordered by block and transaction number
+func (ix *Indexer) add(namespace, key string, mod *KeyModification) {
+	keys, ok := ix.history[namespace]
+	if !ok {
+		keys = make(map[string][]*KeyModification)
+		ix.history[namespace] = keys
+	}
+
+	mods := keys[key]
+	i := sort.Search(len(mods), func(i int) bool {
+		return after(mods[i], mod)
+	})
+	mods = append(mods, nil)
+	copy(mods[i+1:], mods[i:])
+	mods[i] = mod
+	keys[key] = mods
+}
+
+// latestAt returns the last modification committed at or before the given block
+func latestAt(mods []*KeyModification, blockNum uint64) *KeyModification {
+	i := sort.Search(len(mods), func(i int) bool {
+		return mods[i].BlockNum > blockNum
+	})
+	if i == 0 {
+		return nil
+	}
+	return mods[i-1]
+}
+
+func after(m1, m2 *KeyModification) bool {
+	if m1.BlockNum != m2.BlockNum {
+		return m1.BlockNum > m2.BlockNum
+	}
+	return m1.TxNum > m2.TxNum
+}
@@ -0,0 +1,265 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyhistory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/ledger"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	ledgerutil "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ccID1 = "cc1"
+	ccID2 = "cc2"
+)
+
+type testTx struct {
+	txID   string
+	code   pb.TxValidationCode
+	writes map[string][]*kvrwset.KVWrite
+}
+
+func TestIndexBlock(t *testing.T) {
+	ix := New()
+
+	_, ok := ix.LastBlockNum()
+	assert.False(t, ok)
+
+	require.NoError(t, ix.IndexBlock(newBlock(t, 1,
+		testTx{txID: "tx1", code: pb.TxValidationCode_VALID, writes: map[string][]*kvrwset.KVWrite{
+			ccID1: {{Key: "a", Value: []byte("a1")}, {Key: "b", Value: []byte("b1")}},
+			ccID2: {{Key: "a", Value: []byte("x1")}},
+		}},
+		testTx{txID: "tx2", code: pb.TxValidationCode_MVCC_READ_CONFLICT, writes: map[string][]*kvrwset.KVWrite{
+			ccID1: {{Key: "a", Value: []byte("invalid")}},
+		}},
+	)))
+
+	require.NoError(t, ix.IndexBlock(newBlock(t, 3,
+		testTx{txID: "tx4", code: pb.TxValidationCode_VALID, writes: map[string][]*kvrwset.KVWrite{
+			ccID1: {{Key: "a", IsDelete: true}},
+		}},
+	)))
+
+	// Index out of order
+	require.NoError(t, ix.IndexBlock(newBlock(t, 2,
+		testTx{txID: "tx3", code: pb.TxValidationCode_VALID, writes: map[string][]*kvrwset.KVWrite{
+			ccID1: {{Key: "a", Value: []byte("a2")}},
+		}},
+	)))
+
+	lastBlockNum, ok := ix.LastBlockNum()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), lastBlockNum)
+
+	assert.Equal(t, []string{ccID1, ccID2}, ix.Namespaces())
+	assert.Equal(t, []string{"a", "b"}, ix.Keys(ccID1))
+
+	history := ix.History(ccID1, "a")
+	require.Len(t, history, 3)
+	assert.Equal(t, "tx1", history[0].TxID)
+	assert.Equal(t, []byte("a1"), history[0].Value)
+	assert.Equal(t, "tx3", history[1].TxID)
+	assert.Equal(t, uint64(2), history[1].BlockNum)
+	assert.Equal(t, "tx4", history[2].TxID)
+	assert.True(t, history[2].IsDelete)
+
+	_, ok = ix.ValueAt(ccID1, "a", 0)
+	assert.False(t, ok)
+
+	value, ok := ix.ValueAt(ccID1, "a", 1)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a1"), value)
+
+	value, ok = ix.ValueAt(ccID1, "a", 2)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a2"), value)
+
+	_, ok = ix.ValueAt(ccID1, "a", 3)
+	assert.False(t, ok)
+
+	state := ix.StateAt(ccID1, 2)
+	assert.Equal(t, map[string][]byte{"a": []byte("a2"), "b": []byte("b1")}, state)
+
+	state = ix.StateAt(ccID1, 3)
+	assert.Equal(t, map[string][]byte{"b": []byte("b1")}, state)
+
+	// Indexing the same block again should be ignored
+	require.NoError(t, ix.IndexBlock(newBlock(t, 2,
+		testTx{txID: "tx3", code: pb.TxValidationCode_VALID, writes: map[string][]*kvrwset.KVWrite{
+			ccID1: {{Key: "a", Value: []byte("a2")}},
+		}},
+	)))
+	assert.Len(t, ix.History(ccID1, "a"), 3)
+
+	assert.Error(t, ix.IndexBlock(nil))
+}
+
+func TestIndexBlockWithNamespaces(t *testing.T) {
+	ix := New(WithNamespaces(ccID2))
+
+	require.NoError(t, ix.IndexBlock(newBlock(t, 1,
+		testTx{txID: "tx1", code: pb.TxValidationCode_VALID, writes: map[string][]*kvrwset.KVWrite{
+			ccID1: {{Key: "a", Value: []byte("a1")}},
+			ccID2: {{Key: "a", Value: []byte("x1")}},
+		}},
+	)))
+
+	assert.Equal(t, []string{ccID2}, ix.Namespaces())
+	assert.Empty(t, ix.History(ccID1, "a"))
+}
+
+func TestIndexRange(t *testing.T) {
+	querier := &mockBlockQuerier{blocks: map[uint64]*common.Block{
+		0: newBlock(t, 0),
+		1: newBlock(t, 1, testTx{txID: "tx1", code: pb.TxValidationCode_VALID, writes: map[string][]*kvrwset.KVWrite{
+			ccID1: {{Key: "a", Value: []byte("a1")}},
+		}}),
+		2: newBlock(t, 2, testTx{txID: "tx2", code: pb.TxValidationCode_VALID, writes: map[string][]*kvrwset.KVWrite{
+			ccID1: {{Key: "a", Value: []byte("a2")}},
+		}}),
+	}}
+
+	ix := New()
+	require.NoError(t, ix.IndexRange(querier, 0, 2))
+	assert.Len(t, ix.History(ccID1, "a"), 2)
+	assert.Equal(t, 3, querier.queries)
+
+	// Blocks already indexed should not be queried again
+	require.NoError(t, ix.IndexRange(querier, 1, 2))
+	assert.Equal(t, 3, querier.queries)
+
+	err := ix.IndexRange(querier, 2, 3)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to query block")
+
+	assert.Error(t, ix.IndexRange(querier, 2, 1))
+}
+
+func TestListen(t *testing.T) {
+	source := &mockBlockEventSource{eventch: make(chan *fab.BlockEvent, 10)}
+
+	ix := New()
+	stop, err := ix.Listen(source)
+	require.NoError(t, err)
+
+	source.eventch <- &fab.BlockEvent{Block: newBlock(t, 5, testTx{txID: "tx1", code: pb.TxValidationCode_VALID, writes: map[string][]*kvrwset.KVWrite{
+		ccID1: {{Key: "a", Value: []byte("a1")}},
+	}})}
+
+	stop()
+	assert.True(t, source.unregistered)
+	assert.True(t, ix.IsIndexed(5))
+	assert.Len(t, ix.History(ccID1, "a"), 1)
+
+	_, err = ix.Listen(&mockBlockEventSource{err: errors.New("no permission")})
+	assert.Error(t, err)
+}
+
+func newBlock(t *testing.T, blockNum uint64, txs ...testTx) *common.Block {
+	block := &common.Block{
+		Header:   &common.BlockHeader{Number: blockNum},
+		Data:     &common.BlockData{},
+		Metadata: &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))},
+	}
+
+	txFilter := ledgerutil.NewTxValidationFlags(len(txs))
+	for i, tx := range txs {
+		block.Data.Data = append(block.Data.Data, newTxEnvelopeBytes(t, tx))
+		txFilter[i] = uint8(tx.code)
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txFilter
+
+	return block
+}
+
+func newTxEnvelopeBytes(t *testing.T, tx testTx) []byte {
+	txRWSet := &rwsetutil.TxRwSet{}
+	for ns, writes := range tx.writes {
+		txRWSet.NsRwSets = append(txRWSet.NsRwSets, &rwsetutil.NsRwSet{
+			NameSpace: ns,
+			KvRwSet:   &kvrwset.KVRWSet{Writes: writes},
+		})
+	}
+	results, err := txRWSet.ToProtoBytes()
+	require.NoError(t, err)
+
+	prpBytes, err := utils.GetBytesProposalResponsePayload([]byte("proposal_hash"), &pb.Response{Status: 200}, results, nil, nil)
+	require.NoError(t, err)
+
+	capBytes, err := utils.GetBytesChaincodeActionPayload(&pb.ChaincodeActionPayload{
+		Action: &pb.ChaincodeEndorsedAction{ProposalResponsePayload: prpBytes},
+	})
+	require.NoError(t, err)
+
+	txBytes, err := utils.GetBytesTransaction(&pb.Transaction{
+		Actions: []*pb.TransactionAction{{Payload: capBytes}},
+	})
+	require.NoError(t, err)
+
+	chdr := &common.ChannelHeader{
+		Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+		TxId:      tx.txID,
+		ChannelId: "testchannel",
+		Timestamp: &timestamp.Timestamp{Seconds: time.Now().Unix()},
+	}
+
+	payloadBytes, err := utils.GetBytesPayload(&common.Payload{
+		Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(chdr)},
+		Data:   txBytes,
+	})
+	require.NoError(t, err)
+
+	envBytes, err := utils.GetBytesEnvelope(&common.Envelope{Payload: payloadBytes})
+	require.NoError(t, err)
+
+	return envBytes
+}
+
+type mockBlockQuerier struct {
+	blocks  map[uint64]*common.Block
+	queries int
+}
+
+func (m *mockBlockQuerier) QueryBlock(blockNumber uint64, options ...ledger.RequestOption) (*common.Block, error) {
+	m.queries++
+	block, ok := m.blocks[blockNumber]
+	if !ok {
+		return nil, errors.Errorf("block %d not found", blockNumber)
+	}
+	return block, nil
+}
+
+type mockBlockEventSource struct {
+	eventch      chan *fab.BlockEvent
+	err          error
+	unregistered bool
+}
+
+func (m *mockBlockEventSource) RegisterBlockEvent(filter ...fab.BlockFilter) (fab.Registration, <-chan *fab.BlockEvent, error) {
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	return "reg", m.eventch, nil
+}
+
+func (m *mockBlockEventSource) Unregister(reg fab.Registration) {
+	m.unregistered = true
+	close(m.eventch)
+}
@@ -7,7 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 // Package ledger enables ledger queries on specified channel on a Fabric network.
 // An application that requires ledger queries from multiple channels should create a separate
 // instance of the ledger client for each channel. Ledger client supports the following queries:
-// QueryInfo, QueryBlock, QueryBlockByHash,  QueryBlockByTxID, QueryTransaction and QueryConfig.
+// QueryInfo, QueryBlock, QueryBlockByHash,  QueryBlockByTxID, QueryTransaction, QueryTransactionDetails, QueryConfig,
+// QueryConfigHistory, QueryConfigAt, QueryConfigDiff and QueryHealthReport.
 //
 //  Basic Flow:
 //  1) Prepare channel context
@@ -0,0 +1,275 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Identity is a decoded serialized identity (of a transaction creator or endorser)
+type Identity struct {
+	MSPID       string
+	Subject     string
+	Certificate *x509.Certificate
+}
+
+// TransactionDetails contains the decoded contents of a processed transaction
+type TransactionDetails struct {
+	TxID           string
+	ChannelID      string
+	Type           common.HeaderType
+	Timestamp      time.Time
+	BlockNumber    uint64
+	ValidationCode pb.TxValidationCode
+	Creator        *Identity
+	// Actions contains the chaincode actions of an endorser transaction
+	Actions []*ChaincodeActionDetails
+}
+
+// ChaincodeActionDetails contains the decoded contents of a single chaincode action of a transaction
+type ChaincodeActionDetails struct {
+	ChaincodeName    string
+	ChaincodeVersion string
+	Function         string
+	Args             [][]byte
+	Endorsers        []*Identity
+	Response         *pb.Response
+	RWSet            *rwsetutil.TxRwSet
+	Event            *pb.ChaincodeEvent
+}
+
+// QueryTransactionDetails queries the ledger for the processed transaction with the given ID
+// along with the block that contains it and returns the decoded transaction.
+//  Parameters:
+//  transactionID is required transaction ID
+//  options hold optional request options
+//
+//  Returns:
+//  the decoded transaction
+func (c *Client) QueryTransactionDetails(transactionID fab.TransactionID, options ...RequestOption) (*TransactionDetails, error) {
+	processedTx, err := c.QueryTransaction(transactionID, options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "QueryTransactionDetails failed to query transaction")
+	}
+
+	block, err := c.QueryBlockByTxID(transactionID, options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "QueryTransactionDetails failed to query block")
+	}
+	if block.Header == nil {
+		return nil, errors.New("QueryTransactionDetails: block header is nil")
+	}
+
+	details, err := DecodeTransaction(processedTx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "QueryTransactionDetails failed to decode transaction")
+	}
+	details.BlockNumber = block.Header.Number
+
+	return details, nil
+}
+
+// DecodeTransaction decodes the given processed transaction. The block number is not
+// part of a processed transaction and is therefore not set.
+func DecodeTransaction(processedTx *pb.ProcessedTransaction) (*TransactionDetails, error) {
+	if processedTx == nil || processedTx.TransactionEnvelope == nil {
+		return nil, errors.New("transaction envelope is nil")
+	}
+
+	payload, err := utils.GetPayload(processedTx.TransactionEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := decodeHeader(payload.Header)
+	if err != nil {
+		return nil, err
+	}
+	details.ValidationCode = pb.TxValidationCode(processedTx.ValidationCode)
+
+	if details.Type != common.HeaderType_ENDORSER_TRANSACTION {
+		return details, nil
+	}
+
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, action := range tx.Actions {
+		actionDetails, err := decodeChaincodeAction(action)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed to decode action %d", i))
+		}
+		details.Actions = append(details.Actions, actionDetails)
+	}
+
+	return details, nil
+}
+
+// decodeHeader returns the transaction details held by the payload header: the transaction ID, channel, type,
+// timestamp and creator
+func decodeHeader(header *common.Header) (*TransactionDetails, error) {
+	if header == nil {
+		return nil, errors.New("payload header is nil")
+	}
+
+	chdr, err := utils.UnmarshalChannelHeader(header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	shdr, err := utils.GetSignatureHeader(header.SignatureHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	creator, err := decodeIdentity(shdr.Creator)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to decode creator")
+	}
+
+	details := &TransactionDetails{
+		TxID:      chdr.TxId,
+		ChannelID: chdr.ChannelId,
+		Type:      common.HeaderType(chdr.Type),
+		Creator:   creator,
+	}
+	if chdr.Timestamp != nil {
+		details.Timestamp = time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos)).UTC()
+	}
+
+	return details, nil
+}
+
+func decodeChaincodeAction(action *pb.TransactionAction) (*ChaincodeActionDetails, error) {
+	actionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if actionPayload.Action == nil {
+		return nil, errors.New("chaincode endorsed action is nil")
+	}
+
+	details := &ChaincodeActionDetails{}
+
+	if err := decodeInvocation(details, actionPayload.ChaincodeProposalPayload); err != nil {
+		return nil, err
+	}
+
+	for _, endorsement := range actionPayload.Action.Endorsements {
+		endorser, err := decodeIdentity(endorsement.Endorser)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to decode endorser")
+		}
+		details.Endorsers = append(details.Endorsers, endorser)
+	}
+
+	prp, err := utils.GetProposalResponsePayload(actionPayload.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decodeChaincodeActionResults(details, prp.Extension); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// decodeChaincodeActionResults sets the chaincode ID, response, read-write set and event from the chaincode action
+// of the proposal response payload
+func decodeChaincodeActionResults(details *ChaincodeActionDetails, extension []byte) error {
+	ccAction, err := utils.GetChaincodeAction(extension)
+	if err != nil {
+		return err
+	}
+
+	if ccAction.ChaincodeId != nil {
+		details.ChaincodeName = ccAction.ChaincodeId.Name
+		details.ChaincodeVersion = ccAction.ChaincodeId.Version
+	}
+	details.Response = ccAction.Response
+
+	if len(ccAction.Results) > 0 {
+		details.RWSet = &rwsetutil.TxRwSet{}
+		if err := details.RWSet.FromProtoBytes(ccAction.Results); err != nil {
+			return errors.WithMessage(err, "failed to unmarshal read-write set")
+		}
+	}
+
+	if len(ccAction.Events) > 0 {
+		details.Event, err = utils.GetChaincodeEvents(ccAction.Events)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeInvocation sets the chaincode name, function and arguments from the chaincode proposal payload
+func decodeInvocation(details *ChaincodeActionDetails, proposalPayloadBytes []byte) error {
+	proposalPayload, err := utils.GetChaincodeProposalPayload(proposalPayloadBytes)
+	if err != nil {
+		return err
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{}
+	if err := proto.Unmarshal(proposalPayload.Input, cis); err != nil {
+		return errors.Wrap(err, "unmarshal ChaincodeInvocationSpec failed")
+	}
+
+	spec := cis.ChaincodeSpec
+	if spec == nil {
+		return nil
+	}
+	if spec.ChaincodeId != nil {
+		details.ChaincodeName = spec.ChaincodeId.Name
+	}
+	if spec.Input != nil && len(spec.Input.Args) > 0 {
+		details.Function = string(spec.Input.Args[0])
+		details.Args = spec.Input.Args[1:]
+	}
+
+	return nil
+}
+
+func decodeIdentity(serializedIdentity []byte) (*Identity, error) {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, sID); err != nil {
+		return nil, errors.Wrap(err, "unmarshal SerializedIdentity failed")
+	}
+
+	identity := &Identity{MSPID: sID.Mspid}
+
+	block, _ := pem.Decode(sID.IdBytes)
+	if block == nil {
+		return identity, nil
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse certificate failed")
+	}
+	identity.Certificate = cert
+	identity.Subject = cert.Subject.String()
+
+	return identity, nil
+}
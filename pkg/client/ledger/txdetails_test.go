@@ -0,0 +1,185 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTxID = "txid1"
+
+func TestDecodeTransaction(t *testing.T) {
+	processedTx := newProcessedTx(t, time.Unix(1500000000, 0))
+
+	details, err := DecodeTransaction(processedTx)
+	require.NoError(t, err)
+
+	assert.Equal(t, testTxID, details.TxID)
+	assert.Equal(t, channelID, details.ChannelID)
+	assert.Equal(t, common.HeaderType_ENDORSER_TRANSACTION, details.Type)
+	assert.Equal(t, time.Unix(1500000000, 0).UTC(), details.Timestamp)
+	assert.Equal(t, pb.TxValidationCode_MVCC_READ_CONFLICT, details.ValidationCode)
+
+	require.NotNil(t, details.Creator)
+	assert.Equal(t, "Org1MSP", details.Creator.MSPID)
+	assert.Equal(t, "CN=User1@org1.example.com", details.Creator.Subject)
+	assert.NotNil(t, details.Creator.Certificate)
+
+	require.Len(t, details.Actions, 1)
+	action := details.Actions[0]
+	assert.Equal(t, "examplecc", action.ChaincodeName)
+	assert.Equal(t, "v1", action.ChaincodeVersion)
+	assert.Equal(t, "move", action.Function)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte("10")}, action.Args)
+	assert.Equal(t, int32(200), action.Response.Status)
+
+	require.Len(t, action.Endorsers, 2)
+	assert.Equal(t, "Org1MSP", action.Endorsers[0].MSPID)
+	assert.Equal(t, "CN=peer0.org1.example.com", action.Endorsers[0].Subject)
+	assert.Equal(t, "Org2MSP", action.Endorsers[1].MSPID)
+	assert.Equal(t, "CN=peer0.org2.example.com", action.Endorsers[1].Subject)
+
+	require.NotNil(t, action.RWSet)
+	require.Len(t, action.RWSet.NsRwSets, 1)
+	assert.Equal(t, "examplecc", action.RWSet.NsRwSets[0].NameSpace)
+	assert.Equal(t, "a", action.RWSet.NsRwSets[0].KvRwSet.Writes[0].Key)
+
+	require.NotNil(t, action.Event)
+	assert.Equal(t, "moved", action.Event.EventName)
+
+	_, err = DecodeTransaction(nil)
+	assert.Error(t, err)
+
+	_, err = DecodeTransaction(&pb.ProcessedTransaction{TransactionEnvelope: &common.Envelope{Payload: []byte("invalid")}})
+	assert.Error(t, err)
+}
+
+func TestQueryTransactionDetails(t *testing.T) {
+	processedTxBytes, err := proto.Marshal(newProcessedTx(t, time.Now()))
+	require.NoError(t, err)
+	blockBytes, err := proto.Marshal(&common.Block{Header: &common.BlockHeader{Number: 42}, Data: &common.BlockData{}})
+	require.NoError(t, err)
+
+	peer := &healthTestPeer{MockPeer: mocks.NewMockPeer("Peer1", "http://peer1.com")}
+	peer.SetMSPID("test")
+	peer.responses = []*pb.Response{{Status: 200, Payload: processedTxBytes}, {Status: 200, Payload: blockBytes}}
+
+	lc := setupLedgerClient([]fab.Peer{peer}, t)
+
+	details, err := lc.QueryTransactionDetails(testTxID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), details.BlockNumber)
+	assert.Equal(t, testTxID, details.TxID)
+	require.Len(t, details.Actions, 1)
+	assert.Equal(t, "move", details.Actions[0].Function)
+
+	// Bad response from peer
+	peer.responses = []*pb.Response{{Status: 500}}
+	_, err = lc.QueryTransactionDetails(testTxID)
+	assert.Error(t, err)
+}
+
+func newProcessedTx(t *testing.T, txTime time.Time) *pb.ProcessedTransaction {
+	txRWSet := &rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{{
+			NameSpace: "examplecc",
+			KvRwSet:   &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a", Value: []byte("90")}}},
+		}},
+	}
+	results, err := txRWSet.ToProtoBytes()
+	require.NoError(t, err)
+
+	eventBytes, err := utils.GetBytesChaincodeEvent(&pb.ChaincodeEvent{ChaincodeId: "examplecc", TxId: testTxID, EventName: "moved"})
+	require.NoError(t, err)
+
+	prpBytes, err := utils.GetBytesProposalResponsePayload([]byte("proposal_hash"), &pb.Response{Status: 200}, results, eventBytes, &pb.ChaincodeID{Name: "examplecc", Version: "v1"})
+	require.NoError(t, err)
+
+	cisBytes, err := proto.Marshal(&pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: "examplecc"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("10")}},
+		},
+	})
+	require.NoError(t, err)
+	cppBytes, err := utils.GetBytesChaincodeProposalPayload(&pb.ChaincodeProposalPayload{Input: cisBytes})
+	require.NoError(t, err)
+
+	capBytes, err := utils.GetBytesChaincodeActionPayload(&pb.ChaincodeActionPayload{
+		ChaincodeProposalPayload: cppBytes,
+		Action: &pb.ChaincodeEndorsedAction{
+			ProposalResponsePayload: prpBytes,
+			Endorsements: []*pb.Endorsement{
+				{Endorser: newSerializedIdentity(t, "Org1MSP", "peer0.org1.example.com"), Signature: []byte("signature")},
+				{Endorser: newSerializedIdentity(t, "Org2MSP", "peer0.org2.example.com"), Signature: []byte("signature")},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	txBytes, err := utils.GetBytesTransaction(&pb.Transaction{Actions: []*pb.TransactionAction{{Payload: capBytes}}})
+	require.NoError(t, err)
+
+	chdr := &common.ChannelHeader{
+		Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+		TxId:      testTxID,
+		ChannelId: channelID,
+		Timestamp: &timestamp.Timestamp{Seconds: txTime.Unix(), Nanos: int32(txTime.Nanosecond())},
+	}
+	shdr := &common.SignatureHeader{Creator: newSerializedIdentity(t, "Org1MSP", "User1@org1.example.com")}
+
+	payloadBytes, err := utils.GetBytesPayload(&common.Payload{
+		Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(chdr), SignatureHeader: utils.MarshalOrPanic(shdr)},
+		Data:   txBytes,
+	})
+	require.NoError(t, err)
+
+	return &pb.ProcessedTransaction{
+		TransactionEnvelope: &common.Envelope{Payload: payloadBytes, Signature: []byte("signature")},
+		ValidationCode:      int32(pb.TxValidationCode_MVCC_READ_CONFLICT),
+	}
+}
+
+func newSerializedIdentity(t *testing.T, mspID, commonName string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return utils.MarshalOrPanic(&msp.SerializedIdentity{
+		Mspid:   mspID,
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}),
+	})
+}
@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// SetACLsRequest holds parameters for setting ACLs on a channel
+type SetACLsRequest struct {
+	ChannelID         string
+	ACLs              map[string]string     // Policies (for example, "/Channel/Application/Writers") keyed by resource (for example, "peer/Propose")
+	SigningIdentities []msp.SigningIdentity // Users that sign the config update (defaults to the client's identity)
+}
+
+// ResetACLsRequest holds parameters for resetting ACLs on a channel
+type ResetACLsRequest struct {
+	ChannelID         string
+	Resources         []string              // Resources (for example, "qscc/GetBlockByNumber") whose ACLs are removed from the channel config
+	SigningIdentities []msp.SigningIdentity // Users that sign the config update (defaults to the client's identity)
+}
+
+// SetACLs sets the policies of the given resources in the ACLs of the channel's Application group. ACLs of other
+// resources are left unchanged. The config update must be signed by the admins of the organizations as required
+// by the modification policy of the ACLs (usually a majority).
+//  Parameters:
+//  req holds info about the channel and the ACLs to set
+//  options holds optional request options
+//
+//  Returns:
+//  save channel response with transaction ID
+func (rc *Client) SetACLs(req SetACLsRequest, options ...RequestOption) (SaveChannelResponse, error) {
+
+	if len(req.ACLs) == 0 {
+		return SaveChannelResponse{}, errors.New("must provide ACLs")
+	}
+	for resource, policy := range req.ACLs {
+		if resource == "" || policy == "" {
+			return SaveChannelResponse{}, errors.Errorf("invalid ACL [%s: %s]", resource, policy)
+		}
+	}
+
+	logger.Debugf("setting ACLs on channel: %s", req.ChannelID)
+
+	return rc.updateChannelConfig(req.ChannelID, req.SigningIdentities, func(config *common.Config) error {
+		return updateACLsConfig(config, func(acls map[string]*pb.APIResource) error {
+			for resource, policy := range req.ACLs {
+				acls[resource] = &pb.APIResource{PolicyRef: policy}
+			}
+			return nil
+		})
+	}, options...)
+}
+
+// ResetACLs removes the ACLs of the given resources from the channel's Application group, so that the peers
+// apply their default policies to these resources.
+//  Parameters:
+//  req holds info about the channel and the resources whose ACLs are reset
+//  options holds optional request options
+//
+//  Returns:
+//  save channel response with transaction ID
+func (rc *Client) ResetACLs(req ResetACLsRequest, options ...RequestOption) (SaveChannelResponse, error) {
+
+	if len(req.Resources) == 0 {
+		return SaveChannelResponse{}, errors.New("must provide resources")
+	}
+
+	logger.Debugf("resetting ACLs on channel: %s", req.ChannelID)
+
+	return rc.updateChannelConfig(req.ChannelID, req.SigningIdentities, func(config *common.Config) error {
+		return updateACLsConfig(config, func(acls map[string]*pb.APIResource) error {
+			for _, resource := range req.Resources {
+				if _, ok := acls[resource]; !ok {
+					return errors.Errorf("ACL for resource %s not found", resource)
+				}
+				delete(acls, resource)
+			}
+			return nil
+		})
+	}, options...)
+}
+
+// updateACLsConfig updates the ACLs value of the Application group. The value is removed if no ACLs remain.
+func updateACLsConfig(config *common.Config, update func(acls map[string]*pb.APIResource) error) error {
+	application, err := channelGroup(config, string(fab.ApplicationGroupKey))
+	if err != nil {
+		return err
+	}
+
+	acls := &pb.ACLs{}
+	if value, ok := application.Values[channelconfig.ACLsKey]; ok {
+		if err := proto.Unmarshal(value.Value, acls); err != nil {
+			return errors.Wrap(err, "unmarshal ACLs failed")
+		}
+	}
+	if acls.Acls == nil {
+		acls.Acls = make(map[string]*pb.APIResource)
+	}
+
+	if err := update(acls.Acls); err != nil {
+		return err
+	}
+
+	if len(acls.Acls) == 0 {
+		delete(application.Values, channelconfig.ACLsKey)
+		return nil
+	}
+
+	return updateConfigValue(application, channelconfig.ACLsKey, channelconfig.AdminsPolicyKey, acls)
+}
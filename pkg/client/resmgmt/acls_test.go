@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/chconfig"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetACLsError(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	_, err := rc.SetACLs(SetACLsRequest{ChannelID: "mychannel"})
+	assert.Error(t, err, "expecting error for missing ACLs")
+
+	_, err = rc.SetACLs(SetACLsRequest{ChannelID: "mychannel", ACLs: map[string]string{"peer/Propose": ""}})
+	assert.Error(t, err, "expecting error for missing policy")
+
+	_, err = rc.ResetACLs(ResetACLsRequest{ChannelID: "mychannel"})
+	assert.Error(t, err, "expecting error for missing resources")
+
+	orderer := fcmocks.NewMockOrderer("", nil)
+	defer orderer.Close()
+	orderer.EnqueueForSendDeliver(fcmocks.NewSimpleMockError())
+
+	_, err = rc.SetACLs(SetACLsRequest{ChannelID: "mychannel", ACLs: map[string]string{"peer/Propose": "/Channel/Application/Writers"}}, WithOrderer(orderer))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "LastConfigFromOrderer failed")
+}
+
+func TestUpdateACLsConfig(t *testing.T) {
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+
+	setACLs := func(acls map[string]*pb.APIResource) error {
+		acls["peer/Propose"] = &pb.APIResource{PolicyRef: "/Channel/Application/Writers"}
+		acls["qscc/GetBlockByNumber"] = &pb.APIResource{PolicyRef: "/Channel/Application/Readers"}
+		return nil
+	}
+
+	updated := proto.Clone(config).(*common.Config)
+	require.NoError(t, updateACLsConfig(updated, setACLs))
+
+	diff, err := chconfig.Diff(config, updated)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"peer/Propose", "qscc/GetBlockByNumber"}, diff.ACLsAdded)
+	assert.Equal(t, "Admins", updated.ChannelGroup.Groups["Application"].Values["ACLs"].ModPolicy)
+
+	// Only the given ACL is updated
+	current := proto.Clone(updated).(*common.Config)
+	require.NoError(t, updateACLsConfig(updated, func(acls map[string]*pb.APIResource) error {
+		acls["qscc/GetBlockByNumber"] = &pb.APIResource{PolicyRef: "/Channel/Application/Admins"}
+		return nil
+	}))
+	diff, err = chconfig.Diff(current, updated)
+	require.NoError(t, err)
+	assert.Empty(t, diff.ACLsAdded)
+	assert.Empty(t, diff.ACLsRemoved)
+	assert.Equal(t, []string{"qscc/GetBlockByNumber"}, diff.ACLsModified)
+
+	// The ACLs value is removed when all ACLs are reset
+	require.NoError(t, updateACLsConfig(updated, func(acls map[string]*pb.APIResource) error {
+		delete(acls, "peer/Propose")
+		delete(acls, "qscc/GetBlockByNumber")
+		return nil
+	}))
+	assert.NotContains(t, updated.ChannelGroup.Groups["Application"].Values, "ACLs")
+
+	updated.ChannelGroup.Groups["Application"].Values["ACLs"] = &common.ConfigValue{Value: []byte("invalid")}
+	assert.Error(t, updateACLsConfig(updated, setACLs), "expecting error for invalid ACLs")
+
+	delete(updated.ChannelGroup.Groups, "Application")
+	assert.Error(t, updateACLsConfig(updated, setACLs), "expecting error since application group is missing")
+}
@@ -0,0 +1,320 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/chconfig"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// anchorPeersPollInterval is the interval at which the channel configuration is queried from the peers while
+// waiting for an anchor peers update to be committed
+const anchorPeersPollInterval = time.Second
+
+// AnchorPeer identifies an anchor peer of an organization
+type AnchorPeer struct {
+	Host string
+	Port int32
+}
+
+// AnchorPeersRequest holds parameters for an anchor peers update
+type AnchorPeersRequest struct {
+	ChannelID         string
+	MSPID             string                // Organization whose anchor peers are updated (defaults to the client's organization)
+	AnchorPeers       []AnchorPeer          // Anchor peers to set, add or remove
+	SigningIdentities []msp.SigningIdentity // Users that sign the config update (defaults to the client's identity)
+	Verify            bool                  // Wait until the update is committed and verify the anchor peers of the organization
+}
+
+// SetAnchorPeers replaces the anchor peers of an organization on a channel. The current channel configuration is
+// retrieved from the orderer and the config update is signed by the org admin(s) before being submitted.
+// If no anchor peers are provided then the anchor peers of the organization are removed. No config update is
+// submitted (and the response has no transaction ID) if the anchor peers don't change. If the request's Verify
+// flag is set, the call returns once the channel configuration of the peers contains the new anchor peers.
+//  Parameters:
+//  req holds info about the channel, organization and anchor peers
+//  options holds optional request options
+//
+//  Returns:
+//  save channel response with transaction ID
+func (rc *Client) SetAnchorPeers(req AnchorPeersRequest, options ...RequestOption) (SaveChannelResponse, error) {
+	return rc.updateAnchorPeers(req, func(current []*pb.AnchorPeer) []*pb.AnchorPeer {
+		var anchorPeers []*pb.AnchorPeer
+		for _, ap := range req.AnchorPeers {
+			anchorPeers = appendAnchorPeer(anchorPeers, ap)
+		}
+		return anchorPeers
+	}, options...)
+}
+
+// AddAnchorPeers adds anchor peers to an organization on a channel. Anchor peers that are already defined
+// for the organization are ignored.
+//  Parameters:
+//  req holds info about the channel, organization and anchor peers
+//  options holds optional request options
+//
+//  Returns:
+//  save channel response with transaction ID
+func (rc *Client) AddAnchorPeers(req AnchorPeersRequest, options ...RequestOption) (SaveChannelResponse, error) {
+	return rc.updateAnchorPeers(req, func(current []*pb.AnchorPeer) []*pb.AnchorPeer {
+		anchorPeers := current
+		for _, ap := range req.AnchorPeers {
+			anchorPeers = appendAnchorPeer(anchorPeers, ap)
+		}
+		return anchorPeers
+	}, options...)
+}
+
+// RemoveAnchorPeers removes anchor peers from an organization on a channel. Anchor peers that are not defined
+// for the organization are ignored.
+//  Parameters:
+//  req holds info about the channel, organization and anchor peers
+//  options holds optional request options
+//
+//  Returns:
+//  save channel response with transaction ID
+func (rc *Client) RemoveAnchorPeers(req AnchorPeersRequest, options ...RequestOption) (SaveChannelResponse, error) {
+	return rc.updateAnchorPeers(req, func(current []*pb.AnchorPeer) []*pb.AnchorPeer {
+		var anchorPeers []*pb.AnchorPeer
+		for _, ap := range current {
+			if !containsAnchorPeer(req.AnchorPeers, ap) {
+				anchorPeers = append(anchorPeers, ap)
+			}
+		}
+		return anchorPeers
+	}, options...)
+}
+
+func (rc *Client) updateAnchorPeers(req AnchorPeersRequest, update func(current []*pb.AnchorPeer) []*pb.AnchorPeer, options ...RequestOption) (SaveChannelResponse, error) {
+
+	mspID := req.MSPID
+	if mspID == "" {
+		mspID = rc.ctx.Identifier().MSPID
+	}
+
+	logger.Debugf("updating anchor peers of %s on channel: %s", mspID, req.ChannelID)
+
+	currentConfig, err := rc.queryCurrentConfig(req.ChannelID, options...)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	orgName, err := applicationOrgName(currentConfig, mspID)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	var expected []*pb.AnchorPeer
+	unchanged := false
+	updatedConfig := proto.Clone(currentConfig).(*common.Config)
+	err = updateAnchorPeersConfig(updatedConfig, mspID, func(current []*pb.AnchorPeer) []*pb.AnchorPeer {
+		expected = update(current)
+		unchanged = sameAnchorPeers(current, expected)
+		return expected
+	})
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	resp := SaveChannelResponse{}
+	if unchanged {
+		logger.Debugf("anchor peers of %s on channel %s are unchanged", mspID, req.ChannelID)
+	} else if resp, err = rc.saveAnchorPeersUpdate(req, currentConfig, updatedConfig, options...); err != nil {
+		return resp, err
+	}
+
+	if !req.Verify {
+		return resp, nil
+	}
+
+	if err := rc.waitForAnchorPeers(req.ChannelID, orgName, expected, options...); err != nil {
+		return resp, errors.WithMessage(err, "verifying anchor peers failed")
+	}
+
+	return resp, nil
+}
+
+// saveAnchorPeersUpdate submits the config update that transforms the current configuration into the updated
+// configuration
+func (rc *Client) saveAnchorPeersUpdate(req AnchorPeersRequest, currentConfig, updatedConfig *common.Config, options ...RequestOption) (SaveChannelResponse, error) {
+	envelope, err := newChannelConfigUpdateEnvelope(req.ChannelID, currentConfig, updatedConfig)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+	return rc.saveChannelConfigUpdate(req.ChannelID, envelope, req.SigningIdentities, options...)
+}
+
+// waitForAnchorPeers queries the channel configuration from the peers until the anchor peers of the organization
+// (identified by the name of its application group) are the expected anchor peers or the peer response timeout
+// expires
+func (rc *Client) waitForAnchorPeers(channelID string, orgName string, expected []*pb.AnchorPeer, options ...RequestOption) error {
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return err
+	}
+
+	targets, err := rc.getCCProposalTargets(channelID, opts)
+	if err != nil {
+		return err
+	}
+
+	channelConfig, err := chconfig.New(channelID, chconfig.WithPeers(targets))
+	if err != nil {
+		return errors.WithMessage(err, "channel config creation failed")
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.PeerResponse)
+	defer cancel()
+
+	for {
+		cfg, err := channelConfig.Query(reqCtx)
+		if err != nil {
+			return errors.WithMessage(err, "querying channel config from peers failed")
+		}
+
+		if sameAnchorPeers(cfgAnchorPeers(cfg, orgName), expected) {
+			return nil
+		}
+
+		select {
+		case <-reqCtx.Done():
+			return errors.New("timed out waiting for anchor peers update to be committed")
+		case <-time.After(anchorPeersPollInterval):
+		}
+	}
+}
+
+// updateAnchorPeersConfig updates the AnchorPeers value of the given organization's application group
+func updateAnchorPeersConfig(config *common.Config, mspID string, update func(current []*pb.AnchorPeer) []*pb.AnchorPeer) error {
+	org, err := applicationOrgGroup(config, mspID)
+	if err != nil {
+		return err
+	}
+
+	anchorPeers, err := orgAnchorPeers(org)
+	if err != nil {
+		return err
+	}
+
+	value, ok := org.Values[channelconfig.AnchorPeersKey]
+	if !ok {
+		value = &common.ConfigValue{ModPolicy: channelconfig.AdminsPolicyKey}
+	}
+
+	anchorPeers.AnchorPeers = update(anchorPeers.AnchorPeers)
+	if len(anchorPeers.AnchorPeers) == 0 {
+		delete(org.Values, channelconfig.AnchorPeersKey)
+		return nil
+	}
+
+	value.Value, err = proto.Marshal(anchorPeers)
+	if err != nil {
+		return errors.Wrap(err, "marshal anchor peers failed")
+	}
+
+	if org.Values == nil {
+		org.Values = make(map[string]*common.ConfigValue)
+	}
+	org.Values[channelconfig.AnchorPeersKey] = value
+
+	return nil
+}
+
+// applicationOrgGroup returns the application group of the given organization
+func applicationOrgGroup(config *common.Config, mspID string) (*common.ConfigGroup, error) {
+	if config.ChannelGroup == nil {
+		return nil, errors.New("channel group not found in config")
+	}
+
+	org, err := orgGroup(config.ChannelGroup.Groups[string(fab.ApplicationGroupKey)], mspID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "application organization lookup failed")
+	}
+	return org, nil
+}
+
+// applicationOrgName returns the name of the application group of the given organization
+func applicationOrgName(config *common.Config, mspID string) (string, error) {
+	org, err := applicationOrgGroup(config, mspID)
+	if err != nil {
+		return "", err
+	}
+
+	for name, group := range config.ChannelGroup.Groups[string(fab.ApplicationGroupKey)].Groups {
+		if group == org {
+			return name, nil
+		}
+	}
+	return "", errors.Errorf("application organization %s not found", mspID)
+}
+
+// cfgAnchorPeers returns the anchor peers of the organization (identified by the name of its application group) in
+// the given channel configuration
+func cfgAnchorPeers(cfg fab.ChannelCfg, orgName string) []*pb.AnchorPeer {
+	var anchorPeers []*pb.AnchorPeer
+	for _, ap := range cfg.AnchorPeers() {
+		if ap.Org == orgName {
+			anchorPeers = append(anchorPeers, &pb.AnchorPeer{Host: ap.Host, Port: ap.Port})
+		}
+	}
+	return anchorPeers
+}
+
+// orgAnchorPeers returns the AnchorPeers value of the given organization group (empty if the value isn't set)
+func orgAnchorPeers(org *common.ConfigGroup) (*pb.AnchorPeers, error) {
+	anchorPeers := &pb.AnchorPeers{}
+	if value, ok := org.Values[channelconfig.AnchorPeersKey]; ok {
+		if err := proto.Unmarshal(value.Value, anchorPeers); err != nil {
+			return nil, errors.Wrap(err, "unmarshal anchor peers failed")
+		}
+	}
+	return anchorPeers, nil
+}
+
+// sameAnchorPeers returns true if both lists contain the same anchor peers, regardless of their order
+func sameAnchorPeers(anchorPeers []*pb.AnchorPeer, expected []*pb.AnchorPeer) bool {
+	if len(anchorPeers) != len(expected) {
+		return false
+	}
+
+	expectedPeers := make([]AnchorPeer, len(expected))
+	for i, ap := range expected {
+		expectedPeers[i] = AnchorPeer{Host: ap.Host, Port: ap.Port}
+	}
+	for _, ap := range anchorPeers {
+		if !containsAnchorPeer(expectedPeers, ap) {
+			return false
+		}
+	}
+	return true
+}
+
+func appendAnchorPeer(anchorPeers []*pb.AnchorPeer, ap AnchorPeer) []*pb.AnchorPeer {
+	for _, existing := range anchorPeers {
+		if existing.Host == ap.Host && existing.Port == ap.Port {
+			return anchorPeers
+		}
+	}
+	return append(anchorPeers, &pb.AnchorPeer{Host: ap.Host, Port: ap.Port})
+}
+
+func containsAnchorPeer(anchorPeers []AnchorPeer, ap *pb.AnchorPeer) bool {
+	for _, a := range anchorPeers {
+		if a.Host == ap.Host && a.Port == ap.Port {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	reqContext "context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAnchorPeersError(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	_, err := rc.SetAnchorPeers(AnchorPeersRequest{AnchorPeers: []AnchorPeer{{Host: "peer0.org1.example.com", Port: 7051}}})
+	assert.Error(t, err, "expecting error for missing channel ID")
+
+	orderer := fcmocks.NewMockOrderer("", nil)
+	defer orderer.Close()
+	orderer.EnqueueForSendDeliver(fcmocks.NewSimpleMockError())
+
+	_, err = rc.AddAnchorPeers(AnchorPeersRequest{ChannelID: "mychannel", AnchorPeers: []AnchorPeer{{Host: "peer0.org1.example.com", Port: 7051}}}, WithOrderer(orderer))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "LastConfigFromOrderer failed")
+}
+
+func TestUpdateAnchorPeersConfig(t *testing.T) {
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+
+	peer0 := AnchorPeer{Host: "peer0.org1.example.com", Port: 7051}
+	peer1 := AnchorPeer{Host: "peer1.org1.example.com", Port: 7051}
+
+	set := func(current []*pb.AnchorPeer) []*pb.AnchorPeer {
+		return appendAnchorPeer(appendAnchorPeer(nil, peer0), peer1)
+	}
+	updated := proto.Clone(config).(*common.Config)
+	require.NoError(t, updateAnchorPeersConfig(updated, "Org1MSP", set))
+	assert.Equal(t, []*pb.AnchorPeer{{Host: peer0.Host, Port: peer0.Port}, {Host: peer1.Host, Port: peer1.Port}}, anchorPeersFromConfig(t, updated, "Org1MSP"))
+	assert.Empty(t, anchorPeersFromConfig(t, updated, "Org2MSP"))
+
+	// Only the anchor peers of Org1MSP are in the write set
+	configUpdate, err := resource.ComputeConfigUpdate("mychannel", config, updated)
+	require.NoError(t, err)
+	org1 := configUpdate.WriteSet.Groups["Application"].Groups["Org1MSP"]
+	require.NotNil(t, org1)
+	assert.Contains(t, org1.Values, "AnchorPeers")
+	assert.Equal(t, "Admins", org1.Values["AnchorPeers"].ModPolicy)
+	assert.NotContains(t, configUpdate.WriteSet.Groups["Application"].Groups, "Org2MSP")
+
+	remove := func(current []*pb.AnchorPeer) []*pb.AnchorPeer {
+		var anchorPeers []*pb.AnchorPeer
+		for _, ap := range current {
+			if !containsAnchorPeer([]AnchorPeer{peer0}, ap) {
+				anchorPeers = append(anchorPeers, ap)
+			}
+		}
+		return anchorPeers
+	}
+	require.NoError(t, updateAnchorPeersConfig(updated, "Org1MSP", remove))
+	assert.Equal(t, []*pb.AnchorPeer{{Host: peer1.Host, Port: peer1.Port}}, anchorPeersFromConfig(t, updated, "Org1MSP"))
+
+	// Removing all anchor peers removes the value
+	require.NoError(t, updateAnchorPeersConfig(updated, "Org1MSP", func([]*pb.AnchorPeer) []*pb.AnchorPeer { return nil }))
+	assert.NotContains(t, updated.ChannelGroup.Groups["Application"].Groups["Org1MSP"].Values, "AnchorPeers")
+
+	_, err = resource.ComputeConfigUpdate("mychannel", config, updated)
+	assert.Error(t, err, "expecting error since config is unchanged")
+
+	err = updateAnchorPeersConfig(updated, "Org3MSP", set)
+	assert.Error(t, err, "expecting error for unknown organization")
+}
+
+func TestUpdateAnchorPeersConfigByMSPName(t *testing.T) {
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+
+	// Organization groups are not necessarily named after the MSP ID
+	application := config.ChannelGroup.Groups["Application"]
+	application.Groups["Org1"] = application.Groups["Org1MSP"]
+	delete(application.Groups, "Org1MSP")
+
+	peer0 := AnchorPeer{Host: "peer0.org1.example.com", Port: 7051}
+	err = updateAnchorPeersConfig(config, "Org1MSP", func(current []*pb.AnchorPeer) []*pb.AnchorPeer {
+		return appendAnchorPeer(current, peer0)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.AnchorPeer{{Host: peer0.Host, Port: peer0.Port}}, anchorPeersFromConfig(t, config, "Org1"))
+}
+
+func TestUpdateAnchorPeersUnchanged(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+
+	peer0 := AnchorPeer{Host: "peer0.org1.example.com", Port: 7051}
+	peer1 := AnchorPeer{Host: "peer1.org1.example.com", Port: 7051}
+	require.NoError(t, updateAnchorPeersConfig(config, "Org1MSP", func([]*pb.AnchorPeer) []*pb.AnchorPeer {
+		return appendAnchorPeer(nil, peer0)
+	}))
+
+	// The orderer doesn't accept config updates, so no update must be submitted
+	orderer := &configOrderer{t: t, configs: []*common.Config{config}}
+
+	resp, err := rc.AddAnchorPeers(AnchorPeersRequest{ChannelID: "mychannel", AnchorPeers: []AnchorPeer{peer0}}, WithOrderer(orderer))
+	assert.NoError(t, err, "adding an existing anchor peer should be a no-op")
+	assert.Empty(t, resp.TransactionID)
+
+	resp, err = rc.RemoveAnchorPeers(AnchorPeersRequest{ChannelID: "mychannel", AnchorPeers: []AnchorPeer{peer1}}, WithOrderer(orderer))
+	assert.NoError(t, err, "removing a missing anchor peer should be a no-op")
+	assert.Empty(t, resp.TransactionID)
+
+	resp, err = rc.SetAnchorPeers(AnchorPeersRequest{ChannelID: "mychannel", AnchorPeers: []AnchorPeer{peer0}}, WithOrderer(orderer))
+	assert.NoError(t, err, "setting the same anchor peers should be a no-op")
+	assert.Empty(t, resp.TransactionID)
+
+	_, err = rc.AddAnchorPeers(AnchorPeersRequest{ChannelID: "mychannel", AnchorPeers: []AnchorPeer{peer1}}, WithOrderer(orderer))
+	assert.Error(t, err, "expecting the config update to be submitted")
+}
+
+func TestWaitForAnchorPeers(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+
+	peer0 := &pb.AnchorPeer{Host: "peer0.org1.example.com", Port: 7051}
+	updated := proto.Clone(config).(*common.Config)
+	require.NoError(t, updateAnchorPeersConfig(updated, "Org1MSP", func([]*pb.AnchorPeer) []*pb.AnchorPeer {
+		return []*pb.AnchorPeer{peer0}
+	}))
+
+	// The update is committed after the first query
+	peer := newConfigPeer(t, config, updated)
+	assert.NoError(t, rc.waitForAnchorPeers("mychannel", "Org1MSP", []*pb.AnchorPeer{peer0}, WithTargets(peer)))
+	assert.Equal(t, 2, peer.ProcessProposalCalls, "expecting two queries")
+
+	// The update isn't committed
+	peer = newConfigPeer(t, config)
+	err = rc.waitForAnchorPeers("mychannel", "Org1MSP", []*pb.AnchorPeer{peer0}, WithTargets(peer), WithTimeout(fab.PeerResponse, 100*time.Millisecond))
+	assert.EqualError(t, err, "timed out waiting for anchor peers update to be committed")
+
+	// Removing all the anchor peers is verified too
+	peer = newConfigPeer(t, config)
+	assert.NoError(t, rc.waitForAnchorPeers("mychannel", "Org1MSP", nil, WithTargets(peer)))
+
+	peer = newConfigPeer(t, config)
+	peer.Error = errors.New("query failed")
+	err = rc.waitForAnchorPeers("mychannel", "Org1MSP", nil, WithTargets(peer))
+	assert.Error(t, err)
+}
+
+func TestSameAnchorPeers(t *testing.T) {
+	peer0 := &pb.AnchorPeer{Host: "peer0", Port: 7051}
+	peer1 := &pb.AnchorPeer{Host: "peer1", Port: 7051}
+	assert.True(t, sameAnchorPeers(nil, nil))
+	assert.True(t, sameAnchorPeers([]*pb.AnchorPeer{peer0, peer1}, []*pb.AnchorPeer{peer1, peer0}))
+	assert.False(t, sameAnchorPeers([]*pb.AnchorPeer{peer0}, []*pb.AnchorPeer{peer0, peer1}))
+	assert.False(t, sameAnchorPeers([]*pb.AnchorPeer{peer0}, []*pb.AnchorPeer{{Host: "peer0", Port: 8051}}))
+}
+
+func TestAppendAnchorPeer(t *testing.T) {
+	anchorPeers := appendAnchorPeer(nil, AnchorPeer{Host: "peer0", Port: 7051})
+	anchorPeers = appendAnchorPeer(anchorPeers, AnchorPeer{Host: "peer0", Port: 7051})
+	anchorPeers = appendAnchorPeer(anchorPeers, AnchorPeer{Host: "peer0", Port: 8051})
+	assert.Equal(t, []*pb.AnchorPeer{{Host: "peer0", Port: 7051}, {Host: "peer0", Port: 8051}}, anchorPeers)
+}
+
+func anchorPeersFromConfig(t *testing.T, config *common.Config, org string) []*pb.AnchorPeer {
+	value, ok := config.ChannelGroup.Groups["Application"].Groups[org].Values["AnchorPeers"]
+	if !ok {
+		return nil
+	}
+
+	anchorPeers := &pb.AnchorPeers{}
+	require.NoError(t, proto.Unmarshal(value.Value, anchorPeers))
+	return anchorPeers.AnchorPeers
+}
+
+// configPeer is a peer that returns the given channel configurations in turn (the last one indefinitely) when
+// its config block is queried
+type configPeer struct {
+	*fcmocks.MockPeer
+	t       *testing.T
+	configs []*common.Config
+}
+
+func newConfigPeer(t *testing.T, configs ...*common.Config) *configPeer {
+	return &configPeer{
+		MockPeer: &fcmocks.MockPeer{MockName: "Peer1", MockURL: "peer1.org1.example.com:7051", MockMSP: "Org1MSP", Status: 200},
+		t:        t,
+		configs:  configs,
+	}
+}
+
+func (p *configPeer) ProcessTransactionProposal(ctx reqContext.Context, tp fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	payload, err := proto.Marshal(configBlock(p.t, p.configs[0]))
+	require.NoError(p.t, err)
+	p.Payload = payload
+	if len(p.configs) > 1 {
+		p.configs = p.configs[1:]
+	}
+	return p.MockPeer.ProcessTransactionProposal(ctx, tp)
+}
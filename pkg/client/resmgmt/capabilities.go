@@ -0,0 +1,472 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	reqContext "context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	discclient "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	fabdiscovery "github.com/hyperledger/fabric-sdk-go/pkg/fab/discovery"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// capabilitiesPollInterval is the interval at which the channel configuration is polled while waiting
+// for a capabilities update to be committed
+const capabilitiesPollInterval = time.Second
+
+// capabilityVersions contains the capabilities known to this SDK, per config group, along with the minimum
+// Fabric version (major.minor) that supports them. Enabling a capability that isn't supported by a peer or
+// orderer causes it to stop processing the channel, so unknown capabilities are rejected.
+var capabilityVersions = map[fab.ConfigGroupKey]map[string]string{
+	fab.ChannelGroupKey: {
+		fab.V1_1Capability: "1.1",
+	},
+	fab.OrdererGroupKey: {
+		fab.V1_1Capability: "1.1",
+	},
+	fab.ApplicationGroupKey: {
+		fab.V1_1Capability:               "1.1",
+		fab.V1_2Capability:               "1.2",
+		"V1_1_PVTDATA_EXPERIMENTAL":      "1.1",
+		"V1_1_RESOURCETREE_EXPERIMENTAL": "1.1",
+	},
+}
+
+// PeerVersionResolver returns the Fabric version (for example, "1.2.0") of the given peer
+type PeerVersionResolver func(peer fab.Peer) (string, error)
+
+type discoveryClient interface {
+	Send(ctx reqContext.Context, req *discclient.Request, targets ...fab.PeerConfig) ([]fabdiscovery.Response, error)
+}
+
+// discoveryClientProvider is overridden by unit tests
+var discoveryClientProvider = func(ctx context.Client) (discoveryClient, error) {
+	return fabdiscovery.New(ctx)
+}
+
+// CapabilitiesRequest holds parameters for enabling capabilities on a channel
+type CapabilitiesRequest struct {
+	ChannelID               string
+	ChannelCapabilities     []string              // Capabilities to enable in the Channel group
+	OrdererCapabilities     []string              // Capabilities to enable in the Orderer group
+	ApplicationCapabilities []string              // Capabilities to enable in the Application group
+	SystemChannelID         string                // Orderer system channel, whose Channel and Orderer capabilities are enabled first (optional)
+	PeerVersion             PeerVersionResolver   // Resolves the versions of the peers (defaults to the versions advertised in the channel membership)
+	SkipPeerVersionCheck    bool                  // Enables the capabilities without checking the versions of the channel's peers
+	SigningIdentities       []msp.SigningIdentity // Orderer and org admins that sign the config updates (defaults to the client's identity)
+}
+
+// CapabilitiesUpdate identifies a config update that enabled capabilities in a config group
+type CapabilitiesUpdate struct {
+	ChannelID     string
+	Group         fab.ConfigGroupKey
+	TransactionID fab.TransactionID
+}
+
+// CapabilitiesResponse contains the config updates that were submitted, in order
+type CapabilitiesResponse struct {
+	Updates []CapabilitiesUpdate
+}
+
+// capabilitiesStep is a config update that enables capabilities in a single config group of a channel
+type capabilitiesStep struct {
+	channelID    string
+	group        fab.ConfigGroupKey
+	capabilities []string
+}
+
+// UpgradeCapabilities enables capabilities at the Channel, Orderer and Application levels of a channel.
+// The capabilities are validated and the versions of the channel's peers are checked before any update is made,
+// unless the check is skipped. By default the peers of the channel membership reported by the discovery service
+// are checked. If a peer version resolver is provided, the targets provided in the options (or the peers returned
+// by the channel's discovery service) are checked instead.
+// The updates are then submitted one config group at a time: the system channel's Orderer and Channel groups
+// (if a system channel is provided) followed by the channel's Orderer, Channel and Application groups. Each update
+// is committed before the next one is submitted. Groups that already have the capabilities are skipped, so an
+// upgrade that failed part way through may be resumed by repeating the request.
+//  Parameters:
+//  req holds info about the channel and the capabilities to enable
+//  options holds optional request options
+//
+//  Returns:
+//  the config updates that were submitted
+func (rc *Client) UpgradeCapabilities(req CapabilitiesRequest, options ...RequestOption) (CapabilitiesResponse, error) {
+
+	steps, err := capabilitiesSteps(req)
+	if err != nil {
+		return CapabilitiesResponse{}, err
+	}
+
+	if !req.SkipPeerVersionCheck && (len(req.ChannelCapabilities) > 0 || len(req.ApplicationCapabilities) > 0) {
+		if err := rc.verifyPeerVersions(req, options...); err != nil {
+			return CapabilitiesResponse{}, err
+		}
+	}
+
+	var resp CapabilitiesResponse
+	for _, step := range steps {
+		txID, err := rc.enableCapabilities(step, req.SigningIdentities, options...)
+		if err != nil {
+			return resp, errors.WithMessage(err, fmt.Sprintf("enabling capabilities %s in group [%s] of channel %s failed", step.capabilities, step.group, step.channelID))
+		}
+		if txID != "" {
+			resp.Updates = append(resp.Updates, CapabilitiesUpdate{ChannelID: step.channelID, Group: step.group, TransactionID: txID})
+		}
+	}
+
+	return resp, nil
+}
+
+// capabilitiesSteps validates the requested capabilities and returns the config updates in the order in which
+// they must be applied
+func capabilitiesSteps(req CapabilitiesRequest) ([]capabilitiesStep, error) {
+	if req.ChannelID == "" {
+		return nil, errors.New("must provide channel ID")
+	}
+
+	requested := map[fab.ConfigGroupKey][]string{
+		fab.ChannelGroupKey:     req.ChannelCapabilities,
+		fab.OrdererGroupKey:     req.OrdererCapabilities,
+		fab.ApplicationGroupKey: req.ApplicationCapabilities,
+	}
+
+	empty := true
+	for group, capabilities := range requested {
+		for _, capability := range capabilities {
+			if _, ok := capabilityVersions[group][capability]; !ok {
+				return nil, errors.Errorf("capability %s is not supported in group [%s]", capability, group)
+			}
+			empty = false
+		}
+	}
+	if empty {
+		return nil, errors.New("no capabilities to enable")
+	}
+
+	var steps []capabilitiesStep
+	addStep := func(channelID string, group fab.ConfigGroupKey) {
+		if len(requested[group]) > 0 {
+			steps = append(steps, capabilitiesStep{channelID: channelID, group: group, capabilities: requested[group]})
+		}
+	}
+
+	// The system channel doesn't have an Application group
+	if req.SystemChannelID != "" && req.SystemChannelID != req.ChannelID {
+		addStep(req.SystemChannelID, fab.OrdererGroupKey)
+		addStep(req.SystemChannelID, fab.ChannelGroupKey)
+	}
+	addStep(req.ChannelID, fab.OrdererGroupKey)
+	addStep(req.ChannelID, fab.ChannelGroupKey)
+	addStep(req.ChannelID, fab.ApplicationGroupKey)
+
+	return steps, nil
+}
+
+// verifyPeerVersions checks that the peers of the channel support the requested Channel and Application capabilities
+func (rc *Client) verifyPeerVersions(req CapabilitiesRequest, options ...RequestOption) error {
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return err
+	}
+
+	var versions map[string]string
+	if req.PeerVersion == nil {
+		versions, err = rc.membershipPeerVersions(req.ChannelID, opts)
+	} else {
+		versions, err = rc.resolvePeerVersions(req.ChannelID, req.PeerVersion, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(versions) == 0 {
+		return errors.Errorf("no peers found for channel %s", req.ChannelID)
+	}
+
+	return checkPeerVersions(versions, requiredPeerVersion(req))
+}
+
+// requiredPeerVersion returns the minimum peer version that supports the requested Channel and Application
+// capabilities
+func requiredPeerVersion(req CapabilitiesRequest) string {
+	required := "1.0"
+	for group, capabilities := range map[fab.ConfigGroupKey][]string{fab.ChannelGroupKey: req.ChannelCapabilities, fab.ApplicationGroupKey: req.ApplicationCapabilities} {
+		for _, capability := range capabilities {
+			version := capabilityVersions[group][capability]
+			if compareVersions(version, required) > 0 {
+				required = version
+			}
+		}
+	}
+	return required
+}
+
+// checkPeerVersions checks that the versions of the peers, keyed by URL, are the required version or later
+func checkPeerVersions(versions map[string]string, required string) error {
+	urls := make([]string, 0, len(versions))
+	for url := range versions {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+
+	for _, url := range urls {
+		if compareVersions(versions[url], required) < 0 {
+			return errors.Errorf("peer %s has version %s but version %s or later is required", url, versions[url], required)
+		}
+	}
+
+	return nil
+}
+
+// resolvePeerVersions returns the versions, keyed by URL, of the targets (or the peers returned by the channel's
+// discovery service) using the given resolver
+func (rc *Client) resolvePeerVersions(channelID string, resolver PeerVersionResolver, opts requestOptions) (map[string]string, error) {
+	peers := opts.Targets
+	if len(peers) == 0 {
+		chCtx, err := contextImpl.NewChannel(
+			func() (context.Client, error) {
+				return rc.ctx, nil
+			},
+			channelID,
+		)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to create channel context")
+		}
+
+		peers, err = rc.getDefaultTargets(chCtx.DiscoveryService())
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to get channel peers")
+		}
+	}
+
+	versions := make(map[string]string)
+	for _, peer := range peers {
+		version, err := resolver(peer)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed to get version of peer %s", peer.URL()))
+		}
+		versions[peer.URL()] = version
+	}
+	return versions, nil
+}
+
+// membershipPeerVersions returns the versions, keyed by endpoint, of the peers in the channel membership reported
+// by the discovery service of the targets (or of the channel's peers in the config). Peers advertise their
+// properties (ledger height, etc.) in the membership since Fabric 1.2, so the peers that don't are reported as
+// Fabric 1.1 peers (earlier versions can't be told apart).
+func (rc *Client) membershipPeerVersions(channelID string, opts requestOptions) (map[string]string, error) {
+	targets, err := rc.discoveryTargets(channelID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := discoveryClientProvider(rc.ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create discovery client")
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.DiscoveryResponse)
+	defer cancel()
+
+	responses, err := client.Send(reqCtx, discclient.NewRequest().OfChannel(channelID).AddPeersQuery(), targets...)
+	if len(responses) == 0 {
+		if err == nil {
+			err = errors.New("no response received")
+		}
+		return nil, errors.WithMessage(err, "failed to query channel membership")
+	}
+
+	for _, response := range responses {
+		members, err := response.ForChannel(channelID).Peers()
+		if err != nil {
+			logger.Warnf("error getting channel membership from discovery response of %s: %s", response.Target(), err)
+			continue
+		}
+
+		versions := make(map[string]string)
+		for _, member := range members {
+			versions[member.AliveMessage.GetAliveMsg().Membership.Endpoint] = membershipVersion(member)
+		}
+		return versions, nil
+	}
+
+	return nil, errors.New("no valid channel membership received from discovery service")
+}
+
+// discoveryTargets returns the configs of the targets or, if there are no targets, of the channel's peers in the
+// config
+func (rc *Client) discoveryTargets(channelID string, opts requestOptions) ([]fab.PeerConfig, error) {
+	var targets []fab.PeerConfig
+	for _, peer := range opts.Targets {
+		peerConfig, ok := rc.ctx.EndpointConfig().PeerConfig(peer.URL())
+		if !ok {
+			return nil, errors.Errorf("peer config not found for target %s", peer.URL())
+		}
+		targets = append(targets, *peerConfig)
+	}
+	if len(targets) == 0 {
+		channelPeers, ok := rc.ctx.EndpointConfig().ChannelPeers(channelID)
+		if !ok {
+			return nil, errors.Errorf("failed to get peer configs for channel %s", channelID)
+		}
+		for _, channelPeer := range channelPeers {
+			targets = append(targets, channelPeer.NetworkPeer.PeerConfig)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, errors.Errorf("no peers configured for channel %s", channelID)
+	}
+	return targets, nil
+}
+
+// membershipVersion returns the minimum Fabric version (major.minor) of a member of the channel
+func membershipVersion(member *discclient.Peer) string {
+	if member.StateInfoMessage != nil && member.StateInfoMessage.GetStateInfo().GetProperties() != nil {
+		return "1.2"
+	}
+	return "1.1"
+}
+
+// enableCapabilities enables the capabilities of the given step that aren't enabled yet and waits until the
+// config update is committed. An empty transaction ID is returned if no update was required.
+func (rc *Client) enableCapabilities(step capabilitiesStep, signingIdentities []msp.SigningIdentity, options ...RequestOption) (fab.TransactionID, error) {
+
+	cfg, err := rc.QueryConfigFromOrderer(step.channelID, options...)
+	if err != nil {
+		return "", err
+	}
+
+	pending := missingCapabilities(cfg, step.group, step.capabilities)
+	if len(pending) == 0 {
+		logger.Debugf("capabilities %s are already enabled in group [%s] of channel %s", step.capabilities, step.group, step.channelID)
+		return "", nil
+	}
+
+	logger.Debugf("enabling capabilities %s in group [%s] of channel %s", pending, step.group, step.channelID)
+
+	resp, err := rc.updateChannelConfig(step.channelID, signingIdentities, func(config *common.Config) error {
+		return enableCapabilitiesConfig(config, step.group, pending)
+	}, options...)
+	if err != nil {
+		return "", err
+	}
+
+	if err := rc.waitForCapabilities(step, options...); err != nil {
+		return resp.TransactionID, err
+	}
+
+	return resp.TransactionID, nil
+}
+
+// waitForCapabilities polls the channel configuration until the capabilities are enabled
+// or the orderer response timeout expires
+func (rc *Client) waitForCapabilities(step capabilitiesStep, options ...RequestOption) error {
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.OrdererResponse)
+	defer cancel()
+
+	for {
+		cfg, err := rc.QueryConfigFromOrderer(step.channelID, options...)
+		if err != nil {
+			return err
+		}
+		if len(missingCapabilities(cfg, step.group, step.capabilities)) == 0 {
+			return nil
+		}
+
+		select {
+		case <-reqCtx.Done():
+			return errors.New("timed out waiting for capabilities update to be committed")
+		case <-time.After(capabilitiesPollInterval):
+		}
+	}
+}
+
+func missingCapabilities(cfg fab.ChannelCfg, group fab.ConfigGroupKey, capabilities []string) []string {
+	var missing []string
+	for _, capability := range capabilities {
+		if !cfg.HasCapability(group, capability) {
+			missing = append(missing, capability)
+		}
+	}
+	return missing
+}
+
+// enableCapabilitiesConfig adds the given capabilities to the Capabilities value of the given config group
+func enableCapabilitiesConfig(config *common.Config, groupKey fab.ConfigGroupKey, capabilities []string) error {
+	group := config.ChannelGroup
+	if groupKey != fab.ChannelGroupKey {
+		var err error
+		group, err = channelGroup(config, string(groupKey))
+		if err != nil {
+			return err
+		}
+	} else if group == nil {
+		return errors.New("channel group not found in config")
+	}
+
+	current := &common.Capabilities{}
+	if value, ok := group.Values[channelconfig.CapabilitiesKey]; ok {
+		if err := proto.Unmarshal(value.Value, current); err != nil {
+			return errors.Wrap(err, "unmarshal capabilities failed")
+		}
+	}
+	if current.Capabilities == nil {
+		current.Capabilities = make(map[string]*common.Capability)
+	}
+
+	for _, capability := range capabilities {
+		current.Capabilities[capability] = &common.Capability{}
+	}
+
+	return updateConfigValue(group, channelconfig.CapabilitiesKey, channelconfig.AdminsPolicyKey, current)
+}
+
+// compareVersions compares the major and minor numbers of two Fabric versions (for example, "1.2.0" or
+// "v1.1.0-rc1"), returning a negative number, zero or a positive number if v1 is respectively lower than,
+// equal to or greater than v2. Parts that are not numeric are treated as zero.
+func compareVersions(v1, v2 string) int {
+	p1 := versionParts(v1)
+	p2 := versionParts(v2)
+	for i := range p1 {
+		if p1[i] != p2[i] {
+			return p1[i] - p2[i]
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) [2]int {
+	var parts [2]int
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+ "); i >= 0 {
+		version = version[:i]
+	}
+	for i, s := range strings.SplitN(version, ".", 3) {
+		if i >= len(parts) {
+			break
+		}
+		parts[i], _ = strconv.Atoi(s)
+	}
+	return parts
+}
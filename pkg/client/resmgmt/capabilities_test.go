@@ -0,0 +1,223 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	reqContext "context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	dyndiscmocks "github.com/hyperledger/fabric-sdk-go/pkg/client/common/discovery/dynamicdiscovery/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/chconfig"
+	discmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/discovery/mocks"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilitiesSteps(t *testing.T) {
+	req := CapabilitiesRequest{
+		ChannelID:               "mychannel",
+		SystemChannelID:         "testchainid",
+		ChannelCapabilities:     []string{fab.V1_1Capability},
+		OrdererCapabilities:     []string{fab.V1_1Capability},
+		ApplicationCapabilities: []string{fab.V1_2Capability},
+	}
+
+	steps, err := capabilitiesSteps(req)
+	require.NoError(t, err)
+	assert.Equal(t, []capabilitiesStep{
+		{channelID: "testchainid", group: fab.OrdererGroupKey, capabilities: []string{fab.V1_1Capability}},
+		{channelID: "testchainid", group: fab.ChannelGroupKey, capabilities: []string{fab.V1_1Capability}},
+		{channelID: "mychannel", group: fab.OrdererGroupKey, capabilities: []string{fab.V1_1Capability}},
+		{channelID: "mychannel", group: fab.ChannelGroupKey, capabilities: []string{fab.V1_1Capability}},
+		{channelID: "mychannel", group: fab.ApplicationGroupKey, capabilities: []string{fab.V1_2Capability}},
+	}, steps)
+
+	// Only the groups with capabilities are updated
+	steps, err = capabilitiesSteps(CapabilitiesRequest{ChannelID: "mychannel", SystemChannelID: "testchainid", ApplicationCapabilities: []string{fab.V1_2Capability}})
+	require.NoError(t, err)
+	assert.Equal(t, []capabilitiesStep{{channelID: "mychannel", group: fab.ApplicationGroupKey, capabilities: []string{fab.V1_2Capability}}}, steps)
+
+	_, err = capabilitiesSteps(CapabilitiesRequest{ApplicationCapabilities: []string{fab.V1_2Capability}})
+	assert.Error(t, err, "expecting error for missing channel ID")
+
+	_, err = capabilitiesSteps(CapabilitiesRequest{ChannelID: "mychannel"})
+	assert.Error(t, err, "expecting error since there are no capabilities to enable")
+
+	_, err = capabilitiesSteps(CapabilitiesRequest{ChannelID: "mychannel", OrdererCapabilities: []string{fab.V1_2Capability}})
+	assert.Error(t, err, "expecting error since V1_2 is not an orderer capability")
+}
+
+func TestEnableCapabilitiesConfig(t *testing.T) {
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+
+	updated := proto.Clone(config).(*common.Config)
+	require.NoError(t, enableCapabilitiesConfig(updated, fab.ChannelGroupKey, []string{fab.V1_1Capability}))
+	require.NoError(t, enableCapabilitiesConfig(updated, fab.ApplicationGroupKey, []string{fab.V1_1Capability, fab.V1_2Capability}))
+
+	diff, err := chconfig.Diff(config, updated)
+	require.NoError(t, err)
+	assert.Equal(t, []string{fab.V1_1Capability}, diff.CapabilitiesAdded[fab.ChannelGroupKey])
+	assert.Equal(t, []string{fab.V1_1Capability, fab.V1_2Capability}, diff.CapabilitiesAdded[fab.ApplicationGroupKey])
+	assert.NotContains(t, diff.CapabilitiesAdded, fab.OrdererGroupKey)
+	assert.Empty(t, diff.CapabilitiesRemoved)
+
+	// Existing capabilities are retained
+	require.NoError(t, enableCapabilitiesConfig(updated, fab.ApplicationGroupKey, []string{"V1_1_PVTDATA_EXPERIMENTAL"}))
+	cfg, err := chconfig.ExtractConfigFromBlock("mychannel", configBlock(t, updated))
+	require.NoError(t, err)
+	assert.True(t, cfg.HasCapability(fab.ApplicationGroupKey, fab.V1_2Capability))
+	assert.True(t, cfg.HasCapability(fab.ApplicationGroupKey, "V1_1_PVTDATA_EXPERIMENTAL"))
+	assert.Empty(t, missingCapabilities(cfg, fab.ApplicationGroupKey, []string{fab.V1_1Capability, fab.V1_2Capability}))
+	assert.Equal(t, []string{fab.V1_1Capability}, missingCapabilities(cfg, fab.OrdererGroupKey, []string{fab.V1_1Capability}))
+
+	delete(updated.ChannelGroup.Groups, "Application")
+	assert.Error(t, enableCapabilitiesConfig(updated, fab.ApplicationGroupKey, []string{fab.V1_2Capability}), "expecting error since application group is missing")
+}
+
+func TestVerifyPeerVersions(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	peer1 := fcmocks.NewMockPeer("peer1", "peer1.example.com:7051")
+	peer2 := fcmocks.NewMockPeer("peer2", "peer2.example.com:7051")
+	versions := map[string]string{peer1.URL(): "1.2.0", peer2.URL(): "v1.1.1"}
+	resolver := func(peer fab.Peer) (string, error) {
+		version, ok := versions[peer.URL()]
+		if !ok {
+			return "", errors.New("unknown peer")
+		}
+		return version, nil
+	}
+
+	req := CapabilitiesRequest{ChannelID: "mychannel", ApplicationCapabilities: []string{fab.V1_1Capability}, PeerVersion: resolver}
+	assert.NoError(t, rc.verifyPeerVersions(req, WithTargets(peer1, peer2)))
+
+	req.ApplicationCapabilities = []string{fab.V1_2Capability}
+	err := rc.verifyPeerVersions(req, WithTargets(peer1, peer2))
+	assert.Error(t, err, "expecting error since peer2 doesn't support V1_2")
+	assert.Contains(t, err.Error(), "peer2.example.com:7051")
+
+	err = rc.verifyPeerVersions(req, WithTargets(fcmocks.NewMockPeer("peer3", "peer3.example.com:7051")))
+	assert.Error(t, err, "expecting error since version of peer3 is unknown")
+
+	// Peer versions are checked before any config update is made
+	_, err = rc.UpgradeCapabilities(req, WithTargets(peer1, peer2))
+	assert.Error(t, err)
+}
+
+func TestVerifyMembershipPeerVersions(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	discClient := dyndiscmocks.NewMockDiscoveryClient()
+	discClient.SetResponses(&dyndiscmocks.MockDiscoverEndpointResponse{
+		PeerEndpoints: []*discmocks.MockDiscoveryPeerEndpoint{
+			{MSPID: "Org1MSP", Endpoint: "peer0.org1.example.com:7051", LedgerHeight: 5},
+			{MSPID: "Org2MSP", Endpoint: "peer0.org2.example.com:7051", LedgerHeight: 5},
+		},
+	})
+
+	defaultProvider := discoveryClientProvider
+	defer func() { discoveryClientProvider = defaultProvider }()
+	discoveryClientProvider = func(ctx context.Client) (discoveryClient, error) {
+		return discClient, nil
+	}
+
+	// Peer versions are checked by default
+	req := CapabilitiesRequest{ChannelID: "mychannel", ApplicationCapabilities: []string{fab.V1_2Capability}}
+	assert.NoError(t, rc.verifyPeerVersions(req))
+
+	// Fabric 1.1 peers don't advertise their properties in the channel membership
+	responses, err := discClient.Send(reqContext.Background(), nil)
+	require.NoError(t, err)
+	members, err := responses[0].ForChannel("mychannel").Peers()
+	require.NoError(t, err)
+	members[1].StateInfoMessage.GetStateInfo().Properties = nil
+
+	err = rc.verifyPeerVersions(req)
+	assert.Error(t, err, "expecting error since peer0.org2 doesn't support V1_2")
+	assert.Contains(t, err.Error(), "peer peer0.org2.example.com:7051 has version 1.1")
+
+	req.ApplicationCapabilities = []string{fab.V1_1Capability}
+	assert.NoError(t, rc.verifyPeerVersions(req))
+
+	// The check must be skipped explicitly
+	req.ApplicationCapabilities = []string{fab.V1_2Capability}
+	_, err = rc.UpgradeCapabilities(req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "peer peer0.org2.example.com:7051 has version 1.1")
+
+	orderer := fcmocks.NewMockOrderer("", nil)
+	defer orderer.Close()
+	orderer.EnqueueForSendDeliver(fcmocks.NewSimpleMockError())
+
+	req.SkipPeerVersionCheck = true
+	_, err = rc.UpgradeCapabilities(req, WithOrderer(orderer))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "enabling capabilities [V1_2] in group [Application] of channel mychannel failed")
+
+	discClient.SetResponses()
+	req.SkipPeerVersionCheck = false
+	_, err = rc.UpgradeCapabilities(req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to query channel membership")
+}
+
+func TestUpgradeCapabilitiesError(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	_, err := rc.UpgradeCapabilities(CapabilitiesRequest{ChannelID: "mychannel", ApplicationCapabilities: []string{"V9_9"}})
+	assert.Error(t, err, "expecting error for unknown capability")
+
+	orderer := fcmocks.NewMockOrderer("", nil)
+	defer orderer.Close()
+	orderer.EnqueueForSendDeliver(fcmocks.NewSimpleMockError())
+
+	resp, err := rc.UpgradeCapabilities(CapabilitiesRequest{ChannelID: "mychannel", ApplicationCapabilities: []string{fab.V1_2Capability}, SkipPeerVersionCheck: true}, WithOrderer(orderer))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "enabling capabilities [V1_2] in group [Application] of channel mychannel failed")
+	assert.Empty(t, resp.Updates)
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, compareVersions("1.2.0", "1.2"))
+	assert.Equal(t, 0, compareVersions("v1.2.1-rc1", "1.2.0"))
+	assert.True(t, compareVersions("1.1.0", "1.2") < 0)
+	assert.True(t, compareVersions("1.10.0", "1.2") > 0)
+	assert.True(t, compareVersions("2.0.0", "1.2") > 0)
+	assert.True(t, compareVersions("unknown", "1.1") < 0)
+}
+
+func configBlock(t *testing.T, config *common.Config) *common.Block {
+	block := newMockConfigBlock()
+	envelope := &common.Envelope{}
+	require.NoError(t, proto.Unmarshal(block.Data.Data[0], envelope))
+	payload := &common.Payload{}
+	require.NoError(t, proto.Unmarshal(envelope.Payload, payload))
+	configEnvelope := &common.ConfigEnvelope{}
+	require.NoError(t, proto.Unmarshal(payload.Data, configEnvelope))
+
+	configEnvelope.Config = config
+
+	var err error
+	payload.Data, err = proto.Marshal(configEnvelope)
+	require.NoError(t, err)
+	envelope.Payload, err = proto.Marshal(payload)
+	require.NoError(t, err)
+	block.Data.Data[0], err = proto.Marshal(envelope)
+	require.NoError(t, err)
+	return block
+}
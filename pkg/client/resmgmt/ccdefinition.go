@@ -0,0 +1,158 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/chconfig"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// ChaincodeDefinition contains the definition of a chaincode instantiated on a channel, as stored by the
+// lifecycle system chaincode (LSCC)
+type ChaincodeDefinition struct {
+	Name    string
+	Version string
+	// Escc is the name of the endorsement system chaincode
+	Escc string
+	// Vscc is the name of the validation system chaincode
+	Vscc string
+	// Policy is the endorsement policy of the chaincode
+	Policy *common.SignaturePolicyEnvelope
+	// PolicyExpression is the endorsement policy rendered as an expression (see fab.SignaturePolicy), for example
+	// OutOf(2, 'Org1MSP.member', 'Org2MSP.member') (empty if the policy can't be rendered)
+	PolicyExpression string
+	// InstantiationPolicy is the policy that must be satisfied by the signers of upgrade proposals
+	InstantiationPolicy *common.SignaturePolicyEnvelope
+	// InstantiationPolicyExpression is the instantiation policy rendered as an expression
+	InstantiationPolicyExpression string
+	// CodeHash is the hash of the chaincode's code package
+	CodeHash []byte
+	// Fingerprint is the fingerprint of the chaincode deployment package (see VerifyInstalledCC)
+	Fingerprint []byte
+}
+
+// QueryChaincodeDefinition queries the definition of a chaincode instantiated on a channel. If peer is not specified
+// in options it will query a random peer of the admin's MSP on this channel.
+//  Parameters:
+//  channelID is mandatory channel name
+//  ccName is mandatory chaincode name
+//  options hold optional request options
+//
+//  Returns:
+//  the chaincode definition
+func (rc *Client) QueryChaincodeDefinition(channelID string, ccName string, options ...RequestOption) (*ChaincodeDefinition, error) {
+	if channelID == "" || ccName == "" {
+		return nil, errors.New("must provide channel ID and chaincode name")
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	l, target, v, err := rc.prepareChannelQuery(channelID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.PeerResponse)
+	defer cancel()
+
+	responses, err := l.QueryChaincodeData(reqCtx, ccName, []fab.ProposalProcessor{target}, v)
+	if err != nil {
+		return nil, errors.WithMessage(err, "querying chaincode data failed")
+	}
+
+	return newChaincodeDefinition(responses[0])
+}
+
+// QueryCollectionsConfig queries the collections config of a chaincode instantiated on a channel. If peer is not
+// specified in options it will query a random peer of the admin's MSP on this channel.
+//  Parameters:
+//  channelID is mandatory channel name
+//  ccName is mandatory chaincode name
+//  options hold optional request options
+//
+//  Returns:
+//  the collections config of the chaincode
+func (rc *Client) QueryCollectionsConfig(channelID string, ccName string, options ...RequestOption) (*common.CollectionConfigPackage, error) {
+	if channelID == "" || ccName == "" {
+		return nil, errors.New("must provide channel ID and chaincode name")
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	l, target, v, err := rc.prepareChannelQuery(channelID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.PeerResponse)
+	defer cancel()
+
+	responses, err := l.QueryCollectionsConfig(reqCtx, ccName, []fab.ProposalProcessor{target}, v)
+	if err != nil {
+		return nil, errors.WithMessage(err, "querying collections config failed")
+	}
+
+	return responses[0], nil
+}
+
+func newChaincodeDefinition(ccData *ccprovider.ChaincodeData) (*ChaincodeDefinition, error) {
+	definition := &ChaincodeDefinition{
+		Name:        ccData.Name,
+		Version:     ccData.Version,
+		Escc:        ccData.Escc,
+		Vscc:        ccData.Vscc,
+		Fingerprint: ccData.Id,
+	}
+
+	var err error
+	definition.Policy, definition.PolicyExpression, err = unmarshalPolicy(ccData.Policy)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid endorsement policy")
+	}
+
+	if len(ccData.InstantiationPolicy) > 0 {
+		definition.InstantiationPolicy, definition.InstantiationPolicyExpression, err = unmarshalPolicy(ccData.InstantiationPolicy)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid instantiation policy")
+		}
+	}
+
+	// The data of both unsigned and signed deployment packages starts with the code hash
+	cdsData := &ccprovider.CDSData{}
+	if err := proto.Unmarshal(ccData.Data, cdsData); err != nil {
+		return nil, errors.Wrap(err, "unmarshal of chaincode package data failed")
+	}
+	definition.CodeHash = cdsData.CodeHash
+
+	return definition, nil
+}
+
+// unmarshalPolicy unmarshals a signature policy and renders it as an expression, if possible
+func unmarshalPolicy(policyBytes []byte) (*common.SignaturePolicyEnvelope, string, error) {
+	policy := &common.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(policyBytes, policy); err != nil {
+		return nil, "", errors.Wrap(err, "unmarshal of signature policy failed")
+	}
+
+	signaturePolicy, err := chconfig.NewSignaturePolicy(policy)
+	if err != nil {
+		logger.Debugf("signature policy can't be rendered as an expression: %s", err)
+		return policy, "", nil
+	}
+
+	return policy, signaturePolicy.String(), nil
+}
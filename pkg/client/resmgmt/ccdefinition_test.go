@@ -0,0 +1,119 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryChaincodeDefinition(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	policy, err := cauthdsl.FromString("AND('Org1MSP.member', 'Org2MSP.member')")
+	require.NoError(t, err)
+	policyBytes, err := proto.Marshal(policy)
+	require.NoError(t, err)
+	instantiationPolicyBytes, err := proto.Marshal(cauthdsl.SignedByAnyAdmin([]string{"Org1MSP"}))
+	require.NoError(t, err)
+	data, err := proto.Marshal(&ccprovider.CDSData{CodeHash: []byte("codehash"), MetaDataHash: []byte("metadatahash")})
+	require.NoError(t, err)
+
+	payload, err := proto.Marshal(&ccprovider.ChaincodeData{
+		Name:                "examplecc",
+		Version:             "v1",
+		Escc:                "escc",
+		Vscc:                "vscc",
+		Policy:              policyBytes,
+		Data:                data,
+		Id:                  []byte("fingerprint"),
+		InstantiationPolicy: instantiationPolicyBytes,
+	})
+	require.NoError(t, err)
+
+	peer := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockMSP: "Org1MSP", Status: http.StatusOK, Payload: payload}
+
+	definition, err := rc.QueryChaincodeDefinition("mychannel", "examplecc", WithTargets(peer))
+	require.NoError(t, err)
+	assert.Equal(t, "examplecc", definition.Name)
+	assert.Equal(t, "v1", definition.Version)
+	assert.Equal(t, "escc", definition.Escc)
+	assert.Equal(t, "vscc", definition.Vscc)
+	assert.True(t, proto.Equal(policy, definition.Policy))
+	assert.Equal(t, "OutOf(2, 'Org1MSP.member', 'Org2MSP.member')", definition.PolicyExpression)
+	assert.Equal(t, "OutOf(1, 'Org1MSP.admin')", definition.InstantiationPolicyExpression)
+	assert.Equal(t, []byte("codehash"), definition.CodeHash)
+	assert.Equal(t, []byte("fingerprint"), definition.Fingerprint)
+}
+
+func TestQueryChaincodeDefinitionError(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	_, err := rc.QueryChaincodeDefinition("mychannel", "")
+	assert.Error(t, err, "expecting error for missing chaincode name")
+
+	_, err = rc.QueryChaincodeDefinition("mychannel", "examplecc")
+	assert.Error(t, err, "expecting error since there are no channel peers")
+
+	peer := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockMSP: "Org1MSP", Status: http.StatusOK, Payload: []byte("invalid")}
+	_, err = rc.QueryChaincodeDefinition("mychannel", "examplecc", WithTargets(peer))
+	assert.Error(t, err, "expecting error for invalid chaincode data")
+
+	peer.Status = http.StatusInternalServerError
+	_, err = rc.QueryChaincodeDefinition("mychannel", "examplecc", WithTargets(peer))
+	assert.Error(t, err, "expecting error for bad status")
+}
+
+func TestQueryCollectionsConfig(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	collConfig := &common.CollectionConfigPackage{Config: []*common.CollectionConfig{newCollectionConfig("collection1", "OR('Org1MSP.member')", 1, 2, 100)}}
+	payload, err := proto.Marshal(collConfig)
+	require.NoError(t, err)
+
+	peer := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockMSP: "Org1MSP", Status: http.StatusOK, Payload: payload}
+
+	response, err := rc.QueryCollectionsConfig("mychannel", "examplecc", WithTargets(peer))
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(collConfig, response))
+
+	_, err = rc.QueryCollectionsConfig("", "examplecc", WithTargets(peer))
+	assert.Error(t, err, "expecting error for missing channel ID")
+
+	peer.Payload = []byte("invalid")
+	_, err = rc.QueryCollectionsConfig("mychannel", "examplecc", WithTargets(peer))
+	assert.Error(t, err, "expecting error for invalid collections config")
+}
+
+func newCollectionConfig(name string, policy string, requiredPeerCount, maximumPeerCount int32, blockToLive uint64) *common.CollectionConfig {
+	memberOrgsPolicy, err := cauthdsl.FromString(policy)
+	if err != nil {
+		panic(err)
+	}
+
+	return &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &common.StaticCollectionConfig{
+				Name: name,
+				MemberOrgsPolicy: &common.CollectionPolicyConfig{
+					Payload: &common.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: memberOrgsPolicy},
+				},
+				RequiredPeerCount: requiredPeerCount,
+				MaximumPeerCount:  maximumPeerCount,
+				BlockToLive:       blockToLive,
+			},
+		},
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// ChannelTxRequest describes a new application channel in the same way as a channel profile of configtx.yaml
+type ChannelTxRequest struct {
+	ChannelID  string
+	Consortium string            // Consortium of the orderer system channel that the channel is created for
+	Orgs       []ChannelTxOrg    // Member organizations, which must be members of the consortium
+	Policies   map[string]Policy // Application policies keyed by name (defaults to ANY Readers, ANY Writers and MAJORITY Admins)
+	// Capabilities are the application capabilities of the channel (for example, V1_2)
+	Capabilities []string
+}
+
+// ChannelTxOrg is a member organization of a new channel
+type ChannelTxOrg struct {
+	MSPID string
+	// AnchorPeers are optional anchor peers of the organization. Setting anchor peers modifies the organization's
+	// config group, so the channel creation transaction must also be signed by an admin of the organization.
+	AnchorPeers []AnchorPeer
+}
+
+// Policy is a channel config policy as defined in configtx.yaml
+type Policy struct {
+	// Type is either fab.ImplicitMetaPolicyType or fab.SignaturePolicyType
+	Type fab.PolicyType
+	// Rule is the rule of the policy, for example "MAJORITY Admins" for an implicit meta policy or
+	// "OR('Org1MSP.admin', 'Org2MSP.admin')" for a signature policy
+	Rule string
+}
+
+// defaultApplicationPolicies are the application policies that configtxgen uses by default
+var defaultApplicationPolicies = map[string]Policy{
+	channelconfig.ReadersPolicyKey: {Type: fab.ImplicitMetaPolicyType, Rule: "ANY Readers"},
+	channelconfig.WritersPolicyKey: {Type: fab.ImplicitMetaPolicyType, Rule: "ANY Writers"},
+	channelconfig.AdminsPolicyKey:  {Type: fab.ImplicitMetaPolicyType, Rule: "MAJORITY Admins"},
+}
+
+// CreateChannelTx creates the (unsigned) channel creation transaction for the given channel, which is equivalent
+// to the transaction produced by 'configtxgen -outputCreateChannelTx'. The transaction may be passed to
+// SaveChannel (using a bytes.Reader) to create the channel.
+//  Parameters:
+//  req describes the channel
+//
+//  Returns:
+//  the marshalled config update envelope
+func CreateChannelTx(req ChannelTxRequest) ([]byte, error) {
+
+	configUpdate, err := newChannelCreationConfigUpdate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource.CreateConfigUpdateEnvelope(configUpdate)
+}
+
+// newChannelCreationConfigUpdate computes the config update from the template of the new channel (the member
+// organizations of the consortium, as created by the orderer) to the configuration described by the request
+func newChannelCreationConfigUpdate(req ChannelTxRequest) (*common.ConfigUpdate, error) {
+	if err := checkRequiredChannelTxParams(req); err != nil {
+		return nil, err
+	}
+
+	template, application, err := newChannelTxApplicationGroups(req)
+	if err != nil {
+		return nil, err
+	}
+
+	original := &common.Config{ChannelGroup: &common.ConfigGroup{Groups: map[string]*common.ConfigGroup{channelconfig.ApplicationGroupKey: template}}}
+	updated := &common.Config{ChannelGroup: &common.ConfigGroup{Groups: map[string]*common.ConfigGroup{channelconfig.ApplicationGroupKey: application}}}
+
+	configUpdate, err := resource.ComputeConfigUpdate(req.ChannelID, original, updated)
+	if err != nil {
+		return nil, errors.WithMessage(err, "computing config update failed")
+	}
+
+	if err := setChannelTxConsortium(configUpdate, req.Consortium); err != nil {
+		return nil, err
+	}
+
+	return configUpdate, nil
+}
+
+func checkRequiredChannelTxParams(req ChannelTxRequest) error {
+	if req.ChannelID == "" {
+		return errors.New("must provide channel ID")
+	}
+	if req.Consortium == "" {
+		return errors.New("must provide consortium name")
+	}
+	if len(req.Orgs) == 0 {
+		return errors.New("must provide at least one organization")
+	}
+	return nil
+}
+
+// newChannelTxApplicationGroups returns the template of the application group of the new channel and the
+// application group described by the request
+func newChannelTxApplicationGroups(req ChannelTxRequest) (template, application *common.ConfigGroup, err error) {
+	template = newConfigGroup()
+	application = newConfigGroup()
+	application.ModPolicy = channelconfig.AdminsPolicyKey
+
+	for _, org := range req.Orgs {
+		if org.MSPID == "" {
+			return nil, nil, errors.New("must provide MSP ID")
+		}
+		if _, ok := application.Groups[org.MSPID]; ok {
+			return nil, nil, errors.Errorf("duplicate organization %s", org.MSPID)
+		}
+
+		templateOrg, orgGroup, err := newChannelTxOrgGroups(org)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, org.MSPID)
+		}
+		template.Groups[org.MSPID] = templateOrg
+		application.Groups[org.MSPID] = orgGroup
+	}
+
+	if err := setChannelTxPolicies(application, req.Policies); err != nil {
+		return nil, nil, err
+	}
+
+	if err := setChannelTxCapabilities(application, req.Capabilities); err != nil {
+		return nil, nil, err
+	}
+
+	return template, application, nil
+}
+
+// setChannelTxPolicies sets the policies of the application group (the default policies of configtxgen if no
+// policies are given)
+func setChannelTxPolicies(application *common.ConfigGroup, policies map[string]Policy) error {
+	if policies == nil {
+		policies = defaultApplicationPolicies
+	}
+	for name, policy := range policies {
+		configPolicy, err := newConfigPolicy(policy)
+		if err != nil {
+			return errors.WithMessage(err, name)
+		}
+		application.Policies[name] = configPolicy
+	}
+	return nil
+}
+
+func setChannelTxCapabilities(application *common.ConfigGroup, capabilityNames []string) error {
+	if len(capabilityNames) == 0 {
+		return nil
+	}
+
+	capabilities := &common.Capabilities{Capabilities: make(map[string]*common.Capability)}
+	for _, capability := range capabilityNames {
+		capabilities.Capabilities[capability] = &common.Capability{}
+	}
+	return setConfigValue(application, channelconfig.CapabilitiesKey, capabilities)
+}
+
+// setChannelTxConsortium adds the consortium of the new channel to the config update
+func setChannelTxConsortium(configUpdate *common.ConfigUpdate, consortium string) error {
+	consortiumBytes, err := proto.Marshal(&common.Consortium{Name: consortium})
+	if err != nil {
+		return errors.Wrap(err, "marshal consortium failed")
+	}
+	if configUpdate.ReadSet.Values == nil {
+		configUpdate.ReadSet.Values = make(map[string]*common.ConfigValue)
+	}
+	if configUpdate.WriteSet.Values == nil {
+		configUpdate.WriteSet.Values = make(map[string]*common.ConfigValue)
+	}
+	configUpdate.ReadSet.Values[channelconfig.ConsortiumKey] = &common.ConfigValue{}
+	configUpdate.WriteSet.Values[channelconfig.ConsortiumKey] = &common.ConfigValue{Value: consortiumBytes}
+	return nil
+}
+
+// newChannelTxOrgGroups returns the template of the organization's group (only the keys of the elements created by
+// configtxgen are known) and the organization's group in the new channel
+func newChannelTxOrgGroups(org ChannelTxOrg) (template, group *common.ConfigGroup, err error) {
+	if len(org.AnchorPeers) == 0 {
+		return &common.ConfigGroup{}, &common.ConfigGroup{}, nil
+	}
+
+	template = newConfigGroup()
+	template.ModPolicy = channelconfig.AdminsPolicyKey
+	template.Values[channelconfig.MSPKey] = &common.ConfigValue{}
+	for _, name := range []string{channelconfig.ReadersPolicyKey, channelconfig.WritersPolicyKey, channelconfig.AdminsPolicyKey} {
+		template.Policies[name] = &common.ConfigPolicy{}
+	}
+
+	group = proto.Clone(template).(*common.ConfigGroup)
+	anchorPeers := &pb.AnchorPeers{}
+	for _, ap := range org.AnchorPeers {
+		anchorPeers.AnchorPeers = appendAnchorPeer(anchorPeers.AnchorPeers, ap)
+	}
+	if err := setConfigValue(group, channelconfig.AnchorPeersKey, anchorPeers); err != nil {
+		return nil, nil, err
+	}
+
+	return template, group, nil
+}
+
+// newConfigPolicy converts the policy to a config policy, which is modified by the Admins policy
+func newConfigPolicy(policy Policy) (*common.ConfigPolicy, error) {
+	var p *common.Policy
+	switch policy.Type {
+	case fab.ImplicitMetaPolicyType:
+		implicitMetaPolicy, err := newImplicitMetaPolicy(policy.Rule)
+		if err != nil {
+			return nil, err
+		}
+		policyBytes, err := proto.Marshal(implicitMetaPolicy)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal implicit meta policy failed")
+		}
+		p = &common.Policy{Type: int32(common.Policy_IMPLICIT_META), Value: policyBytes}
+	case fab.SignaturePolicyType:
+		signaturePolicy, err := cauthdsl.FromString(policy.Rule)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid signature policy rule")
+		}
+		policyBytes, err := proto.Marshal(signaturePolicy)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal signature policy failed")
+		}
+		p = &common.Policy{Type: int32(common.Policy_SIGNATURE), Value: policyBytes}
+	default:
+		return nil, errors.Errorf("unsupported policy type: %s", policy.Type)
+	}
+
+	return &common.ConfigPolicy{ModPolicy: channelconfig.AdminsPolicyKey, Policy: p}, nil
+}
+
+// newImplicitMetaPolicy parses an implicit meta policy rule such as "MAJORITY Admins"
+func newImplicitMetaPolicy(rule string) (*common.ImplicitMetaPolicy, error) {
+	args := strings.Fields(rule)
+	if len(args) != 2 {
+		return nil, errors.Errorf("invalid implicit meta policy rule: %s", rule)
+	}
+
+	r, ok := common.ImplicitMetaPolicy_Rule_value[strings.ToUpper(args[0])]
+	if !ok {
+		return nil, errors.Errorf("unknown implicit meta policy rule type: %s", args[0])
+	}
+
+	return &common.ImplicitMetaPolicy{Rule: common.ImplicitMetaPolicy_Rule(r), SubPolicy: args[1]}, nil
+}
+
+func newConfigGroup() *common.ConfigGroup {
+	return &common.ConfigGroup{
+		Groups:   make(map[string]*common.ConfigGroup),
+		Values:   make(map[string]*common.ConfigValue),
+		Policies: make(map[string]*common.ConfigPolicy),
+	}
+}
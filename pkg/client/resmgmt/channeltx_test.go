@@ -0,0 +1,126 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orgChannelTx = "../../../test/fixtures/fabric/v1.2/channel/orgchannel.tx"
+
+func TestCreateChannelTx(t *testing.T) {
+	// Same channel as the TwoOrgsChannel profile used to generate the fixture
+	tx, err := CreateChannelTx(ChannelTxRequest{
+		ChannelID:    "orgchannel",
+		Consortium:   "SampleConsortium",
+		Orgs:         []ChannelTxOrg{{MSPID: "Org1MSP"}, {MSPID: "Org2MSP"}},
+		Capabilities: []string{"V1_2", "V1_1_PVTDATA_EXPERIMENTAL", "V1_1_RESOURCETREE_EXPERIMENTAL"},
+	})
+	require.NoError(t, err)
+
+	configUpdate := extractConfigUpdate(t, tx)
+
+	expectedTx, err := ioutil.ReadFile(orgChannelTx)
+	require.NoError(t, err)
+	expected := extractConfigUpdate(t, expectedTx)
+
+	// Capabilities are compared separately since the encoding of maps isn't deterministic
+	assert.Equal(t, capabilitiesValue(t, expected), capabilitiesValue(t, configUpdate))
+	assert.True(t, proto.Equal(expected, configUpdate), "config update differs from configtxgen output:\n%s", proto.MarshalTextString(configUpdate))
+}
+
+func TestCreateChannelTxWithAnchorPeersAndPolicies(t *testing.T) {
+	tx, err := CreateChannelTx(ChannelTxRequest{
+		ChannelID:  "mychannel",
+		Consortium: "SampleConsortium",
+		Orgs: []ChannelTxOrg{
+			{MSPID: "Org1MSP", AnchorPeers: []AnchorPeer{{Host: "peer0.org1.example.com", Port: 7051}}},
+			{MSPID: "Org2MSP"},
+		},
+		Policies: map[string]Policy{
+			"Readers": {Type: fab.ImplicitMetaPolicyType, Rule: "ANY Readers"},
+			"Writers": {Type: fab.ImplicitMetaPolicyType, Rule: "ANY Writers"},
+			"Admins":  {Type: fab.SignaturePolicyType, Rule: "OR('Org1MSP.admin', 'Org2MSP.admin')"},
+		},
+	})
+	require.NoError(t, err)
+
+	configUpdate := extractConfigUpdate(t, tx)
+	assert.Equal(t, "mychannel", configUpdate.ChannelId)
+
+	application := configUpdate.WriteSet.Groups["Application"]
+	assert.Equal(t, uint64(1), application.Version)
+	assert.NotContains(t, application.Values, "Capabilities")
+	assert.Equal(t, int32(common.Policy_SIGNATURE), application.Policies["Admins"].Policy.Type)
+
+	org1 := application.Groups["Org1MSP"]
+	assert.Equal(t, uint64(1), org1.Version)
+	assert.Equal(t, "Admins", org1.ModPolicy)
+	assert.Contains(t, org1.Values, "MSP")
+	assert.Len(t, org1.Policies, 3)
+	anchorPeers := &pb.AnchorPeers{}
+	require.NoError(t, proto.Unmarshal(org1.Values["AnchorPeers"].Value, anchorPeers))
+	assert.Equal(t, "peer0.org1.example.com", anchorPeers.AnchorPeers[0].Host)
+	assert.Contains(t, configUpdate.ReadSet.Groups["Application"].Groups["Org1MSP"].Values, "MSP")
+
+	assert.Equal(t, uint64(0), application.Groups["Org2MSP"].Version)
+	assert.Empty(t, application.Groups["Org2MSP"].Values)
+}
+
+func TestCreateChannelTxError(t *testing.T) {
+	orgs := []ChannelTxOrg{{MSPID: "Org1MSP"}}
+
+	_, err := CreateChannelTx(ChannelTxRequest{Consortium: "SampleConsortium", Orgs: orgs})
+	assert.Error(t, err, "expecting error for missing channel ID")
+
+	_, err = CreateChannelTx(ChannelTxRequest{ChannelID: "mychannel", Orgs: orgs})
+	assert.Error(t, err, "expecting error for missing consortium")
+
+	_, err = CreateChannelTx(ChannelTxRequest{ChannelID: "mychannel", Consortium: "SampleConsortium"})
+	assert.Error(t, err, "expecting error for missing organizations")
+
+	_, err = CreateChannelTx(ChannelTxRequest{ChannelID: "mychannel", Consortium: "SampleConsortium", Orgs: []ChannelTxOrg{{MSPID: "Org1MSP"}, {MSPID: "Org1MSP"}}})
+	assert.Error(t, err, "expecting error for duplicate organization")
+
+	invalidPolicies := []Policy{
+		{Type: fab.ImplicitMetaPolicyType, Rule: "MAJORITY"},
+		{Type: fab.ImplicitMetaPolicyType, Rule: "SOME Admins"},
+		{Type: fab.SignaturePolicyType, Rule: "OR('Org1MSP.admin'"},
+		{Type: fab.UnknownPolicyType, Rule: "ANY Readers"},
+	}
+	for _, policy := range invalidPolicies {
+		_, err = CreateChannelTx(ChannelTxRequest{ChannelID: "mychannel", Consortium: "SampleConsortium", Orgs: orgs, Policies: map[string]Policy{"Admins": policy}})
+		assert.Error(t, err, "expecting error for invalid policy %+v", policy)
+	}
+}
+
+func extractConfigUpdate(t *testing.T, tx []byte) *common.ConfigUpdate {
+	configUpdateBytes, err := resource.ExtractChannelConfig(tx)
+	require.NoError(t, err)
+
+	configUpdate := &common.ConfigUpdate{}
+	require.NoError(t, proto.Unmarshal(configUpdateBytes, configUpdate))
+	return configUpdate
+}
+
+// capabilitiesValue returns the application capabilities of the config update, removing them from the update
+func capabilitiesValue(t *testing.T, configUpdate *common.ConfigUpdate) *common.Capabilities {
+	application := configUpdate.WriteSet.Groups["Application"]
+	capabilities := &common.Capabilities{}
+	require.NoError(t, proto.Unmarshal(application.Values["Capabilities"].Value, capabilities))
+	application.Values["Capabilities"].Value = nil
+	return capabilities
+}
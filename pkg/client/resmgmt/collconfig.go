@@ -0,0 +1,178 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// CollectionConfigDefinition is the definition of a private data collection in the collection
+// configuration file format (collections_config.json) used by the peer CLI
+type CollectionConfigDefinition struct {
+	Name              string `json:"name"`
+	Policy            string `json:"policy"`            // Member orgs policy, for example OR('Org1MSP.member','Org2MSP.member')
+	RequiredPeerCount int32  `json:"requiredPeerCount"` // Minimum number of peers the private data is disseminated to at endorsement
+	MaxPeerCount      int32  `json:"maxPeerCount"`      // Maximum number of peers the private data is disseminated to at endorsement
+	BlockToLive       uint64 `json:"blockToLive"`       // Number of blocks after which the private data is purged (0 to keep it forever)
+	MemberOnlyRead    bool   `json:"memberOnlyRead"`    // Not supported by Fabric 1.2 collections (must be false)
+}
+
+// NewSignaturePolicy parses a signature policy expression, for example AND('Org1MSP.member', 'Org2MSP.member'),
+// and verifies that the MSPs referenced by the policy are members of the channel.
+//  Parameters:
+//  expression is the policy expression (see cauthdsl.FromString for the syntax)
+//  cfg is the configuration of the channel, as returned by QueryConfigFromOrderer
+//
+//  Returns:
+//  signature policy, which can be used as the endorsement policy of InstantiateCCRequest and UpgradeCCRequest
+func NewSignaturePolicy(expression string, cfg fab.ChannelCfg) (*common.SignaturePolicyEnvelope, error) {
+	mspIDs, err := channelMSPIDs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return newSignaturePolicy(expression, mspIDs)
+}
+
+// NewCollectionConfigs reads private data collection definitions in the collection configuration file format
+// (collections_config.json) and verifies that the MSPs referenced by the collection policies are members of the channel.
+//  Parameters:
+//  reader provides the JSON collection definitions
+//  cfg is the configuration of the channel, as returned by QueryConfigFromOrderer
+//
+//  Returns:
+//  collection configs, which can be used as the CollConfig of InstantiateCCRequest and UpgradeCCRequest
+func NewCollectionConfigs(reader io.Reader, cfg fab.ChannelCfg) ([]*common.CollectionConfig, error) {
+	var definitions []CollectionConfigDefinition
+	if err := json.NewDecoder(reader).Decode(&definitions); err != nil {
+		return nil, errors.Wrap(err, "unmarshal of collection definitions failed")
+	}
+
+	mspIDs, err := channelMSPIDs(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	collConfigs := make([]*common.CollectionConfig, len(definitions))
+	for i, definition := range definitions {
+		if names[definition.Name] {
+			return nil, errors.Errorf("duplicate collection name: %s", definition.Name)
+		}
+		names[definition.Name] = true
+
+		collConfigs[i], err = collectionConfigFromDefinition(definition, mspIDs)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid collection "+definition.Name)
+		}
+	}
+	return collConfigs, nil
+}
+
+// LoadCollectionConfigs reads private data collection definitions from a collection configuration file
+// (collections_config.json). See NewCollectionConfigs.
+//  Parameters:
+//  collConfigPath is the path of the collection configuration file
+//  cfg is the configuration of the channel, as returned by QueryConfigFromOrderer
+//
+//  Returns:
+//  collection configs
+func LoadCollectionConfigs(collConfigPath string, cfg fab.ChannelCfg) ([]*common.CollectionConfig, error) {
+	collConfigBytes, err := ioutil.ReadFile(collConfigPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading collection configuration file failed")
+	}
+	return NewCollectionConfigs(bytes.NewReader(collConfigBytes), cfg)
+}
+
+func collectionConfigFromDefinition(definition CollectionConfigDefinition, mspIDs map[string]bool) (*common.CollectionConfig, error) {
+	if definition.Name == "" {
+		return nil, errors.New("collection name is required")
+	}
+	if definition.RequiredPeerCount < 0 {
+		return nil, errors.New("required peer count must not be negative")
+	}
+	if definition.MaxPeerCount < definition.RequiredPeerCount {
+		return nil, errors.Errorf("maximum peer count (%d) must not be less than the required peer count (%d)", definition.MaxPeerCount, definition.RequiredPeerCount)
+	}
+	if definition.MemberOnlyRead {
+		// Fabric 1.2 collections don't restrict reads to member orgs; silently ignoring the setting would expose the private data
+		return nil, errors.New("member only read isn't supported by Fabric 1.2 collections")
+	}
+
+	memberOrgsPolicy, err := newSignaturePolicy(definition.Policy, mspIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &common.StaticCollectionConfig{
+				Name: definition.Name,
+				MemberOrgsPolicy: &common.CollectionPolicyConfig{
+					Payload: &common.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: memberOrgsPolicy},
+				},
+				RequiredPeerCount: definition.RequiredPeerCount,
+				MaximumPeerCount:  definition.MaxPeerCount,
+				BlockToLive:       definition.BlockToLive,
+			},
+		},
+	}, nil
+}
+
+func newSignaturePolicy(expression string, mspIDs map[string]bool) (*common.SignaturePolicyEnvelope, error) {
+	if expression == "" {
+		return nil, errors.New("policy is required")
+	}
+
+	policy, err := cauthdsl.FromString(expression)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid policy "+expression)
+	}
+
+	for _, identity := range policy.Identities {
+		if identity.PrincipalClassification != mb.MSPPrincipal_ROLE {
+			return nil, errors.Errorf("unsupported principal classification: %s", identity.PrincipalClassification)
+		}
+		role := &mb.MSPRole{}
+		if err := proto.Unmarshal(identity.Principal, role); err != nil {
+			return nil, errors.Wrap(err, "unmarshal of MSP role failed")
+		}
+		if !mspIDs[role.MspIdentifier] {
+			return nil, errors.Errorf("MSP %s referenced by policy %s isn't a member of the channel", role.MspIdentifier, expression)
+		}
+	}
+	return policy, nil
+}
+
+// channelMSPIDs returns the IDs of the MSPs of the channel
+func channelMSPIDs(cfg fab.ChannelCfg) (map[string]bool, error) {
+	if cfg == nil {
+		return nil, errors.New("channel config is required")
+	}
+
+	mspIDs := make(map[string]bool)
+	for _, mspConfig := range cfg.MSPs() {
+		mspID, err := fabricMSPID(mspConfig)
+		if err != nil {
+			return nil, err
+		}
+		if mspID != "" {
+			mspIDs[mspID] = true
+		}
+	}
+	return mspIDs, nil
+}
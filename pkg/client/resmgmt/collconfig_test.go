@@ -0,0 +1,93 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSignaturePolicy(t *testing.T) {
+	cfg := newChannelCfgWithMSPs(t, "Org1MSP", "Org2MSP")
+
+	policy, err := NewSignaturePolicy("OR('Org1MSP.member','Org2MSP.admin')", cfg)
+	require.NoError(t, err)
+	expected, err := cauthdsl.FromString("OR('Org1MSP.member','Org2MSP.admin')")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(expected, policy))
+
+	_, err = NewSignaturePolicy("OR('Org1MSP.member','Org3MSP.member')", cfg)
+	assert.EqualError(t, err, "MSP Org3MSP referenced by policy OR('Org1MSP.member','Org3MSP.member') isn't a member of the channel")
+
+	_, err = NewSignaturePolicy("OR('Org1MSP.member'", cfg)
+	assert.Error(t, err, "expected error for invalid expression")
+
+	_, err = NewSignaturePolicy("", cfg)
+	assert.EqualError(t, err, "policy is required")
+
+	_, err = NewSignaturePolicy("OR('Org1MSP.member')", nil)
+	assert.EqualError(t, err, "channel config is required")
+}
+
+func TestLoadCollectionConfigs(t *testing.T) {
+	cfg := newChannelCfgWithMSPs(t, "Org1MSP", "Org2MSP")
+
+	collConfigs, err := LoadCollectionConfigs("testdata/collections_config.json", cfg)
+	require.NoError(t, err)
+	require.Len(t, collConfigs, 2)
+	assert.True(t, proto.Equal(newCollectionConfig("collectionMarbles", "OR('Org1MSP.member','Org2MSP.member')", 0, 3, 1000000), collConfigs[0]))
+	assert.True(t, proto.Equal(newCollectionConfig("collectionMarblePrivateDetails", "OR('Org1MSP.member')", 0, 3, 3), collConfigs[1]))
+
+	_, err = LoadCollectionConfigs("testdata/invalid.json", cfg)
+	assert.Error(t, err, "expected error for missing file")
+
+	_, err = LoadCollectionConfigs("testdata/collections_config.json", newChannelCfgWithMSPs(t, "Org1MSP"))
+	assert.Error(t, err, "expected error for MSP that isn't a member of the channel")
+}
+
+func TestNewCollectionConfigsError(t *testing.T) {
+	cfg := newChannelCfgWithMSPs(t, "Org1MSP")
+
+	tests := []struct {
+		json string
+		err  string
+	}{
+		{json: `{"name": "coll1"}`, err: "unmarshal of collection definitions failed"},
+		{json: `[{"policy": "OR('Org1MSP.member')", "maxPeerCount": 1}]`, err: "collection name is required"},
+		{json: `[{"name": "coll1", "maxPeerCount": 1}]`, err: "policy is required"},
+		{json: `[{"name": "coll1", "policy": "OR('Org1MSP.member')", "requiredPeerCount": -1}]`, err: "required peer count must not be negative"},
+		{json: `[{"name": "coll1", "policy": "OR('Org1MSP.member')", "requiredPeerCount": 2, "maxPeerCount": 1}]`, err: "maximum peer count (1) must not be less than the required peer count (2)"},
+		{json: `[{"name": "coll1", "policy": "OR('Org1MSP.member')", "memberOnlyRead": true}]`, err: "member only read isn't supported"},
+		{json: `[{"name": "coll1", "policy": "OR('Org2MSP.member')"}]`, err: "MSP Org2MSP referenced by policy OR('Org2MSP.member') isn't a member of the channel"},
+		{json: `[{"name": "coll1", "policy": "OR('Org1MSP.member')"}, {"name": "coll1", "policy": "OR('Org1MSP.member')"}]`, err: "duplicate collection name: coll1"},
+	}
+
+	for _, test := range tests {
+		_, err := NewCollectionConfigs(strings.NewReader(test.json), cfg)
+		require.Error(t, err, "expected error for %s", test.json)
+		assert.Contains(t, err.Error(), test.err)
+	}
+}
+
+func newChannelCfgWithMSPs(t *testing.T, mspIDs ...string) *fcmocks.MockChannelCfg {
+	cfg := fcmocks.NewMockChannelCfg("mychannel")
+	for _, mspID := range mspIDs {
+		fabricMSPConfig, err := proto.Marshal(&mb.FabricMSPConfig{Name: mspID})
+		require.NoError(t, err)
+		cfg.MockMSPs = append(cfg.MockMSPs, &mb.MSPConfig{Config: fabricMSPConfig})
+	}
+	// MSPs other than Fabric MSPs are ignored
+	cfg.MockMSPs = append(cfg.MockMSPs, &mb.MSPConfig{Type: 1, Config: []byte("idemix")})
+	return cfg
+}
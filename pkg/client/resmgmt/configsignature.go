@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	fcutils "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel/membership"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/chconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// CreateConfigSignature signs a channel configuration (a config update envelope such as a .tx file produced by
+// configtxgen or the output of CreateConfigUpdateEnvelope) with the given signing identity. This allows the admins
+// of several organizations to sign a config update on their own machines; the resulting signatures (which may be
+// exported with proto.Marshal) are then passed to SaveChannel using WithConfigSignatures.
+//  Parameters:
+//  signer is the identity that signs the channel configuration
+//  channelConfigPath is the path of the channel configuration file
+//
+//  Returns:
+//  the config signature
+func (rc *Client) CreateConfigSignature(signer msp.SigningIdentity, channelConfigPath string) (*common.ConfigSignature, error) {
+
+	configReader, err := os.Open(channelConfigPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening channel config file failed")
+	}
+	defer loggedClose(configReader)
+
+	return rc.CreateConfigSignatureFromReader(signer, configReader)
+}
+
+// CreateConfigSignatureFromReader signs a channel configuration read from the given reader with the given
+// signing identity (see CreateConfigSignature).
+//  Parameters:
+//  signer is the identity that signs the channel configuration
+//  channelConfig is the channel configuration data source
+//
+//  Returns:
+//  the config signature
+func (rc *Client) CreateConfigSignatureFromReader(signer msp.SigningIdentity, channelConfig io.Reader) (*common.ConfigSignature, error) {
+
+	if signer == nil {
+		return nil, errors.New("must provide signing user")
+	}
+
+	configTx, err := ioutil.ReadAll(channelConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading channel config failed")
+	}
+
+	chConfig, err := resource.ExtractChannelConfig(configTx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "extracting channel config failed")
+	}
+
+	return rc.signChannelConfig(signer, chConfig)
+}
+
+// validateConfigSignatures validates the creators of pre-signed config signatures against the MSPs of the channel
+// and verifies the signatures. The MSPs of a channel that is being created are not known yet, so in that case
+// only the format of the signatures is checked: the signatures are validated by the orderer against the channel
+// creation policy of the consortium, which is defined in the system channel.
+func (rc *Client) validateConfigSignatures(opts requestOptions, orderer fab.Orderer, channelID string, chConfig []byte, signatures []*common.ConfigSignature) error {
+
+	configUpdate := &common.ConfigUpdate{}
+	if err := proto.Unmarshal(chConfig, configUpdate); err != nil {
+		return errors.Wrap(err, "unmarshal config update failed")
+	}
+
+	if isChannelCreation(configUpdate) {
+		logger.Debugf("channel %s is being created - config signatures are not validated against channel MSPs", channelID)
+		return verifyConfigSignatures(nil, chConfig, signatures)
+	}
+
+	channelConfig, err := chconfig.New(channelID, chconfig.WithOrderer(orderer))
+	if err != nil {
+		return errors.WithMessage(err, "QueryConfig failed")
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.OrdererResponse)
+	defer cancel()
+
+	cfg, err := channelConfig.Query(reqCtx)
+	if err != nil {
+		return errors.WithMessage(err, "querying channel config failed")
+	}
+
+	channelMembership, err := membership.New(membership.Context{Providers: rc.ctx, EndpointConfig: rc.ctx.EndpointConfig()}, cfg)
+	if err != nil {
+		return errors.WithMessage(err, "creating channel membership failed")
+	}
+
+	return verifyConfigSignatures(channelMembership, chConfig, signatures)
+}
+
+// verifyConfigSignatures validates the creator and verifies each of the signatures using the given channel
+// membership. Only the format of the signatures is checked if the membership is nil.
+func verifyConfigSignatures(channelMembership fab.ChannelMembership, chConfig []byte, signatures []*common.ConfigSignature) error {
+	for i, signature := range signatures {
+		signatureHeader := &common.SignatureHeader{}
+		if err := proto.Unmarshal(signature.SignatureHeader, signatureHeader); err != nil {
+			return errors.Wrapf(err, "unmarshal header of config signature %d failed", i)
+		}
+		if len(signatureHeader.Creator) == 0 || len(signature.Signature) == 0 {
+			return errors.Errorf("config signature %d is incomplete", i)
+		}
+
+		if channelMembership == nil {
+			continue
+		}
+
+		if err := channelMembership.Validate(signatureHeader.Creator); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("creator of config signature %d is not valid", i))
+		}
+
+		signedBytes := fcutils.ConcatenateBytes(signature.SignatureHeader, chConfig)
+		if err := channelMembership.Verify(signatureHeader.Creator, signedBytes, signature.Signature); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("config signature %d is not valid", i))
+		}
+	}
+
+	return nil
+}
+
+// configSignatureMSPID returns the MSP ID of the creator of a config signature
+func configSignatureMSPID(signature *common.ConfigSignature) (string, error) {
+	signatureHeader := &common.SignatureHeader{}
+	if err := proto.Unmarshal(signature.SignatureHeader, signatureHeader); err != nil {
+		return "", errors.Wrap(err, "unmarshal signature header failed")
+	}
+
+	creator := &mb.SerializedIdentity{}
+	if err := proto.Unmarshal(signatureHeader.Creator, creator); err != nil {
+		return "", errors.Wrap(err, "unmarshal creator failed")
+	}
+	if creator.Mspid == "" {
+		return "", errors.New("creator MSP ID is missing")
+	}
+	return creator.Mspid, nil
+}
+
+// isChannelCreation returns true if the config update creates a channel, in which case the read set of the
+// channel group contains the consortium.
+func isChannelCreation(configUpdate *common.ConfigUpdate) bool {
+	if configUpdate.ReadSet == nil {
+		return false
+	}
+	_, ok := configUpdate.ReadSet.Values[channelconfig.ConsortiumKey]
+	return ok
+}
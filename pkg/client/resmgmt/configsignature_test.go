@@ -0,0 +1,183 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestCreateConfigSignature(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	signature, err := rc.CreateConfigSignature(mspmocks.NewMockSigningIdentity("admin", "Org2MSP"), channelConfig)
+	require.NoError(t, err)
+
+	signatureHeader := &common.SignatureHeader{}
+	require.NoError(t, proto.Unmarshal(signature.SignatureHeader, signatureHeader))
+	assert.NotEmpty(t, signatureHeader.Creator)
+	assert.NotEmpty(t, signatureHeader.Nonce)
+	assert.NotEmpty(t, signature.Signature)
+
+	// Signatures may be exported and imported
+	signatureBytes, err := proto.Marshal(signature)
+	require.NoError(t, err)
+	imported := &common.ConfigSignature{}
+	require.NoError(t, proto.Unmarshal(signatureBytes, imported))
+
+	configTx, err := ioutil.ReadFile(channelConfig)
+	require.NoError(t, err)
+	chConfig, err := resource.ExtractChannelConfig(configTx)
+	require.NoError(t, err)
+	assert.NoError(t, verifyConfigSignatures(fcmocks.NewMockMembership(), chConfig, []*common.ConfigSignature{imported}))
+
+	_, err = rc.CreateConfigSignature(nil, channelConfig)
+	assert.Error(t, err, "expecting error for missing signer")
+
+	_, err = rc.CreateConfigSignature(ctx, "./testdata/non-existent.tx")
+	assert.Error(t, err, "expecting error for missing file")
+
+	_, err = rc.CreateConfigSignatureFromReader(ctx, bytes.NewReader([]byte("invalid")))
+	assert.Error(t, err, "expecting error for invalid channel config")
+}
+
+func TestVerifyConfigSignatures(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	chConfig := []byte("config update")
+	signature, err := rc.signChannelConfig(ctx, chConfig)
+	require.NoError(t, err)
+	signatures := []*common.ConfigSignature{signature}
+
+	assert.NoError(t, verifyConfigSignatures(nil, chConfig, signatures))
+	assert.NoError(t, verifyConfigSignatures(fcmocks.NewMockMembership(), chConfig, signatures))
+
+	err = verifyConfigSignatures(&fcmocks.MockMembership{ValidateErr: errors.New("unknown MSP")}, chConfig, signatures)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "creator of config signature 0 is not valid")
+
+	err = verifyConfigSignatures(&fcmocks.MockMembership{VerifyErr: errors.New("invalid signature")}, chConfig, signatures)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "config signature 0 is not valid")
+
+	err = verifyConfigSignatures(nil, chConfig, []*common.ConfigSignature{{SignatureHeader: []byte("invalid")}})
+	assert.Error(t, err, "expecting error for invalid signature header")
+
+	err = verifyConfigSignatures(nil, chConfig, []*common.ConfigSignature{{SignatureHeader: signature.SignatureHeader}})
+	assert.Error(t, err, "expecting error for missing signature")
+}
+
+func TestSaveChannelWithConfigSignatures(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	_, addr := fcmocks.StartMockBroadcastServer("127.0.0.1:0", grpcServer)
+
+	ctx := setupTestContext("test", "Org1MSP")
+
+	mockConfig := &fcmocks.MockConfig{}
+	grpcOpts := make(map[string]interface{})
+	grpcOpts["allow-insecure"] = true
+	mockConfig.SetCustomOrdererCfg(&fab.OrdererConfig{URL: addr, GRPCOptions: grpcOpts})
+	ctx.SetEndpointConfig(mockConfig)
+
+	rc := setupResMgmtClient(t, ctx)
+
+	// Signature created by another party
+	signature, err := rc.CreateConfigSignature(mspmocks.NewMockSigningIdentity("admin", "Org2MSP"), channelConfig)
+	require.NoError(t, err)
+
+	// The channel is being created so signatures are not validated against the channel's MSPs
+	resp, err := rc.SaveChannel(SaveChannelRequest{ChannelID: "mychannel", ChannelConfigPath: channelConfig}, WithOrdererEndpoint("example.com"), WithConfigSignatures(signature))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.TransactionID)
+
+	_, err = rc.SaveChannel(SaveChannelRequest{ChannelID: "mychannel", ChannelConfigPath: channelConfig}, WithOrdererEndpoint("example.com"), WithConfigSignatures(&common.ConfigSignature{}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "config signature validation failed")
+
+	_, err = rc.SaveChannel(SaveChannelRequest{ChannelID: "mychannel", ChannelConfigPath: channelConfig}, WithConfigSignatures(nil))
+	assert.Error(t, err, "expecting error for nil signature")
+}
+
+func TestValidateConfigSignaturesForChannelCreation(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	configTx, err := ioutil.ReadFile(channelConfig)
+	require.NoError(t, err)
+	chConfig, err := resource.ExtractChannelConfig(configTx)
+	require.NoError(t, err)
+
+	// The creator's MSP isn't known to the SDK: the orderer validates channel creation signatures against the
+	// consortium's channel creation policy, so the channel config isn't queried
+	signature, err := rc.signChannelConfig(mspmocks.NewMockSigningIdentity("admin", "OtherMSP"), chConfig)
+	require.NoError(t, err)
+
+	orderer := fcmocks.NewMockOrderer("", nil)
+	defer orderer.Close()
+	orderer.EnqueueForSendDeliver(fcmocks.NewSimpleMockError())
+
+	opts, err := rc.prepareRequestOpts()
+	require.NoError(t, err)
+	assert.NoError(t, rc.validateConfigSignatures(opts, orderer, "mychannel", chConfig, []*common.ConfigSignature{signature}))
+
+	// The format of the signatures is still checked
+	err = rc.validateConfigSignatures(opts, orderer, "mychannel", chConfig, []*common.ConfigSignature{{SignatureHeader: signature.SignatureHeader}})
+	assert.Error(t, err, "expecting error for missing signature")
+
+	// Signatures of a channel update are validated against the channel MSPs, which requires the channel config
+	configUpdate := &common.ConfigUpdate{}
+	require.NoError(t, proto.Unmarshal(chConfig, configUpdate))
+	delete(configUpdate.ReadSet.Values, "Consortium")
+	updateBytes, err := proto.Marshal(configUpdate)
+	require.NoError(t, err)
+
+	err = rc.validateConfigSignatures(opts, orderer, "mychannel", updateBytes, []*common.ConfigSignature{signature})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "querying channel config failed")
+}
+
+func TestGetConfigSignatures(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	chConfig := []byte("config update")
+	preSigned, err := rc.signChannelConfig(mspmocks.NewMockSigningIdentity("admin", "Org2MSP"), chConfig)
+	require.NoError(t, err)
+
+	// Client signs by default
+	signatures, err := rc.getConfigSignatures(SaveChannelRequest{}, chConfig, nil)
+	require.NoError(t, err)
+	assert.Len(t, signatures, 1)
+
+	// Client doesn't sign if signatures were collected from other parties
+	signatures, err = rc.getConfigSignatures(SaveChannelRequest{}, chConfig, []*common.ConfigSignature{preSigned})
+	require.NoError(t, err)
+	assert.Equal(t, []*common.ConfigSignature{preSigned}, signatures)
+
+	// Pre-signed signatures are combined with the signing identities of the request
+	req := SaveChannelRequest{SigningIdentities: []msp.SigningIdentity{mspmocks.NewMockSigningIdentity("admin", "Org3MSP")}}
+	signatures, err = rc.getConfigSignatures(req, chConfig, []*common.ConfigSignature{preSigned})
+	require.NoError(t, err)
+	assert.Len(t, signatures, 2)
+	assert.Equal(t, preSigned, signatures[0])
+}
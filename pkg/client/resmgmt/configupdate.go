@@ -0,0 +1,201 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// QueryConfigBlockFromOrderer returns the current config block of the channel from the orderer. If orderer is not provided using options it will be defaulted to channel orderer (if configured) or random orderer from configuration.
+//  Parameters:
+//  channelID is mandatory channel ID
+//  options holds optional request options
+//
+//  Returns:
+//  the current config block of the channel
+func (rc *Client) QueryConfigBlockFromOrderer(channelID string, options ...RequestOption) (*common.Block, error) {
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	orderer, err := rc.requestOrderer(&opts, channelID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find orderer for request")
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.OrdererResponse)
+	defer cancel()
+
+	block, err := resource.LastConfigFromOrderer(reqCtx, channelID, orderer, resource.WithRetry(opts.Retry))
+	if err != nil {
+		return nil, errors.WithMessage(err, "LastConfigFromOrderer failed")
+	}
+
+	return block, nil
+}
+
+// CreateConfigUpdateEnvelope computes the config update that transforms the configuration contained in the
+// current config block (as returned by QueryConfigBlockFromOrderer) into the updated configuration, and wraps it
+// in a config update envelope that may be submitted with SaveChannel.
+//  Parameters:
+//  channelID is mandatory channel ID
+//  currentConfigBlock is the current config block of the channel
+//  updatedConfig is the modified channel configuration
+//
+//  Returns:
+//  the marshalled config update envelope
+func CreateConfigUpdateEnvelope(channelID string, currentConfigBlock *common.Block, updatedConfig *common.Config) ([]byte, error) {
+
+	currentConfig, err := resource.ExtractConfigFromBlock(currentConfigBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "extracting current config from block failed")
+	}
+
+	configUpdate, err := resource.ComputeConfigUpdate(channelID, currentConfig, updatedConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "computing config update failed")
+	}
+
+	return resource.CreateConfigUpdateEnvelope(configUpdate)
+}
+
+// updateChannelConfig retrieves the current configuration of the channel from the orderer, applies the given
+// modification to a copy of it and submits the resulting config update, signed by the given signing identities
+// (or the client's identity if none are provided).
+func (rc *Client) updateChannelConfig(channelID string, signingIdentities []msp.SigningIdentity, modify func(config *common.Config) error, options ...RequestOption) (SaveChannelResponse, error) {
+
+	envelope, err := rc.createChannelConfigUpdate(channelID, modify, options...)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	return rc.saveChannelConfigUpdate(channelID, envelope, signingIdentities, options...)
+}
+
+// saveChannelConfigUpdate submits the given config update envelope, signed by the given signing identities (or the
+// client's identity if none are provided)
+func (rc *Client) saveChannelConfigUpdate(channelID string, envelope []byte, signingIdentities []msp.SigningIdentity, options ...RequestOption) (SaveChannelResponse, error) {
+	req := SaveChannelRequest{
+		ChannelID:         channelID,
+		ChannelConfig:     bytes.NewReader(envelope),
+		SigningIdentities: signingIdentities,
+	}
+	return rc.SaveChannel(req, options...)
+}
+
+// createChannelConfigUpdate retrieves the current configuration of the channel from the orderer, applies the
+// given modification to a copy of it and returns the resulting (unsigned) config update envelope
+func (rc *Client) createChannelConfigUpdate(channelID string, modify func(config *common.Config) error, options ...RequestOption) ([]byte, error) {
+
+	currentConfig, err := rc.queryCurrentConfig(channelID, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedConfig := proto.Clone(currentConfig).(*common.Config)
+	if err = modify(updatedConfig); err != nil {
+		return nil, err
+	}
+
+	return newChannelConfigUpdateEnvelope(channelID, currentConfig, updatedConfig)
+}
+
+// queryCurrentConfig retrieves the current configuration of the channel from the orderer
+func (rc *Client) queryCurrentConfig(channelID string, options ...RequestOption) (*common.Config, error) {
+
+	if channelID == "" {
+		return nil, errors.New("must provide channel ID")
+	}
+
+	configBlock, err := rc.QueryConfigBlockFromOrderer(channelID, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	currentConfig, err := resource.ExtractConfigFromBlock(configBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "extracting current config from block failed")
+	}
+	return currentConfig, nil
+}
+
+// newChannelConfigUpdateEnvelope computes the config update that transforms the current configuration into the
+// updated configuration and returns it as an (unsigned) config update envelope
+func newChannelConfigUpdateEnvelope(channelID string, currentConfig, updatedConfig *common.Config) ([]byte, error) {
+	configUpdate, err := resource.ComputeConfigUpdate(channelID, currentConfig, updatedConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "computing config update failed")
+	}
+
+	return resource.CreateConfigUpdateEnvelope(configUpdate)
+}
+
+// orgGroup returns the config group of the organization with the given MSP ID within the given config group
+// (e.g. the Application group). Organization groups are usually named after the MSP ID, otherwise the group
+// is looked up using the name in its MSP configuration.
+func orgGroup(group *common.ConfigGroup, mspID string) (*common.ConfigGroup, error) {
+	if group == nil {
+		return nil, errors.New("config group not found")
+	}
+
+	if org, ok := group.Groups[mspID]; ok {
+		return org, nil
+	}
+
+	for _, org := range group.Groups {
+		name, err := orgMSPID(org)
+		if err != nil {
+			return nil, err
+		}
+		if name == mspID {
+			return org, nil
+		}
+	}
+
+	return nil, errors.Errorf("organization %s not found", mspID)
+}
+
+// orgMSPID returns the MSP ID from the MSP configuration of the given organization group
+func orgMSPID(org *common.ConfigGroup) (string, error) {
+	value, ok := org.Values[channelconfig.MSPKey]
+	if !ok {
+		return "", nil
+	}
+
+	mspConfig := &mb.MSPConfig{}
+	if err := proto.Unmarshal(value.Value, mspConfig); err != nil {
+		return "", errors.Wrap(err, "unmarshal MSP config failed")
+	}
+
+	return fabricMSPID(mspConfig)
+}
+
+// fabricMSPID returns the MSP ID from the given MSP configuration (empty if it isn't a Fabric MSP)
+func fabricMSPID(mspConfig *mb.MSPConfig) (string, error) {
+	if mspConfig.Type != 0 {
+		// Not a Fabric MSP
+		return "", nil
+	}
+
+	fabricMSPConfig := &mb.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricMSPConfig); err != nil {
+		return "", errors.Wrap(err, "unmarshal Fabric MSP config failed")
+	}
+
+	return fabricMSPConfig.Name, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	reqContext "context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryConfigBlockFromOrdererError(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	orderer := fcmocks.NewMockOrderer("", nil)
+	defer orderer.Close()
+	orderer.EnqueueForSendDeliver(fcmocks.NewSimpleMockError())
+
+	_, err := rc.QueryConfigBlockFromOrderer("mychannel", WithOrderer(orderer))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "LastConfigFromOrderer failed")
+}
+
+func TestCreateConfigUpdateEnvelope(t *testing.T) {
+	configBlock := newMockConfigBlock()
+
+	currentConfig, err := resource.ExtractConfigFromBlock(configBlock)
+	require.NoError(t, err)
+
+	updatedConfig := proto.Clone(currentConfig).(*common.Config)
+	updatedConfig.ChannelGroup.Groups["Application"].Values["NewValue"] = &common.ConfigValue{Value: []byte("value"), ModPolicy: "Admins"}
+
+	envelope, err := CreateConfigUpdateEnvelope("mychannel", configBlock, updatedConfig)
+	require.NoError(t, err)
+
+	configUpdateBytes, err := resource.ExtractChannelConfig(envelope)
+	require.NoError(t, err)
+
+	configUpdate := &common.ConfigUpdate{}
+	require.NoError(t, proto.Unmarshal(configUpdateBytes, configUpdate))
+	assert.Equal(t, "mychannel", configUpdate.ChannelId)
+	assert.Contains(t, configUpdate.WriteSet.Groups["Application"].Values, "NewValue")
+
+	_, err = CreateConfigUpdateEnvelope("mychannel", configBlock, currentConfig)
+	assert.Error(t, err, "expecting error since config is unchanged")
+
+	_, err = CreateConfigUpdateEnvelope("mychannel", &common.Block{}, updatedConfig)
+	assert.Error(t, err, "expecting error for invalid config block")
+}
+
+func newMockConfigBlock() *common.Block {
+	builder := &fcmocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: fcmocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP", "Org2MSP"},
+			OrdererAddress: "localhost:7050",
+			RootCA:         "root-ca",
+		},
+	}
+	return builder.Build()
+}
+
+// configOrderer is an orderer whose last config block contains each of the given configs in turn
+// (the last one is kept once the others have been delivered)
+type configOrderer struct {
+	t       *testing.T
+	configs []*common.Config
+	seeks   int
+}
+
+func (o *configOrderer) URL() string {
+	return "orderer.example.com:7050"
+}
+
+func (o *configOrderer) SendBroadcast(ctx reqContext.Context, envelope *fab.SignedEnvelope) (*common.Status, error) {
+	return nil, errors.New("not supported")
+}
+
+func (o *configOrderer) SendDeliver(ctx reqContext.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error) {
+	blocks := make(chan *common.Block, 1)
+	errs := make(chan error, 1)
+
+	// The newest block and the last config block are requested in turn
+	blocks <- configBlock(o.t, o.configs[0])
+	o.seeks++
+	if o.seeks%2 == 0 && len(o.configs) > 1 {
+		o.configs = o.configs[1:]
+	}
+	close(blocks)
+	return blocks, errs
+}
@@ -0,0 +1,262 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// DefaultSystemChannelID is the ID of the orderer system channel if no channel ID was given when the orderer
+// genesis block was generated. Note that fab.SystemChannel refers to the peer's system channel, not to the
+// orderer system channel.
+const DefaultSystemChannelID = "testchainid"
+
+// Consortium describes a consortium defined in the orderer system channel
+type Consortium struct {
+	Name   string
+	MSPIDs []string // MSP IDs of the member organizations
+}
+
+// CreateConsortiumRequest holds parameters for creating a consortium
+type CreateConsortiumRequest struct {
+	SystemChannelID   string                // ID of the orderer system channel (defaults to DefaultSystemChannelID)
+	Name              string                // Name of the consortium
+	Orgs              []OrgDefinition       // Member organizations (anchor peers are ignored)
+	SigningIdentities []msp.SigningIdentity // Orderer admins that sign the config update (defaults to the client's identity)
+}
+
+// AddConsortiumOrgRequest holds parameters for adding an organization to a consortium
+type AddConsortiumOrgRequest struct {
+	SystemChannelID   string                // ID of the orderer system channel (defaults to DefaultSystemChannelID)
+	Consortium        string                // Name of the consortium
+	Org               OrgDefinition         // Organization to add (anchor peers are ignored)
+	SigningIdentities []msp.SigningIdentity // Orderer admins that sign the config update (defaults to the client's identity)
+}
+
+// RemoveConsortiumOrgRequest holds parameters for removing an organization from a consortium
+type RemoveConsortiumOrgRequest struct {
+	SystemChannelID   string                // ID of the orderer system channel (defaults to DefaultSystemChannelID)
+	Consortium        string                // Name of the consortium
+	MSPID             string                // MSP ID of the organization
+	SigningIdentities []msp.SigningIdentity // Orderer admins that sign the config update (defaults to the client's identity)
+}
+
+// QueryConsortiums returns the consortiums defined in the orderer system channel along with their member
+// organizations. The configuration of the system channel is only readable by the orderer admins.
+//  Parameters:
+//  systemChannelID is the ID of the orderer system channel (defaults to DefaultSystemChannelID)
+//  options holds optional request options
+//
+//  Returns:
+//  the consortiums sorted by name
+func (rc *Client) QueryConsortiums(systemChannelID string, options ...RequestOption) ([]Consortium, error) {
+
+	configBlock, err := rc.QueryConfigBlockFromOrderer(systemChannelIDOrDefault(systemChannelID), options...)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := resource.ExtractConfigFromBlock(configBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "extracting config from block failed")
+	}
+
+	return consortiumsFromConfig(config)
+}
+
+// CreateConsortium adds a consortium with the given member organizations to the orderer system channel. Members of
+// the consortium may create channels if they are signed by the admin of any of the member organizations.
+// The config update must be signed by the orderer admin(s).
+//  Parameters:
+//  req holds info about the consortium
+//  options holds optional request options
+//
+//  Returns:
+//  save channel response with transaction ID
+func (rc *Client) CreateConsortium(req CreateConsortiumRequest, options ...RequestOption) (SaveChannelResponse, error) {
+
+	if req.Name == "" {
+		return SaveChannelResponse{}, errors.New("must provide consortium name")
+	}
+
+	consortium, err := newConsortiumGroup(req.Orgs)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	logger.Debugf("creating consortium: %s", req.Name)
+
+	return rc.updateChannelConfig(systemChannelIDOrDefault(req.SystemChannelID), req.SigningIdentities, func(config *common.Config) error {
+		consortiums, err := channelGroup(config, channelconfig.ConsortiumsGroupKey)
+		if err != nil {
+			return err
+		}
+		if _, ok := consortiums.Groups[req.Name]; ok {
+			return errors.Errorf("consortium %s already exists", req.Name)
+		}
+		if consortiums.Groups == nil {
+			consortiums.Groups = make(map[string]*common.ConfigGroup)
+		}
+		consortiums.Groups[req.Name] = consortium
+		return nil
+	}, options...)
+}
+
+// AddConsortiumOrg adds the MSP definition of an organization to a consortium of the orderer system channel.
+// The config update must be signed by the orderer admin(s).
+//  Parameters:
+//  req holds info about the consortium and the organization
+//  options holds optional request options
+//
+//  Returns:
+//  save channel response with transaction ID
+func (rc *Client) AddConsortiumOrg(req AddConsortiumOrgRequest, options ...RequestOption) (SaveChannelResponse, error) {
+
+	if req.Consortium == "" {
+		return SaveChannelResponse{}, errors.New("must provide consortium name")
+	}
+
+	org, err := newConsortiumOrgGroup(req.Org)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	logger.Debugf("adding organization %s to consortium: %s", req.Org.MSPID, req.Consortium)
+
+	return rc.updateChannelConfig(systemChannelIDOrDefault(req.SystemChannelID), req.SigningIdentities, func(config *common.Config) error {
+		consortium, err := consortiumGroup(config, req.Consortium)
+		if err != nil {
+			return err
+		}
+		return addOrgGroup(consortium, orgGroupName(req.Org), org, req.Org.MSPID)
+	}, options...)
+}
+
+// RemoveConsortiumOrg removes an organization from a consortium of the orderer system channel. Channels that
+// were already created by the consortium are not affected. The config update must be signed by the orderer admin(s).
+//  Parameters:
+//  req holds info about the consortium and the organization
+//  options holds optional request options
+//
+//  Returns:
+//  save channel response with transaction ID
+func (rc *Client) RemoveConsortiumOrg(req RemoveConsortiumOrgRequest, options ...RequestOption) (SaveChannelResponse, error) {
+
+	if req.Consortium == "" {
+		return SaveChannelResponse{}, errors.New("must provide consortium name")
+	}
+	if req.MSPID == "" {
+		return SaveChannelResponse{}, errors.New("must provide MSP ID")
+	}
+
+	logger.Debugf("removing organization %s from consortium: %s", req.MSPID, req.Consortium)
+
+	return rc.updateChannelConfig(systemChannelIDOrDefault(req.SystemChannelID), req.SigningIdentities, func(config *common.Config) error {
+		consortium, err := consortiumGroup(config, req.Consortium)
+		if err != nil {
+			return err
+		}
+		return removeOrgGroup(consortium, req.MSPID)
+	}, options...)
+}
+
+func systemChannelIDOrDefault(channelID string) string {
+	if channelID == "" {
+		return DefaultSystemChannelID
+	}
+	return channelID
+}
+
+// consortiumsFromConfig returns the consortiums of the system channel configuration sorted by name
+func consortiumsFromConfig(config *common.Config) ([]Consortium, error) {
+	consortiumsGroup, err := channelGroup(config, channelconfig.ConsortiumsGroupKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var consortiums []Consortium
+	for name, group := range consortiumsGroup.Groups {
+		consortium := Consortium{Name: name}
+		for orgName, org := range group.Groups {
+			mspID, err := orgMSPID(org)
+			if err != nil {
+				return nil, errors.WithMessage(err, orgName)
+			}
+			if mspID == "" {
+				mspID = orgName
+			}
+			consortium.MSPIDs = append(consortium.MSPIDs, mspID)
+		}
+		sort.Strings(consortium.MSPIDs)
+		consortiums = append(consortiums, consortium)
+	}
+
+	sort.Slice(consortiums, func(i, j int) bool { return consortiums[i].Name < consortiums[j].Name })
+
+	return consortiums, nil
+}
+
+// consortiumGroup returns the config group of the given consortium
+func consortiumGroup(config *common.Config, name string) (*common.ConfigGroup, error) {
+	consortiums, err := channelGroup(config, channelconfig.ConsortiumsGroupKey)
+	if err != nil {
+		return nil, err
+	}
+	consortium, ok := consortiums.Groups[name]
+	if !ok {
+		return nil, errors.Errorf("consortium %s not found", name)
+	}
+	return consortium, nil
+}
+
+// newConsortiumGroup creates the config group of a consortium the same way as configtxgen: the channel creation
+// policy is satisfied by the admin of any member organization and the group is modified by the orderer admins
+func newConsortiumGroup(orgs []OrgDefinition) (*common.ConfigGroup, error) {
+	policyBytes, err := proto.Marshal(&common.ImplicitMetaPolicy{Rule: common.ImplicitMetaPolicy_ANY, SubPolicy: channelconfig.AdminsPolicyKey})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal implicit meta policy failed")
+	}
+
+	group := &common.ConfigGroup{
+		ModPolicy: ordererAdminsPolicy,
+		Groups:    make(map[string]*common.ConfigGroup),
+		Values:    make(map[string]*common.ConfigValue),
+		Policies:  make(map[string]*common.ConfigPolicy),
+	}
+	channelCreationPolicy := &common.Policy{Type: int32(common.Policy_IMPLICIT_META), Value: policyBytes}
+	if err := updateConfigValue(group, channelconfig.ChannelCreationPolicyKey, ordererAdminsPolicy, channelCreationPolicy); err != nil {
+		return nil, err
+	}
+
+	for _, def := range orgs {
+		org, err := newConsortiumOrgGroup(def)
+		if err != nil {
+			return nil, err
+		}
+		if err := addOrgGroup(group, orgGroupName(def), org, def.MSPID); err != nil {
+			return nil, err
+		}
+	}
+
+	return group, nil
+}
+
+// newConsortiumOrgGroup creates the config group of a consortium member organization
+func newConsortiumOrgGroup(def OrgDefinition) (*common.ConfigGroup, error) {
+	mspConfig, err := orgMSPConfig(def)
+	if err != nil {
+		return nil, err
+	}
+	return newOrgGroup(def.MSPID, mspConfig)
+}
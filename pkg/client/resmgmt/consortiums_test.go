@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsortiumsError(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	_, err := rc.CreateConsortium(CreateConsortiumRequest{Orgs: []OrgDefinition{{MSPID: "Org3MSP", MSPDir: org3MSPDir}}})
+	assert.Error(t, err, "expecting error for missing consortium name")
+
+	_, err = rc.CreateConsortium(CreateConsortiumRequest{Name: "SampleConsortium", Orgs: []OrgDefinition{{MSPID: "Org3MSP"}}})
+	assert.Error(t, err, "expecting error for missing MSP config")
+
+	_, err = rc.AddConsortiumOrg(AddConsortiumOrgRequest{Org: OrgDefinition{MSPID: "Org3MSP", MSPDir: org3MSPDir}})
+	assert.Error(t, err, "expecting error for missing consortium name")
+
+	_, err = rc.RemoveConsortiumOrg(RemoveConsortiumOrgRequest{Consortium: "SampleConsortium"})
+	assert.Error(t, err, "expecting error for missing MSP ID")
+
+	orderer := fcmocks.NewMockOrderer("", nil)
+	defer orderer.Close()
+	orderer.EnqueueForSendDeliver(fcmocks.NewSimpleMockError())
+
+	_, err = rc.QueryConsortiums("", WithOrderer(orderer))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "LastConfigFromOrderer failed")
+}
+
+func TestConsortiumsConfig(t *testing.T) {
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+
+	_, err = consortiumsFromConfig(config)
+	assert.Error(t, err, "expecting error since channel isn't the system channel")
+
+	consortium, err := newConsortiumGroup([]OrgDefinition{{MSPID: "Org3MSP", Name: "Org3", MSPDir: org3MSPDir}})
+	require.NoError(t, err)
+	assert.Equal(t, "/Channel/Orderer/Admins", consortium.ModPolicy)
+	assert.Contains(t, consortium.Groups, "Org3")
+
+	policy := &common.Policy{}
+	require.NoError(t, proto.Unmarshal(consortium.Values["ChannelCreationPolicy"].Value, policy))
+	assert.Equal(t, int32(common.Policy_IMPLICIT_META), policy.Type)
+	implicitMetaPolicy := &common.ImplicitMetaPolicy{}
+	require.NoError(t, proto.Unmarshal(policy.Value, implicitMetaPolicy))
+	assert.Equal(t, common.ImplicitMetaPolicy_ANY, implicitMetaPolicy.Rule)
+	assert.Equal(t, "Admins", implicitMetaPolicy.SubPolicy)
+
+	config.ChannelGroup.Groups["Consortiums"] = &common.ConfigGroup{
+		Groups: map[string]*common.ConfigGroup{
+			"SampleConsortium": consortium,
+			"EmptyConsortium":  {},
+		},
+	}
+
+	consortiums, err := consortiumsFromConfig(config)
+	require.NoError(t, err)
+	assert.Equal(t, []Consortium{{Name: "EmptyConsortium"}, {Name: "SampleConsortium", MSPIDs: []string{"Org3MSP"}}}, consortiums)
+
+	sampleConsortium, err := consortiumGroup(config, "SampleConsortium")
+	require.NoError(t, err)
+	_, err = consortiumGroup(config, "OtherConsortium")
+	assert.Error(t, err, "expecting error since consortium doesn't exist")
+
+	org4, err := newConsortiumOrgGroup(OrgDefinition{MSPID: "Org4MSP", MSPDir: org3MSPDir})
+	require.NoError(t, err)
+	assert.NotContains(t, org4.Values, "AnchorPeers")
+	require.NoError(t, addOrgGroup(sampleConsortium, "Org4MSP", org4, "Org4MSP"))
+	require.NoError(t, removeOrgGroup(sampleConsortium, "Org3MSP"))
+
+	consortiums, err = consortiumsFromConfig(config)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Org4MSP"}, consortiums[1].MSPIDs)
+}
@@ -0,0 +1,705 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	reqContext "context"
+	"fmt"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	lifecyclepkg "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/lifecycle"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/pkg/errors"
+)
+
+const (
+	lifecycleCC                           = "_lifecycle"
+	lifecycleInstallFuncName              = "InstallChaincode"
+	lifecycleQueryInstalledFuncName       = "QueryInstalledChaincodes"
+	lifecycleApproveFuncName              = "ApproveChaincodeDefinitionForMyOrg"
+	lifecycleCheckCommitReadinessFuncName = "CheckCommitReadiness"
+	lifecycleCommitFuncName               = "CommitChaincodeDefinition"
+	lifecycleQueryDefinitionFuncName      = "QueryChaincodeDefinition"
+	lifecycleQueryDefinitionsFuncName     = "QueryChaincodeDefinitions"
+)
+
+// LifecycleInstallCCRequest contains the parameters for installing a chaincode package of the Fabric 2.x
+// chaincode lifecycle (see package ccpackager/lifecycle)
+type LifecycleInstallCCRequest struct {
+	Package []byte
+}
+
+// LifecycleInstallCCResponse contains the response from installing a lifecycle chaincode package on a peer
+type LifecycleInstallCCResponse struct {
+	Target    string
+	Status    int32
+	PackageID string
+	Info      string
+}
+
+// LifecycleInstalledCC contains a chaincode package installed on a peer
+type LifecycleInstalledCC struct {
+	PackageID string
+	Label     string
+	// References are the chaincode definitions that use the package, per channel
+	References map[string][]CCReference
+}
+
+// CCReference is the name and version of a chaincode definition
+type CCReference struct {
+	Name    string
+	Version string
+}
+
+// ApproveCCRequest contains the chaincode definition that is approved for the client's organization
+type ApproveCCRequest struct {
+	Name     string
+	Version  string
+	Sequence int64
+	// PackageID is the ID of the installed package that the organization's peers use for the chaincode (may be
+	// empty if the organization doesn't endorse the chaincode's transactions)
+	PackageID         string
+	EndorsementPlugin string
+	ValidationPlugin  string
+	// SignaturePolicy or ChannelConfigPolicy (for example, /Channel/Application/Endorsement) is the endorsement
+	// policy of the chaincode. If neither is set then the peer uses the default endorsement policy of the channel.
+	SignaturePolicy     *common.SignaturePolicyEnvelope
+	ChannelConfigPolicy string
+	CollectionConfig    []*common.CollectionConfig
+	InitRequired        bool
+}
+
+// ApproveCCResponse contains response parameters for approving a chaincode definition
+type ApproveCCResponse struct {
+	TransactionID fab.TransactionID
+}
+
+// CheckCCCommitReadinessRequest contains the chaincode definition whose approvals are checked
+type CheckCCCommitReadinessRequest struct {
+	Name                string
+	Version             string
+	Sequence            int64
+	EndorsementPlugin   string
+	ValidationPlugin    string
+	SignaturePolicy     *common.SignaturePolicyEnvelope
+	ChannelConfigPolicy string
+	CollectionConfig    []*common.CollectionConfig
+	InitRequired        bool
+}
+
+// CheckCCCommitReadinessResponse contains the approvals of a chaincode definition
+type CheckCCCommitReadinessResponse struct {
+	// Approvals is true for each organization (MSP ID) that approved the chaincode definition
+	Approvals map[string]bool
+}
+
+// CommitCCRequest contains the chaincode definition that is committed to a channel
+type CommitCCRequest struct {
+	Name                string
+	Version             string
+	Sequence            int64
+	EndorsementPlugin   string
+	ValidationPlugin    string
+	SignaturePolicy     *common.SignaturePolicyEnvelope
+	ChannelConfigPolicy string
+	CollectionConfig    []*common.CollectionConfig
+	InitRequired        bool
+}
+
+// CommitCCResponse contains response parameters for committing a chaincode definition
+type CommitCCResponse struct {
+	TransactionID fab.TransactionID
+}
+
+// QueryCommittedCCRequest contains the parameters for querying the chaincode definitions committed to a channel
+type QueryCommittedCCRequest struct {
+	// Name is the name of the chaincode (all committed definitions are returned if it is empty)
+	Name string
+}
+
+// CommittedCCDefinition contains a chaincode definition committed to a channel
+type CommittedCCDefinition struct {
+	Name                string
+	Version             string
+	Sequence            int64
+	EndorsementPlugin   string
+	ValidationPlugin    string
+	SignaturePolicy     *common.SignaturePolicyEnvelope
+	ChannelConfigPolicy string
+	CollectionConfig    []*common.CollectionConfig
+	InitRequired        bool
+	// Approvals of the organizations of the channel (only returned when the chaincode name is queried)
+	Approvals map[string]bool
+}
+
+// InstallLifecycleCC installs a chaincode package of the Fabric 2.x chaincode lifecycle on peers. Peers that have
+// already installed the package are skipped. If peer(s) are not specified in options it will default to all peers
+// that belong to admin's MSP.
+//  Parameters:
+//  req holds the mandatory chaincode package (see ccpackager/lifecycle)
+//  options holds optional request options
+//
+//  Returns:
+//  install chaincode responses from peer(s), with the ID of the installed package
+func (rc *Client) InstallLifecycleCC(req LifecycleInstallCCRequest, options ...RequestOption) ([]LifecycleInstallCCResponse, error) {
+	if len(req.Package) == 0 {
+		return nil, errors.New("chaincode package is required")
+	}
+
+	metadata, _, err := lifecyclepkg.ReadCCPackage(req.Package)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid lifecycle chaincode package")
+	}
+	packageID := lifecyclepkg.ComputePackageID(metadata.Label, req.Package)
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get opts for InstallLifecycleCC")
+	}
+
+	targets, err := rc.installLifecycleCCTargets(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.ResMgmt)
+	defer cancel()
+
+	// Targets that have already installed the package are skipped
+	responses, newTargets, errs := rc.lifecycleCCNewTargets(reqCtx, packageID, targets, opts.Retry)
+	if len(newTargets) == 0 {
+		return responses, errs.ToError()
+	}
+
+	for _, target := range newTargets {
+		response, err := rc.installLifecycleCC(reqCtx, req.Package, target, opts.Retry)
+		if err != nil {
+			errs = append(errs, errors.WithMessage(err, fmt.Sprintf("installing lifecycle chaincode on %s failed", target.URL())))
+			continue
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, errs.ToError()
+}
+
+// installLifecycleCCTargets returns the target peers of the options, defaulting to the peers of the admin's MSP
+func (rc *Client) installLifecycleCCTargets(opts *requestOptions) ([]fab.Peer, error) {
+	//Default targets when targets are not provided in options
+	defaultTargets, err := rc.resolveDefaultTargets(opts)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get default targets for InstallLifecycleCC")
+	}
+
+	targets, err := rc.calculateTargets(defaultTargets, opts.TargetFilter)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to determine target peers for install cc")
+	}
+
+	if len(targets) == 0 {
+		return nil, errors.WithStack(status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "no targets available", nil))
+	}
+
+	return targets, nil
+}
+
+// lifecycleCCNewTargets returns the targets that haven't installed the package yet, along with
+// the responses of the targets that have
+func (rc *Client) lifecycleCCNewTargets(reqCtx reqContext.Context, packageID string, targets []fab.Peer, retryOpts retry.Opts) ([]LifecycleInstallCCResponse, []fab.Peer, multi.Errors) {
+	responses := make([]LifecycleInstallCCResponse, 0)
+	newTargets := make([]fab.Peer, 0)
+	errs := multi.Errors{}
+	for _, target := range targets {
+		installed, err := rc.isLifecycleCCInstalled(reqCtx, packageID, target, retryOpts)
+		if err != nil {
+			errs = append(errs, errors.Errorf("unable to verify if cc is installed on %s. Got error: %s", target.URL(), err))
+			continue
+		}
+		if installed {
+			responses = append(responses, LifecycleInstallCCResponse{Target: target.URL(), PackageID: packageID, Info: "already installed"})
+		} else {
+			newTargets = append(newTargets, target)
+		}
+	}
+	return responses, newTargets, errs
+}
+
+// installLifecycleCC installs a lifecycle chaincode package on a single target, so that a failing target doesn't
+// affect (or resend the package to) the other targets
+func (rc *Client) installLifecycleCC(reqCtx reqContext.Context, pkg []byte, target fab.Peer, retryOpts retry.Opts) (LifecycleInstallCCResponse, error) {
+	tprs, err := rc.sendLifecycleProposal(reqCtx, fab.SystemChannel, lifecycleInstallFuncName, &lb.InstallChaincodeArgs{ChaincodeInstallPackage: pkg}, []fab.ProposalProcessor{target}, retryOpts)
+	if err != nil {
+		return LifecycleInstallCCResponse{}, err
+	}
+
+	tpr := tprs[0]
+	logger.Debugf("Install lifecycle chaincode endorser '%s' returned ProposalResponse status:%v", tpr.Endorser, tpr.Status)
+
+	result := &lb.InstallChaincodeResult{}
+	if err := proto.Unmarshal(tpr.ProposalResponse.GetResponse().GetPayload(), result); err != nil {
+		return LifecycleInstallCCResponse{}, errors.Wrap(err, "unmarshal of install chaincode result failed")
+	}
+	return LifecycleInstallCCResponse{Target: tpr.Endorser, Status: tpr.Status, PackageID: result.PackageId}, nil
+}
+
+// isLifecycleCCInstalled verifies if a lifecycle chaincode package is installed on the peer
+func (rc *Client) isLifecycleCCInstalled(reqCtx reqContext.Context, packageID string, target fab.ProposalProcessor, retryOpts retry.Opts) (bool, error) {
+	installedCCs, err := rc.queryInstalledLifecycleCC(reqCtx, target, retryOpts)
+	if err != nil {
+		return false, err
+	}
+
+	for _, installedCC := range installedCCs {
+		if installedCC.PackageID == packageID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// QueryInstalledLifecycleCC queries the chaincode packages of the Fabric 2.x chaincode lifecycle installed on a peer.
+//  Parameters:
+//  options hold optional request options
+//  Note: One target(peer) has to be specified using either WithTargetURLs or WithTargets request option
+//
+//  Returns:
+//  the chaincode packages installed on the peer
+func (rc *Client) QueryInstalledLifecycleCC(options ...RequestOption) ([]LifecycleInstalledCC, error) {
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Targets) != 1 {
+		return nil, errors.New("only one target is supported")
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.PeerResponse)
+	defer cancel()
+
+	return rc.queryInstalledLifecycleCC(reqCtx, opts.Targets[0], opts.Retry)
+}
+
+func (rc *Client) queryInstalledLifecycleCC(reqCtx reqContext.Context, target fab.ProposalProcessor, retryOpts retry.Opts) ([]LifecycleInstalledCC, error) {
+	tprs, err := rc.sendLifecycleProposal(reqCtx, fab.SystemChannel, lifecycleQueryInstalledFuncName, &lb.QueryInstalledChaincodesArgs{}, []fab.ProposalProcessor{target}, retryOpts)
+	if err != nil {
+		return nil, errors.WithMessage(err, "querying installed chaincodes failed")
+	}
+
+	result := &lb.QueryInstalledChaincodesResult{}
+	if err := proto.Unmarshal(tprs[0].ProposalResponse.GetResponse().GetPayload(), result); err != nil {
+		return nil, errors.Wrap(err, "unmarshal of installed chaincodes failed")
+	}
+
+	var installedCCs []LifecycleInstalledCC
+	for _, cc := range result.InstalledChaincodes {
+		installedCC := LifecycleInstalledCC{PackageID: cc.PackageId, Label: cc.Label, References: make(map[string][]CCReference)}
+		for channelID, references := range cc.References {
+			for _, chaincode := range references.Chaincodes {
+				installedCC.References[channelID] = append(installedCC.References[channelID], CCReference{Name: chaincode.Name, Version: chaincode.Version})
+			}
+		}
+		installedCCs = append(installedCCs, installedCC)
+	}
+	return installedCCs, nil
+}
+
+// ApproveCC approves a chaincode definition of the Fabric 2.x chaincode lifecycle for the client's organization.
+// If peer(s) are not specified in options it will default to the channel peers of admin's MSP.
+//  Parameters:
+//  channelID is mandatory channel name
+//  req holds info about the mandatory chaincode name, version and sequence and the optional package ID, policy and collections
+//  options holds optional request options
+//
+//  Returns:
+//  approve chaincode response with transaction ID
+func (rc *Client) ApproveCC(channelID string, req ApproveCCRequest, options ...RequestOption) (ApproveCCResponse, error) {
+	if err := checkRequiredLifecycleCCParams(channelID, req.Name, req.Version, req.Sequence); err != nil {
+		return ApproveCCResponse{}, err
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return ApproveCCResponse{}, errors.WithMessage(err, "failed to get opts for ApproveCC")
+	}
+
+	validationParameter, err := lifecycleValidationParameter(req.SignaturePolicy, req.ChannelConfigPolicy)
+	if err != nil {
+		return ApproveCCResponse{}, err
+	}
+
+	args := &lb.ApproveChaincodeDefinitionForMyOrgArgs{
+		Name:                req.Name,
+		Version:             req.Version,
+		Sequence:            req.Sequence,
+		EndorsementPlugin:   req.EndorsementPlugin,
+		ValidationPlugin:    req.ValidationPlugin,
+		ValidationParameter: validationParameter,
+		Collections:         lifecycleCollections(req.CollectionConfig),
+		InitRequired:        req.InitRequired,
+		Source:              lifecycleCCSource(req.PackageID),
+	}
+
+	// The approval is endorsed by the peers of the client's organization
+	localOnly := len(opts.Targets) == 0
+	targets, err := rc.getCCProposalTargets(channelID, opts)
+	if err != nil {
+		return ApproveCCResponse{}, err
+	}
+	if localOnly {
+		targets = filterTargets(targets, &mspFilter{mspID: rc.ctx.Identifier().MSPID})
+		if len(targets) == 0 {
+			return ApproveCCResponse{}, errors.WithStack(status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "no targets available", nil))
+		}
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.ResMgmt)
+	defer cancel()
+
+	txID, err := rc.sendLifecycleTransaction(reqCtx, channelID, lifecycleApproveFuncName, args, targets)
+	return ApproveCCResponse{TransactionID: txID}, err
+}
+
+// CheckCCCommitReadiness checks which organizations of the channel have approved a chaincode definition of the
+// Fabric 2.x chaincode lifecycle. If peer is not specified in options it will query a random peer of the admin's
+// MSP on this channel.
+//  Parameters:
+//  channelID is mandatory channel name
+//  req holds info about the mandatory chaincode name, version and sequence and the optional policy and collections
+//  options holds optional request options
+//
+//  Returns:
+//  the approvals of the organizations of the channel
+func (rc *Client) CheckCCCommitReadiness(channelID string, req CheckCCCommitReadinessRequest, options ...RequestOption) (CheckCCCommitReadinessResponse, error) {
+	if err := checkRequiredLifecycleCCParams(channelID, req.Name, req.Version, req.Sequence); err != nil {
+		return CheckCCCommitReadinessResponse{}, err
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return CheckCCCommitReadinessResponse{}, err
+	}
+
+	validationParameter, err := lifecycleValidationParameter(req.SignaturePolicy, req.ChannelConfigPolicy)
+	if err != nil {
+		return CheckCCCommitReadinessResponse{}, err
+	}
+
+	args := &lb.CheckCommitReadinessArgs{
+		Name:                req.Name,
+		Version:             req.Version,
+		Sequence:            req.Sequence,
+		EndorsementPlugin:   req.EndorsementPlugin,
+		ValidationPlugin:    req.ValidationPlugin,
+		ValidationParameter: validationParameter,
+		Collections:         lifecycleCollections(req.CollectionConfig),
+		InitRequired:        req.InitRequired,
+	}
+
+	result := &lb.CheckCommitReadinessResult{}
+	if err := rc.queryLifecycle(channelID, lifecycleCheckCommitReadinessFuncName, args, result, opts); err != nil {
+		return CheckCCCommitReadinessResponse{}, errors.WithMessage(err, "checking commit readiness failed")
+	}
+
+	return CheckCCCommitReadinessResponse{Approvals: result.Approvals}, nil
+}
+
+// CommitCC commits a chaincode definition of the Fabric 2.x chaincode lifecycle to a channel. The definition must
+// have been approved by enough organizations to satisfy the channel's lifecycle endorsement policy (see
+// CheckCCCommitReadiness). If peer(s) are not specified in options it will default to all channel peers.
+//  Parameters:
+//  channelID is mandatory channel name
+//  req holds info about the mandatory chaincode name, version and sequence and the optional policy and collections
+//  options holds optional request options
+//
+//  Returns:
+//  commit chaincode response with transaction ID
+func (rc *Client) CommitCC(channelID string, req CommitCCRequest, options ...RequestOption) (CommitCCResponse, error) {
+	if err := checkRequiredLifecycleCCParams(channelID, req.Name, req.Version, req.Sequence); err != nil {
+		return CommitCCResponse{}, err
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return CommitCCResponse{}, errors.WithMessage(err, "failed to get opts for CommitCC")
+	}
+
+	validationParameter, err := lifecycleValidationParameter(req.SignaturePolicy, req.ChannelConfigPolicy)
+	if err != nil {
+		return CommitCCResponse{}, err
+	}
+
+	args := &lb.CommitChaincodeDefinitionArgs{
+		Name:                req.Name,
+		Version:             req.Version,
+		Sequence:            req.Sequence,
+		EndorsementPlugin:   req.EndorsementPlugin,
+		ValidationPlugin:    req.ValidationPlugin,
+		ValidationParameter: validationParameter,
+		Collections:         lifecycleCollections(req.CollectionConfig),
+		InitRequired:        req.InitRequired,
+	}
+
+	targets, err := rc.getCCProposalTargets(channelID, opts)
+	if err != nil {
+		return CommitCCResponse{}, err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.ResMgmt)
+	defer cancel()
+
+	txID, err := rc.sendLifecycleTransaction(reqCtx, channelID, lifecycleCommitFuncName, args, targets)
+	return CommitCCResponse{TransactionID: txID}, err
+}
+
+// QueryCommittedCC queries the chaincode definitions of the Fabric 2.x chaincode lifecycle committed to a channel.
+// If peer is not specified in options it will query a random peer of the admin's MSP on this channel.
+//  Parameters:
+//  channelID is mandatory channel name
+//  req holds the optional chaincode name (all committed definitions are queried if it is empty)
+//  options holds optional request options
+//
+//  Returns:
+//  the committed chaincode definitions
+func (rc *Client) QueryCommittedCC(channelID string, req QueryCommittedCCRequest, options ...RequestOption) ([]CommittedCCDefinition, error) {
+	if channelID == "" {
+		return nil, errors.New("must provide channel ID")
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		result := &lb.QueryChaincodeDefinitionResult{}
+		if err := rc.queryLifecycle(channelID, lifecycleQueryDefinitionFuncName, &lb.QueryChaincodeDefinitionArgs{Name: req.Name}, result, opts); err != nil {
+			return nil, errors.WithMessage(err, "querying committed chaincode definition failed")
+		}
+
+		definition, err := newCommittedCCDefinition(req.Name, result.Version, result.Sequence, result.EndorsementPlugin, result.ValidationPlugin, result.ValidationParameter, result.Collections, result.InitRequired)
+		if err != nil {
+			return nil, err
+		}
+		definition.Approvals = result.Approvals
+		return []CommittedCCDefinition{definition}, nil
+	}
+
+	result := &lb.QueryChaincodeDefinitionsResult{}
+	if err := rc.queryLifecycle(channelID, lifecycleQueryDefinitionsFuncName, &lb.QueryChaincodeDefinitionsArgs{}, result, opts); err != nil {
+		return nil, errors.WithMessage(err, "querying committed chaincode definitions failed")
+	}
+
+	var definitions []CommittedCCDefinition
+	for _, cd := range result.ChaincodeDefinitions {
+		definition, err := newCommittedCCDefinition(cd.Name, cd.Version, cd.Sequence, cd.EndorsementPlugin, cd.ValidationPlugin, cd.ValidationParameter, cd.Collections, cd.InitRequired)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+func newCommittedCCDefinition(name, version string, sequence int64, endorsementPlugin, validationPlugin string, validationParameter []byte, collections *common.CollectionConfigPackage, initRequired bool) (CommittedCCDefinition, error) {
+	definition := CommittedCCDefinition{
+		Name:              name,
+		Version:           version,
+		Sequence:          sequence,
+		EndorsementPlugin: endorsementPlugin,
+		ValidationPlugin:  validationPlugin,
+		CollectionConfig:  collections.GetConfig(),
+		InitRequired:      initRequired,
+	}
+
+	if len(validationParameter) > 0 {
+		policy := &pb.ApplicationPolicy{}
+		if err := proto.Unmarshal(validationParameter, policy); err != nil {
+			return CommittedCCDefinition{}, errors.Wrapf(err, "unmarshal of validation parameter of chaincode %s failed", name)
+		}
+		definition.SignaturePolicy = policy.GetSignaturePolicy()
+		definition.ChannelConfigPolicy = policy.GetChannelConfigPolicyReference()
+	}
+
+	return definition, nil
+}
+
+// queryLifecycle sends a query of the lifecycle chaincode to a channel peer and unmarshals the result
+func (rc *Client) queryLifecycle(channelID, fcn string, args proto.Message, result proto.Message, opts requestOptions) error {
+	_, target, v, err := rc.prepareChannelQuery(channelID, opts)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.PeerResponse)
+	defer cancel()
+
+	tprs, err := rc.sendLifecycleProposal(reqCtx, channelID, fcn, args, []fab.ProposalProcessor{target}, opts.Retry)
+	if err != nil {
+		return err
+	}
+
+	if err := v.Verify(tprs[0]); err != nil {
+		return errors.WithMessage(err, "failed to verify signature")
+	}
+
+	return errors.Wrap(proto.Unmarshal(tprs[0].ProposalResponse.GetResponse().GetPayload(), result), "unmarshal of lifecycle result failed")
+}
+
+// sendLifecycleProposal sends a proposal of the lifecycle chaincode to the targets (which must all succeed)
+func (rc *Client) sendLifecycleProposal(reqCtx reqContext.Context, channelID, fcn string, args proto.Message, targets []fab.ProposalProcessor, retryOpts retry.Opts) ([]*fab.TransactionProposalResponse, error) {
+	tp, err := rc.createLifecycleProposal(channelID, fcn, args)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := retry.NewInvoker(retry.New(retryOpts)).Invoke(
+		func() (interface{}, error) {
+			return txn.SendProposal(reqCtx, tp, targets)
+		},
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "sending lifecycle proposal failed")
+	}
+
+	tprs := resp.([]*fab.TransactionProposalResponse)
+	if err := checkLifecycleResponses(tprs); err != nil {
+		return nil, err
+	}
+	return tprs, nil
+}
+
+// sendLifecycleTransaction endorses a transaction of the lifecycle chaincode, sends it to the orderer and waits for it
+// to be committed
+func (rc *Client) sendLifecycleTransaction(reqCtx reqContext.Context, channelID, fcn string, args proto.Message, targets []fab.Peer) (fab.TransactionID, error) {
+	channelService, err := rc.ctx.ChannelProvider().ChannelService(rc.ctx, channelID)
+	if err != nil {
+		return fab.EmptyTransactionID, errors.WithMessage(err, "Unable to get channel service")
+	}
+
+	transactor, err := channelService.Transactor(reqCtx)
+	if err != nil {
+		return fab.EmptyTransactionID, errors.WithMessage(err, "get channel transactor failed")
+	}
+
+	tp, err := rc.createLifecycleProposal(channelID, fcn, args)
+	if err != nil {
+		return fab.EmptyTransactionID, err
+	}
+
+	txProposalResponse, err := transactor.SendTransactionProposal(tp, peersToTxnProcessors(targets))
+	if err != nil {
+		return tp.TxnID, errors.WithMessage(err, "sending lifecycle transaction proposal failed")
+	}
+
+	if err := checkLifecycleResponses(txProposalResponse); err != nil {
+		return tp.TxnID, err
+	}
+
+	err = rc.verifyTPSignature(channelService, txProposalResponse)
+	if err != nil {
+		return tp.TxnID, errors.WithMessage(err, "sending lifecycle transaction proposal failed to verify signature")
+	}
+
+	eventService, err := channelService.EventService()
+	if err != nil {
+		return tp.TxnID, errors.WithMessage(err, "unable to get event service")
+	}
+
+	return rc.sendTransactionAndCheckEvent(eventService, tp, txProposalResponse, transactor, reqCtx, fcn)
+}
+
+// createLifecycleProposal creates a proposal that invokes a function of the lifecycle chaincode with the marshalled
+// arguments of the function
+func (rc *Client) createLifecycleProposal(channelID, fcn string, args proto.Message) (*fab.TransactionProposal, error) {
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of lifecycle arguments failed")
+	}
+
+	txh, err := txn.NewHeader(rc.ctx, channelID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "create transaction ID failed")
+	}
+
+	cir := fab.ChaincodeInvokeRequest{
+		ChaincodeID: lifecycleCC,
+		Fcn:         fcn,
+		Args:        [][]byte{argsBytes},
+	}
+	tp, err := txn.CreateChaincodeInvokeProposal(txh, cir)
+	if err != nil {
+		return nil, errors.WithMessage(err, "creating lifecycle proposal failed")
+	}
+	return tp, nil
+}
+
+func checkLifecycleResponses(tprs []*fab.TransactionProposalResponse) error {
+	for _, tpr := range tprs {
+		if tpr.Status != http.StatusOK {
+			return errors.WithStack(status.NewFromProposalResponse(tpr.ProposalResponse, tpr.Endorser))
+		}
+	}
+	return nil
+}
+
+func checkRequiredLifecycleCCParams(channelID, name, version string, sequence int64) error {
+	if channelID == "" {
+		return errors.New("must provide channel ID")
+	}
+
+	if name == "" || version == "" || sequence <= 0 {
+		return errors.New("Chaincode name, version and sequence are required")
+	}
+	return nil
+}
+
+// lifecycleValidationParameter returns the validation parameter of a chaincode definition, which is its endorsement
+// policy (nil if the default endorsement policy of the channel is used)
+func lifecycleValidationParameter(signaturePolicy *common.SignaturePolicyEnvelope, channelConfigPolicy string) ([]byte, error) {
+	var policy *pb.ApplicationPolicy
+	switch {
+	case signaturePolicy != nil && channelConfigPolicy != "":
+		return nil, errors.New("only one of signature policy and channel config policy may be provided")
+	case signaturePolicy != nil:
+		policy = &pb.ApplicationPolicy{Type: &pb.ApplicationPolicy_SignaturePolicy{SignaturePolicy: signaturePolicy}}
+	case channelConfigPolicy != "":
+		policy = &pb.ApplicationPolicy{Type: &pb.ApplicationPolicy_ChannelConfigPolicyReference{ChannelConfigPolicyReference: channelConfigPolicy}}
+	default:
+		return nil, nil
+	}
+
+	policyBytes, err := proto.Marshal(policy)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of endorsement policy failed")
+	}
+	return policyBytes, nil
+}
+
+func lifecycleCollections(collConfig []*common.CollectionConfig) *common.CollectionConfigPackage {
+	if collConfig == nil {
+		return nil
+	}
+	return &common.CollectionConfigPackage{Config: collConfig}
+}
+
+// lifecycleCCSource returns the package that the organization's peers use for the chaincode (unavailable if no
+// package ID is provided)
+func lifecycleCCSource(packageID string) *lb.ChaincodeSource {
+	if packageID == "" {
+		return &lb.ChaincodeSource{Type: &lb.ChaincodeSource_Unavailable_{Unavailable: &lb.ChaincodeSource_Unavailable{}}}
+	}
+	return &lb.ChaincodeSource{Type: &lb.ChaincodeSource_LocalPackage{LocalPackage: &lb.ChaincodeSource_Local{PackageId: packageID}}}
+}
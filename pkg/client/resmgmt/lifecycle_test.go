@@ -0,0 +1,384 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	reqContext "context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	lifecyclepkg "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/lifecycle"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer/lifecycle"
+	protos_utils "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallLifecycleCC(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	pkgBytes, err := lifecyclepkg.NewCCPackage("mycc_1", "github.com/example_cc", &resource.CCPackage{Type: pb.ChaincodeSpec_GOLANG, Code: []byte("code")})
+	require.NoError(t, err)
+	packageID := lifecyclepkg.ComputePackageID("mycc_1", pkgBytes)
+
+	_, err = rc.InstallLifecycleCC(LifecycleInstallCCRequest{})
+	assert.Error(t, err, "expecting error for missing package")
+
+	_, err = rc.InstallLifecycleCC(LifecycleInstallCCRequest{Package: []byte("invalid")})
+	assert.Error(t, err, "expecting error for invalid package")
+
+	// The package is installed on peers that don't have it
+	peer1 := newLifecyclePeer(t, "grpc://peer1.com")
+	peer1.results[lifecycleQueryInstalledFuncName] = &lb.QueryInstalledChaincodesResult{}
+	peer1.results[lifecycleInstallFuncName] = &lb.InstallChaincodeResult{PackageId: packageID, Label: "mycc_1"}
+
+	responses, err := rc.InstallLifecycleCC(LifecycleInstallCCRequest{Package: pkgBytes}, WithTargets(peer1))
+	require.NoError(t, err)
+	assert.Equal(t, []LifecycleInstallCCResponse{{Target: "grpc://peer1.com", Status: http.StatusOK, PackageID: packageID}}, responses)
+	assert.Equal(t, fab.SystemChannel, peer1.channelID)
+
+	args := &lb.InstallChaincodeArgs{}
+	require.NoError(t, proto.Unmarshal(peer1.args[lifecycleInstallFuncName], args))
+	assert.Equal(t, pkgBytes, args.ChaincodeInstallPackage)
+
+	// Peers that have already installed the package are skipped
+	peer2 := newLifecyclePeer(t, "grpc://peer2.com")
+	peer2.results[lifecycleQueryInstalledFuncName] = &lb.QueryInstalledChaincodesResult{
+		InstalledChaincodes: []*lb.QueryInstalledChaincodesResult_InstalledChaincode{{PackageId: packageID, Label: "mycc_1"}},
+	}
+
+	responses, err = rc.InstallLifecycleCC(LifecycleInstallCCRequest{Package: pkgBytes}, WithTargets(peer2))
+	require.NoError(t, err)
+	assert.Equal(t, []LifecycleInstallCCResponse{{Target: "grpc://peer2.com", PackageID: packageID, Info: "already installed"}}, responses)
+	assert.NotContains(t, peer2.args, lifecycleInstallFuncName)
+
+	// Install fails
+	peer3 := newLifecyclePeer(t, "grpc://peer3.com")
+	peer3.results[lifecycleQueryInstalledFuncName] = &lb.QueryInstalledChaincodesResult{}
+	peer3.failures[lifecycleInstallFuncName] = "chaincode install failed"
+
+	_, err = rc.InstallLifecycleCC(LifecycleInstallCCRequest{Package: pkgBytes}, WithTargets(peer3))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "chaincode install failed")
+
+	// Failing targets don't affect the other targets, and only failing targets are retried
+	peer4 := newLifecyclePeer(t, "grpc://peer4.com")
+	peer4.results[lifecycleQueryInstalledFuncName] = &lb.QueryInstalledChaincodesResult{}
+	peer4.results[lifecycleInstallFuncName] = &lb.InstallChaincodeResult{PackageId: packageID, Label: "mycc_1"}
+	peer5 := newLifecyclePeer(t, "grpc://peer5.com")
+	peer5.results[lifecycleQueryInstalledFuncName] = &lb.QueryInstalledChaincodesResult{}
+	peer5.errs[lifecycleInstallFuncName] = status.New(status.EndorserClientStatus, status.ConnectionFailed.ToInt32(), "connection failed", nil)
+
+	retryOpts := retry.Opts{
+		Attempts:       2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		BackoffFactor:  1,
+		RetryableCodes: map[status.Group][]status.Code{status.EndorserClientStatus: {status.ConnectionFailed}},
+	}
+	responses, err = rc.InstallLifecycleCC(LifecycleInstallCCRequest{Package: pkgBytes}, WithTargets(peer4, peer5), WithRetry(retryOpts))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "grpc://peer5.com")
+	assert.Equal(t, []LifecycleInstallCCResponse{{Target: "grpc://peer4.com", Status: http.StatusOK, PackageID: packageID}}, responses)
+	assert.Equal(t, 1, peer4.calls[lifecycleInstallFuncName])
+	assert.Equal(t, 3, peer5.calls[lifecycleInstallFuncName])
+}
+
+func TestQueryInstalledLifecycleCC(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	_, err := rc.QueryInstalledLifecycleCC()
+	assert.Error(t, err, "expecting error since one target is required")
+
+	peer1 := newLifecyclePeer(t, "grpc://peer1.com")
+	peer1.results[lifecycleQueryInstalledFuncName] = &lb.QueryInstalledChaincodesResult{
+		InstalledChaincodes: []*lb.QueryInstalledChaincodesResult_InstalledChaincode{
+			{
+				PackageId: "mycc_1:hash",
+				Label:     "mycc_1",
+				References: map[string]*lb.QueryInstalledChaincodesResult_References{
+					"mychannel": {Chaincodes: []*lb.QueryInstalledChaincodesResult_Chaincode{{Name: "mycc", Version: "1"}}},
+				},
+			},
+		},
+	}
+
+	installedCCs, err := rc.QueryInstalledLifecycleCC(WithTargets(peer1))
+	require.NoError(t, err)
+	assert.Equal(t, []LifecycleInstalledCC{
+		{PackageID: "mycc_1:hash", Label: "mycc_1", References: map[string][]CCReference{"mychannel": {{Name: "mycc", Version: "1"}}}},
+	}, installedCCs)
+}
+
+func TestApproveCC(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	ctx.SetEndpointConfig(getNetworkConfig(t))
+	transactor := &lifecycleTransactor{t: t}
+	ctx.ChannelProvider().(*fcmocks.MockChannelProvider).SetTransactor(transactor)
+	rc := setupResMgmtClient(t, ctx, getDefaultTargetFilterOption())
+
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+	req := ApproveCCRequest{Name: "mycc", Version: "1", Sequence: 1, PackageID: "mycc_1:hash", SignaturePolicy: policy, InitRequired: true}
+
+	_, err := rc.ApproveCC("", req)
+	assert.Error(t, err, "expecting error for missing channel ID")
+
+	_, err = rc.ApproveCC("mychannel", ApproveCCRequest{Name: "mycc", Version: "1"})
+	assert.Error(t, err, "expecting error for missing sequence")
+
+	_, err = rc.ApproveCC("mychannel", ApproveCCRequest{Name: "mycc", Version: "1", Sequence: 1, SignaturePolicy: policy, ChannelConfigPolicy: "/Channel/Application/Endorsement"})
+	assert.Error(t, err, "expecting error since only one policy may be provided")
+
+	// The approval is endorsed by the channel peers of the client's organization
+	_, err = rc.ApproveCC("mychannel", req)
+	require.NoError(t, err)
+	assert.Equal(t, "mychannel", transactor.channelID)
+	assert.Equal(t, lifecycleApproveFuncName, transactor.fcn)
+
+	args := &lb.ApproveChaincodeDefinitionForMyOrgArgs{}
+	require.NoError(t, proto.Unmarshal(transactor.args, args))
+	assert.Equal(t, "mycc", args.Name)
+	assert.Equal(t, "1", args.Version)
+	assert.Equal(t, int64(1), args.Sequence)
+	assert.True(t, args.InitRequired)
+	assert.Equal(t, "mycc_1:hash", args.Source.GetLocalPackage().GetPackageId())
+	assertSignaturePolicy(t, policy, args.ValidationParameter)
+
+	// Organizations that don't endorse the chaincode approve it without a package
+	_, err = rc.ApproveCC("mychannel", ApproveCCRequest{Name: "mycc", Version: "1", Sequence: 1})
+	require.NoError(t, err)
+	require.NoError(t, proto.Unmarshal(transactor.args, args))
+	assert.NotNil(t, args.Source.GetUnavailable())
+	assert.Nil(t, args.ValidationParameter)
+
+	_, err = rc.ApproveCC("mychannel", req, WithTargetFilter(&mspFilter{mspID: "Org2MSP"}))
+	assert.Error(t, err, "expecting error since the filter rejects all channel peers")
+}
+
+func TestCheckCCCommitReadiness(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	req := CheckCCCommitReadinessRequest{Name: "mycc", Version: "1", Sequence: 1, ChannelConfigPolicy: "/Channel/Application/Endorsement"}
+
+	_, err := rc.CheckCCCommitReadiness("mychannel", CheckCCCommitReadinessRequest{Name: "mycc", Sequence: 1})
+	assert.Error(t, err, "expecting error for missing version")
+
+	peer1 := newLifecyclePeer(t, "grpc://peer1.com")
+	peer1.results[lifecycleCheckCommitReadinessFuncName] = &lb.CheckCommitReadinessResult{Approvals: map[string]bool{"Org1MSP": true, "Org2MSP": false}}
+
+	resp, err := rc.CheckCCCommitReadiness("mychannel", req, WithTargets(peer1))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"Org1MSP": true, "Org2MSP": false}, resp.Approvals)
+	assert.Equal(t, "mychannel", peer1.channelID)
+
+	args := &lb.CheckCommitReadinessArgs{}
+	require.NoError(t, proto.Unmarshal(peer1.args[lifecycleCheckCommitReadinessFuncName], args))
+	assert.Equal(t, "mycc", args.Name)
+	assertChannelConfigPolicy(t, "/Channel/Application/Endorsement", args.ValidationParameter)
+
+	peer1.failures[lifecycleCheckCommitReadinessFuncName] = "chaincode definition not found"
+	_, err = rc.CheckCCCommitReadiness("mychannel", req, WithTargets(peer1))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "chaincode definition not found")
+}
+
+func TestCommitCC(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	ctx.SetEndpointConfig(getNetworkConfig(t))
+	transactor := &lifecycleTransactor{t: t}
+	ctx.ChannelProvider().(*fcmocks.MockChannelProvider).SetTransactor(transactor)
+	rc := setupResMgmtClient(t, ctx, getDefaultTargetFilterOption())
+
+	collConfig := []*common.CollectionConfig{newCollectionConfig("collection1", "OR('Org1MSP.member','Org2MSP.member')", 1, 2, 100)}
+	req := CommitCCRequest{Name: "mycc", Version: "1", Sequence: 2, ChannelConfigPolicy: "/Channel/Application/Endorsement", CollectionConfig: collConfig}
+
+	_, err := rc.CommitCC("mychannel", CommitCCRequest{Version: "1", Sequence: 2})
+	assert.Error(t, err, "expecting error for missing name")
+
+	_, err = rc.CommitCC("mychannel", req)
+	require.NoError(t, err)
+	assert.Equal(t, lifecycleCommitFuncName, transactor.fcn)
+
+	args := &lb.CommitChaincodeDefinitionArgs{}
+	require.NoError(t, proto.Unmarshal(transactor.args, args))
+	assert.Equal(t, int64(2), args.Sequence)
+	assert.Equal(t, &common.CollectionConfigPackage{Config: collConfig}, args.Collections)
+	assertChannelConfigPolicy(t, "/Channel/Application/Endorsement", args.ValidationParameter)
+
+	transactor.status = http.StatusInternalServerError
+	_, err = rc.CommitCC("mychannel", req)
+	assert.Error(t, err, "expecting error since the endorsement failed")
+}
+
+func TestQueryCommittedCC(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+	validationParameter, err := lifecycleValidationParameter(policy, "")
+	require.NoError(t, err)
+
+	_, err = rc.QueryCommittedCC("", QueryCommittedCCRequest{Name: "mycc"})
+	assert.Error(t, err, "expecting error for missing channel ID")
+
+	peer1 := newLifecyclePeer(t, "grpc://peer1.com")
+	peer1.results[lifecycleQueryDefinitionFuncName] = &lb.QueryChaincodeDefinitionResult{
+		Sequence:            1,
+		Version:             "1",
+		ValidationPlugin:    "vscc",
+		ValidationParameter: validationParameter,
+		Approvals:           map[string]bool{"Org1MSP": true},
+	}
+	peer1.results[lifecycleQueryDefinitionsFuncName] = &lb.QueryChaincodeDefinitionsResult{
+		ChaincodeDefinitions: []*lb.QueryChaincodeDefinitionsResult_ChaincodeDefinition{
+			{Name: "mycc", Sequence: 1, Version: "1", ValidationParameter: validationParameter},
+			{Name: "othercc", Sequence: 3, Version: "2", InitRequired: true},
+		},
+	}
+
+	definitions, err := rc.QueryCommittedCC("mychannel", QueryCommittedCCRequest{Name: "mycc"}, WithTargets(peer1))
+	require.NoError(t, err)
+	require.Len(t, definitions, 1)
+	assert.Equal(t, "mycc", definitions[0].Name)
+	assert.Equal(t, int64(1), definitions[0].Sequence)
+	assert.Equal(t, "vscc", definitions[0].ValidationPlugin)
+	assert.True(t, proto.Equal(policy, definitions[0].SignaturePolicy))
+	assert.Equal(t, map[string]bool{"Org1MSP": true}, definitions[0].Approvals)
+
+	args := &lb.QueryChaincodeDefinitionArgs{}
+	require.NoError(t, proto.Unmarshal(peer1.args[lifecycleQueryDefinitionFuncName], args))
+	assert.Equal(t, "mycc", args.Name)
+
+	definitions, err = rc.QueryCommittedCC("mychannel", QueryCommittedCCRequest{}, WithTargets(peer1))
+	require.NoError(t, err)
+	require.Len(t, definitions, 2)
+	assert.Equal(t, "mycc", definitions[0].Name)
+	assert.True(t, proto.Equal(policy, definitions[0].SignaturePolicy))
+	assert.Equal(t, "othercc", definitions[1].Name)
+	assert.Nil(t, definitions[1].SignaturePolicy)
+	assert.True(t, definitions[1].InitRequired)
+
+	peer1.results[lifecycleQueryDefinitionFuncName] = &lb.QueryChaincodeDefinitionResult{ValidationParameter: []byte("invalid")}
+	_, err = rc.QueryCommittedCC("mychannel", QueryCommittedCCRequest{Name: "mycc"}, WithTargets(peer1))
+	assert.Error(t, err, "expecting error for invalid validation parameter")
+}
+
+func assertSignaturePolicy(t *testing.T, expected *common.SignaturePolicyEnvelope, validationParameter []byte) {
+	policy := &pb.ApplicationPolicy{}
+	require.NoError(t, proto.Unmarshal(validationParameter, policy))
+	assert.True(t, proto.Equal(expected, policy.GetSignaturePolicy()))
+}
+
+func assertChannelConfigPolicy(t *testing.T, expected string, validationParameter []byte) {
+	policy := &pb.ApplicationPolicy{}
+	require.NoError(t, proto.Unmarshal(validationParameter, policy))
+	assert.Equal(t, expected, policy.GetChannelConfigPolicyReference())
+}
+
+// lifecyclePeer is a mock peer that responds to the functions of the lifecycle chaincode and records their arguments
+type lifecyclePeer struct {
+	*fcmocks.MockPeer
+	t         *testing.T
+	results   map[string]proto.Message
+	failures  map[string]string
+	errs      map[string]error
+	args      map[string][]byte
+	calls     map[string]int
+	channelID string
+}
+
+func newLifecyclePeer(t *testing.T, url string) *lifecyclePeer {
+	return &lifecyclePeer{
+		MockPeer: &fcmocks.MockPeer{MockName: url, MockURL: url, MockMSP: "Org1MSP"},
+		t:        t,
+		results:  make(map[string]proto.Message),
+		failures: make(map[string]string),
+		errs:     make(map[string]error),
+		args:     make(map[string][]byte),
+		calls:    make(map[string]int),
+	}
+}
+
+func (p *lifecyclePeer) ProcessTransactionProposal(ctx reqContext.Context, request fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	proposal := &pb.Proposal{}
+	require.NoError(p.t, proto.Unmarshal(request.SignedProposal.ProposalBytes, proposal))
+
+	channelID, fcn, args := lifecycleInvocation(p.t, proposal)
+	p.channelID = channelID
+	p.args[fcn] = args
+	p.calls[fcn]++
+
+	if err, ok := p.errs[fcn]; ok {
+		return nil, err
+	}
+
+	response := &pb.Response{Status: http.StatusOK}
+	if msg, ok := p.failures[fcn]; ok {
+		response = &pb.Response{Status: http.StatusInternalServerError, Message: msg}
+	} else {
+		payload, err := proto.Marshal(p.results[fcn])
+		require.NoError(p.t, err)
+		response.Payload = payload
+	}
+
+	return &fab.TransactionProposalResponse{
+		Endorser: p.MockURL,
+		Status:   response.Status,
+		ProposalResponse: &pb.ProposalResponse{
+			Response:    response,
+			Endorsement: &pb.Endorsement{Endorser: p.Endorser, Signature: []byte("signature")},
+		},
+	}, nil
+}
+
+// lifecycleTransactor is a mock transactor that records the lifecycle function of the transaction proposal
+type lifecycleTransactor struct {
+	fcmocks.MockTransactor
+	t         *testing.T
+	status    int32
+	channelID string
+	fcn       string
+	args      []byte
+}
+
+func (tr *lifecycleTransactor) SendTransactionProposal(proposal *fab.TransactionProposal, targets []fab.ProposalProcessor) ([]*fab.TransactionProposalResponse, error) {
+	require.NotEmpty(tr.t, targets)
+	tr.channelID, tr.fcn, tr.args = lifecycleInvocation(tr.t, proposal.Proposal)
+
+	responses, err := tr.MockTransactor.SendTransactionProposal(proposal, targets)
+	if tr.status != 0 {
+		responses[0].Status = tr.status
+		responses[0].ProposalResponse.Response.Status = tr.status
+	}
+	return responses, err
+}
+
+// lifecycleInvocation returns the channel, the lifecycle function and its arguments of a proposal
+func lifecycleInvocation(t *testing.T, proposal *pb.Proposal) (string, string, []byte) {
+	header, err := protos_utils.GetHeader(proposal.Header)
+	require.NoError(t, err)
+	channelHeader := &common.ChannelHeader{}
+	require.NoError(t, proto.Unmarshal(header.ChannelHeader, channelHeader))
+
+	payload, err := protos_utils.GetChaincodeProposalPayload(proposal.Payload)
+	require.NoError(t, err)
+	cis := &pb.ChaincodeInvocationSpec{}
+	require.NoError(t, proto.Unmarshal(payload.Input, cis))
+
+	require.Equal(t, lifecycleCC, cis.ChaincodeSpec.ChaincodeId.Name)
+	require.Len(t, cis.ChaincodeSpec.Input.Args, 2)
+	return channelHeader.ChannelId, string(cis.ChaincodeSpec.Input.Args[0]), cis.ChaincodeSpec.Input.Args[1]
+}
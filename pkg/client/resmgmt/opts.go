@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/comm"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	"github.com/pkg/errors"
 )
 
@@ -124,3 +125,17 @@ func WithRetry(retryOpt retry.Opts) RequestOption {
 		return nil
 	}
 }
+
+// WithConfigSignatures allows pre-signed config signatures (e.g. collected from the admins of other
+// organizations using CreateConfigSignature) to be included in a save channel request.
+func WithConfigSignatures(signatures ...*common.ConfigSignature) RequestOption {
+	return func(ctx context.Client, o *requestOptions) error {
+		for _, signature := range signatures {
+			if signature == nil {
+				return errors.New("config signature is nil")
+			}
+		}
+		o.Signatures = append(o.Signatures, signatures...)
+		return nil
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	ob "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
+	"github.com/pkg/errors"
+)
+
+// ordererAdminsPolicy is the modification policy of the orderer addresses
+const ordererAdminsPolicy = "/Channel/Orderer/Admins"
+
+// OrdererSettingsRequest holds parameters for an update of the orderer settings of a channel.
+// Only the settings that are provided are updated; the others keep their current values.
+type OrdererSettingsRequest struct {
+	ChannelID         string
+	BatchSize         *fab.BatchSize        // Block cutting parameters
+	BatchTimeout      time.Duration         // Amount of time to wait before cutting a block
+	OrdererAddresses  []string              // Addresses (host:port) of the ordering service nodes
+	ConsensusMetadata []byte                // Consensus type specific metadata (the consensus type itself is not changed)
+	SigningIdentities []msp.SigningIdentity // Orderer admins that sign the config update (defaults to the client's identity)
+}
+
+// QueryOrdererSettings returns the current orderer settings (batch size, batch timeout, consensus type, etc.) of
+// the channel from the orderer. The orderer addresses are available from the channel configuration
+// returned by QueryConfigFromOrderer.
+//  Parameters:
+//  channelID is mandatory channel ID
+//  options holds optional request options
+//
+//  Returns:
+//  the orderer settings of the channel
+func (rc *Client) QueryOrdererSettings(channelID string, options ...RequestOption) (*fab.OrdererSettings, error) {
+
+	cfg, err := rc.QueryConfigFromOrderer(channelID, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := cfg.OrdererSettings()
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid orderer settings in channel config")
+	}
+	if settings == nil {
+		return nil, errors.New("orderer settings not found in channel config")
+	}
+	return settings, nil
+}
+
+// UpdateOrdererSettings updates the orderer settings of a channel. The current channel configuration is retrieved
+// from the orderer and the config update must be signed by the orderer admin(s).
+//  Parameters:
+//  req holds info about the channel and the settings to update
+//  options holds optional request options
+//
+//  Returns:
+//  save channel response with transaction ID
+func (rc *Client) UpdateOrdererSettings(req OrdererSettingsRequest, options ...RequestOption) (SaveChannelResponse, error) {
+
+	if err := validateOrdererSettingsRequest(req); err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	logger.Debugf("updating orderer settings on channel: %s", req.ChannelID)
+
+	return rc.updateChannelConfig(req.ChannelID, req.SigningIdentities, func(config *common.Config) error {
+		return updateOrdererSettingsConfig(config, req)
+	}, options...)
+}
+
+// CreateUpdateOrdererSettingsConfigUpdate creates the (unsigned) config update envelope that updates the orderer
+// settings of a channel, without submitting it (see CreateAddOrgConfigUpdate). The signing identities of the
+// request are ignored.
+//  Parameters:
+//  req holds info about the channel and the settings to update
+//  options holds optional request options
+//
+//  Returns:
+//  the marshalled config update envelope
+func (rc *Client) CreateUpdateOrdererSettingsConfigUpdate(req OrdererSettingsRequest, options ...RequestOption) ([]byte, error) {
+
+	if err := validateOrdererSettingsRequest(req); err != nil {
+		return nil, err
+	}
+
+	return rc.createChannelConfigUpdate(req.ChannelID, func(config *common.Config) error {
+		return updateOrdererSettingsConfig(config, req)
+	}, options...)
+}
+
+// validateOrdererSettingsRequest applies the same checks to the settings as the orderer does
+func validateOrdererSettingsRequest(req OrdererSettingsRequest) error {
+	if req.BatchSize == nil && req.BatchTimeout == 0 && len(req.OrdererAddresses) == 0 && req.ConsensusMetadata == nil {
+		return errors.New("no orderer settings to update")
+	}
+
+	if req.BatchSize != nil {
+		if req.BatchSize.MaxMessageCount == 0 {
+			return errors.New("batch size max message count must be greater than 0")
+		}
+		if req.BatchSize.AbsoluteMaxBytes == 0 {
+			return errors.New("batch size absolute max bytes must be greater than 0")
+		}
+		if req.BatchSize.PreferredMaxBytes > req.BatchSize.AbsoluteMaxBytes {
+			return errors.Errorf("batch size preferred max bytes (%d) must be less than or equal to absolute max bytes (%d)", req.BatchSize.PreferredMaxBytes, req.BatchSize.AbsoluteMaxBytes)
+		}
+	}
+
+	if req.BatchTimeout < 0 {
+		return errors.Errorf("invalid batch timeout: %s", req.BatchTimeout)
+	}
+
+	return nil
+}
+
+// updateOrdererSettingsConfig updates the values of the Orderer group (and the orderer addresses of the
+// channel group) with the settings provided in the request
+func updateOrdererSettingsConfig(config *common.Config, req OrdererSettingsRequest) error {
+	ordererGroup, err := channelGroup(config, string(fab.OrdererGroupKey))
+	if err != nil {
+		return err
+	}
+
+	if req.BatchSize != nil {
+		batchSize := &ob.BatchSize{
+			MaxMessageCount:   req.BatchSize.MaxMessageCount,
+			AbsoluteMaxBytes:  req.BatchSize.AbsoluteMaxBytes,
+			PreferredMaxBytes: req.BatchSize.PreferredMaxBytes,
+		}
+		if err := updateConfigValue(ordererGroup, channelconfig.BatchSizeKey, channelconfig.AdminsPolicyKey, batchSize); err != nil {
+			return err
+		}
+	}
+
+	if req.BatchTimeout != 0 {
+		batchTimeout := &ob.BatchTimeout{Timeout: req.BatchTimeout.String()}
+		if err := updateConfigValue(ordererGroup, channelconfig.BatchTimeoutKey, channelconfig.AdminsPolicyKey, batchTimeout); err != nil {
+			return err
+		}
+	}
+
+	if req.ConsensusMetadata != nil {
+		if err := updateConsensusMetadata(ordererGroup, req.ConsensusMetadata); err != nil {
+			return err
+		}
+	}
+
+	if len(req.OrdererAddresses) > 0 {
+		ordererAddresses := &common.OrdererAddresses{Addresses: req.OrdererAddresses}
+		if err := updateConfigValue(config.ChannelGroup, channelconfig.OrdererAddressesKey, ordererAdminsPolicy, ordererAddresses); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateConsensusMetadata replaces the metadata of the consensus type of the orderer group
+func updateConsensusMetadata(ordererGroup *common.ConfigGroup, metadata []byte) error {
+	value, ok := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !ok {
+		return errors.New("consensus type not found in orderer group")
+	}
+
+	consensusType := &ob.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, consensusType); err != nil {
+		return errors.Wrap(err, "unmarshal consensus type failed")
+	}
+	consensusType.Metadata = metadata
+
+	return updateConfigValue(ordererGroup, channelconfig.ConsensusTypeKey, channelconfig.AdminsPolicyKey, consensusType)
+}
+
+// updateConfigValue replaces the given value of a config group, keeping the modification policy of the current
+// value. The given modification policy is used if the value doesn't exist yet.
+func updateConfigValue(group *common.ConfigGroup, key string, modPolicy string, value proto.Message) error {
+	valueBytes, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "marshal %s failed", key)
+	}
+
+	if current, ok := group.Values[key]; ok {
+		current.Value = valueBytes
+		return nil
+	}
+
+	if group.Values == nil {
+		group.Values = make(map[string]*common.ConfigValue)
+	}
+	group.Values[key] = &common.ConfigValue{ModPolicy: modPolicy, Value: valueBytes}
+	return nil
+}
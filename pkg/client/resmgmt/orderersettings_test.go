@@ -0,0 +1,125 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/chconfig"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateOrdererSettingsError(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	_, err := rc.UpdateOrdererSettings(OrdererSettingsRequest{ChannelID: "mychannel"})
+	assert.Error(t, err, "expecting error since there are no settings to update")
+
+	_, err = rc.UpdateOrdererSettings(OrdererSettingsRequest{ChannelID: "mychannel", BatchSize: &fab.BatchSize{AbsoluteMaxBytes: 1024}})
+	assert.Error(t, err, "expecting error for zero max message count")
+
+	_, err = rc.UpdateOrdererSettings(OrdererSettingsRequest{ChannelID: "mychannel", BatchSize: &fab.BatchSize{MaxMessageCount: 10}})
+	assert.Error(t, err, "expecting error for zero absolute max bytes")
+
+	_, err = rc.UpdateOrdererSettings(OrdererSettingsRequest{ChannelID: "mychannel", BatchSize: &fab.BatchSize{MaxMessageCount: 10, AbsoluteMaxBytes: 1024, PreferredMaxBytes: 2048}})
+	assert.Error(t, err, "expecting error since preferred max bytes exceeds absolute max bytes")
+
+	_, err = rc.UpdateOrdererSettings(OrdererSettingsRequest{ChannelID: "mychannel", BatchTimeout: -time.Second})
+	assert.Error(t, err, "expecting error for negative batch timeout")
+
+	_, err = rc.CreateUpdateOrdererSettingsConfigUpdate(OrdererSettingsRequest{BatchTimeout: time.Second})
+	assert.Error(t, err, "expecting error for missing channel ID")
+
+	orderer := fcmocks.NewMockOrderer("", nil)
+	defer orderer.Close()
+	orderer.EnqueueForSendDeliver(fcmocks.NewSimpleMockError())
+
+	_, err = rc.UpdateOrdererSettings(OrdererSettingsRequest{ChannelID: "mychannel", BatchTimeout: time.Second}, WithOrderer(orderer))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "LastConfigFromOrderer failed")
+}
+
+func TestQueryOrdererSettings(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+
+	// The batch timeout of the mock config ("123") isn't a valid duration
+	_, err = rc.QueryOrdererSettings("mychannel", WithOrderer(&configOrderer{t: t, configs: []*common.Config{config}}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid batch timeout [123]")
+
+	require.NoError(t, updateOrdererSettingsConfig(config, OrdererSettingsRequest{BatchTimeout: 2 * time.Second}))
+	settings, err := rc.QueryOrdererSettings("mychannel", WithOrderer(&configOrderer{t: t, configs: []*common.Config{config}}))
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, settings.BatchTimeout)
+	assert.Equal(t, "sample-Consensus-Type", settings.ConsensusType)
+
+	delete(config.ChannelGroup.Groups, "Orderer")
+	_, err = rc.QueryOrdererSettings("mychannel", WithOrderer(&configOrderer{t: t, configs: []*common.Config{config}}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "orderer settings not found in channel config")
+}
+
+func TestUpdateOrdererSettingsConfig(t *testing.T) {
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+	require.NoError(t, updateOrdererSettingsConfig(config, OrdererSettingsRequest{BatchTimeout: 2 * time.Second}))
+
+	req := OrdererSettingsRequest{
+		BatchSize:         &fab.BatchSize{MaxMessageCount: 500, AbsoluteMaxBytes: 10 * 1024 * 1024, PreferredMaxBytes: 2 * 1024 * 1024},
+		BatchTimeout:      500 * time.Millisecond,
+		OrdererAddresses:  []string{"orderer0.example.com:7050", "orderer1.example.com:7050"},
+		ConsensusMetadata: []byte("metadata"),
+	}
+
+	updated := proto.Clone(config).(*common.Config)
+	require.NoError(t, updateOrdererSettingsConfig(updated, req))
+
+	diff, err := chconfig.Diff(config, updated)
+	require.NoError(t, err)
+	require.NotNil(t, diff.OrdererSettingsTo)
+	assert.Equal(t, *req.BatchSize, diff.OrdererSettingsTo.BatchSize)
+	assert.Equal(t, req.BatchTimeout, diff.OrdererSettingsTo.BatchTimeout)
+	assert.Equal(t, diff.OrdererSettingsFrom.ConsensusType, diff.OrdererSettingsTo.ConsensusType, "consensus type should not change")
+	assert.Equal(t, req.ConsensusMetadata, diff.OrdererSettingsTo.ConsensusMetadata)
+	assert.Equal(t, []string{"orderer0.example.com:7050", "orderer1.example.com:7050"}, diff.OrderersAdded)
+	assert.Equal(t, []string{"localhost:7050"}, diff.OrderersRemoved)
+	assert.Empty(t, diff.MSPsModified)
+
+	// The modification policy of existing values is retained
+	ordererGroup := updated.ChannelGroup.Groups["Orderer"]
+	assert.Equal(t, config.ChannelGroup.Groups["Orderer"].Values["BatchSize"].ModPolicy, ordererGroup.Values["BatchSize"].ModPolicy)
+
+	// Only the given settings are updated
+	updated = proto.Clone(config).(*common.Config)
+	require.NoError(t, updateOrdererSettingsConfig(updated, OrdererSettingsRequest{BatchTimeout: time.Minute}))
+
+	configUpdate, err := resource.ComputeConfigUpdate("mychannel", config, updated)
+	require.NoError(t, err)
+	assert.Empty(t, configUpdate.WriteSet.Values)
+	writeSet := configUpdate.WriteSet.Groups["Orderer"]
+	require.NotNil(t, writeSet)
+	assert.Len(t, writeSet.Values, 1)
+	assert.Contains(t, writeSet.Values, "BatchTimeout")
+
+	delete(updated.ChannelGroup.Groups["Orderer"].Values, "ConsensusType")
+	assert.Error(t, updateOrdererSettingsConfig(updated, OrdererSettingsRequest{ConsensusMetadata: []byte("metadata")}), "expecting error since consensus type is missing")
+
+	delete(updated.ChannelGroup.Groups, "Orderer")
+	assert.Error(t, updateOrdererSettingsConfig(updated, req), "expecting error since orderer group is missing")
+}
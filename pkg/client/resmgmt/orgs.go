@@ -0,0 +1,460 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// OrgDefinition defines an organization that is added to a channel
+type OrgDefinition struct {
+	MSPID       string        // MSP ID of the organization
+	Name        string        // Name of the organization's config group (defaults to the MSP ID)
+	MSPConfig   *mb.MSPConfig // MSP configuration of the organization
+	MSPDir      string        // Convenience option to generate the MSP configuration from a local MSP directory
+	AnchorPeers []AnchorPeer  // Anchor peers of the organization (application group only)
+}
+
+// AddOrgRequest holds parameters for adding an organization to a channel
+type AddOrgRequest struct {
+	ChannelID         string
+	Org               OrgDefinition
+	IncludeOrderer    bool                  // Also add the organization to the orderer group of the channel
+	SigningIdentities []msp.SigningIdentity // Admins of the existing organizations that sign the config update
+}
+
+// RemoveOrgRequest holds parameters for removing an organization from a channel
+type RemoveOrgRequest struct {
+	ChannelID         string
+	MSPID             string                // MSP ID of the organization
+	IncludeOrderer    bool                  // Also remove the organization from the orderer group of the channel
+	SigningIdentities []msp.SigningIdentity // Admins of the organizations that sign the config update
+}
+
+// AddOrg adds the MSP definition of an organization to the application group (and optionally the orderer group)
+// of a channel. The config update must be signed by the admins of the existing organizations as required by the
+// channel's modification policy (usually a majority); if the signers (the signing identities of the request and the
+// creators of the signatures provided with WithConfigSignatures) are known to be insufficient then an error is
+// returned before the update is submitted.
+//  Parameters:
+//  req holds info about the channel and the organization
+//  options holds optional request options
+//
+//  Returns:
+//  save channel response with transaction ID
+func (rc *Client) AddOrg(req AddOrgRequest, options ...RequestOption) (SaveChannelResponse, error) {
+
+	signers, err := rc.configSignerMSPIDs(req.SigningIdentities, options...)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	modify, err := addOrgModifier(req, signers)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	logger.Debugf("adding organization %s to channel: %s", req.Org.MSPID, req.ChannelID)
+
+	return rc.updateChannelConfig(req.ChannelID, req.SigningIdentities, modify, options...)
+}
+
+// CreateAddOrgConfigUpdate creates the (unsigned) config update envelope that adds an organization to a channel,
+// without submitting it. The envelope may be distributed to the admins of the existing organizations to be signed
+// (see CreateConfigSignature) and then submitted with SaveChannel. The signing identities of the request are ignored.
+//  Parameters:
+//  req holds info about the channel and the organization
+//  options holds optional request options
+//
+//  Returns:
+//  the marshalled config update envelope
+func (rc *Client) CreateAddOrgConfigUpdate(req AddOrgRequest, options ...RequestOption) ([]byte, error) {
+
+	modify, err := addOrgModifier(req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.createChannelConfigUpdate(req.ChannelID, modify, options...)
+}
+
+// RemoveOrg removes an organization from the application group (and optionally the orderer group) of a channel.
+// The config update must be signed by the admins of the organizations as required by the channel's
+// modification policy (usually a majority), which is checked as for AddOrg.
+//  Parameters:
+//  req holds info about the channel and the organization
+//  options holds optional request options
+//
+//  Returns:
+//  save channel response with transaction ID
+func (rc *Client) RemoveOrg(req RemoveOrgRequest, options ...RequestOption) (SaveChannelResponse, error) {
+
+	signers, err := rc.configSignerMSPIDs(req.SigningIdentities, options...)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	modify, err := removeOrgModifier(req, signers)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	logger.Debugf("removing organization %s from channel: %s", req.MSPID, req.ChannelID)
+
+	return rc.updateChannelConfig(req.ChannelID, req.SigningIdentities, modify, options...)
+}
+
+// CreateRemoveOrgConfigUpdate creates the (unsigned) config update envelope that removes an organization from a
+// channel, without submitting it. The signing identities of the request are ignored.
+//  Parameters:
+//  req holds info about the channel and the organization
+//  options holds optional request options
+//
+//  Returns:
+//  the marshalled config update envelope
+func (rc *Client) CreateRemoveOrgConfigUpdate(req RemoveOrgRequest, options ...RequestOption) ([]byte, error) {
+
+	modify, err := removeOrgModifier(req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.createChannelConfigUpdate(req.ChannelID, modify, options...)
+}
+
+// addOrgModifier returns the config modification that adds the organization. The MSP IDs of the signers are
+// verified against the modification policies, unless no signers are given.
+func addOrgModifier(req AddOrgRequest, signers []string) (func(config *common.Config) error, error) {
+	org, err := newOrgGroups(req.Org)
+	if err != nil {
+		return nil, err
+	}
+
+	groupKeys := orgGroupKeys(req.IncludeOrderer)
+
+	return func(config *common.Config) error {
+		for _, groupKey := range groupKeys {
+			group, err := channelGroup(config, groupKey)
+			if err != nil {
+				return err
+			}
+			if err := verifyAdminSignatures(group, groupKey, signers); err != nil {
+				return err
+			}
+			if err := addOrgGroup(group, org.name, org.groups[groupKey], req.Org.MSPID); err != nil {
+				return errors.WithMessage(err, groupKey)
+			}
+		}
+		return nil
+	}, nil
+}
+
+// removeOrgModifier returns the config modification that removes the organization. The MSP IDs of the signers are
+// verified against the modification policies, unless no signers are given.
+func removeOrgModifier(req RemoveOrgRequest, signers []string) (func(config *common.Config) error, error) {
+	if req.MSPID == "" {
+		return nil, errors.New("must provide MSP ID")
+	}
+
+	groupKeys := orgGroupKeys(req.IncludeOrderer)
+
+	return func(config *common.Config) error {
+		for _, groupKey := range groupKeys {
+			group, err := channelGroup(config, groupKey)
+			if err != nil {
+				return err
+			}
+			if err := verifyAdminSignatures(group, groupKey, signers); err != nil {
+				return err
+			}
+			if err := removeOrgGroup(group, req.MSPID); err != nil {
+				return errors.WithMessage(err, groupKey)
+			}
+		}
+		return nil
+	}, nil
+}
+
+// configSignerMSPIDs returns the MSP IDs of the identities that sign a config update, in the same way as
+// SaveChannel: the signing identities and the creators of the pre-signed config signatures provided in the options,
+// or the client's identity if there are neither
+func (rc *Client) configSignerMSPIDs(signingIdentities []msp.SigningIdentity, options ...RequestOption) ([]string, error) {
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var mspIDs []string
+	for _, id := range signingIdentities {
+		if id != nil {
+			mspIDs = append(mspIDs, id.Identifier().MSPID)
+		}
+	}
+
+	for i, signature := range opts.Signatures {
+		mspID, err := configSignatureMSPID(signature)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid config signature %d", i))
+		}
+		mspIDs = append(mspIDs, mspID)
+	}
+
+	if len(mspIDs) == 0 && rc.ctx != nil {
+		mspIDs = append(mspIDs, rc.ctx.Identifier().MSPID)
+	}
+	return mspIDs, nil
+}
+
+type orgGroups struct {
+	name   string
+	groups map[string]*common.ConfigGroup
+}
+
+// newOrgGroups creates the application and orderer config groups of the given organization
+func newOrgGroups(def OrgDefinition) (*orgGroups, error) {
+	mspConfig, err := orgMSPConfig(def)
+	if err != nil {
+		return nil, err
+	}
+
+	name := orgGroupName(def)
+
+	applicationGroup, err := newOrgGroup(def.MSPID, mspConfig)
+	if err != nil {
+		return nil, err
+	}
+	if len(def.AnchorPeers) > 0 {
+		anchorPeers := &pb.AnchorPeers{}
+		for _, ap := range def.AnchorPeers {
+			anchorPeers.AnchorPeers = appendAnchorPeer(anchorPeers.AnchorPeers, ap)
+		}
+		if err := setConfigValue(applicationGroup, channelconfig.AnchorPeersKey, anchorPeers); err != nil {
+			return nil, err
+		}
+	}
+
+	ordererGroup, err := newOrgGroup(def.MSPID, mspConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orgGroups{
+		name: name,
+		groups: map[string]*common.ConfigGroup{
+			string(fab.ApplicationGroupKey): applicationGroup,
+			string(fab.OrdererGroupKey):     ordererGroup,
+		},
+	}, nil
+}
+
+// orgMSPConfig returns the MSP configuration of the organization, generating it from the MSP directory if needed
+func orgMSPConfig(def OrgDefinition) (*mb.MSPConfig, error) {
+	if def.MSPID == "" {
+		return nil, errors.New("must provide MSP ID")
+	}
+
+	if def.MSPConfig != nil {
+		return def.MSPConfig, nil
+	}
+
+	if def.MSPDir == "" {
+		return nil, errors.New("must provide MSP config or MSP directory")
+	}
+
+	mspConfig, err := resource.GenerateMSPConfig(def.MSPDir, def.MSPID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "generating MSP config failed")
+	}
+	return mspConfig, nil
+}
+
+// orgGroupName returns the name of the organization's config group
+func orgGroupName(def OrgDefinition) string {
+	if def.Name != "" {
+		return def.Name
+	}
+	return def.MSPID
+}
+
+// newOrgGroup creates the config group of an organization with the default Readers, Writers and Admins policies
+func newOrgGroup(mspID string, mspConfig *mb.MSPConfig) (*common.ConfigGroup, error) {
+	group := &common.ConfigGroup{
+		ModPolicy: channelconfig.AdminsPolicyKey,
+		Groups:    make(map[string]*common.ConfigGroup),
+		Values:    make(map[string]*common.ConfigValue),
+		Policies:  make(map[string]*common.ConfigPolicy),
+	}
+
+	if err := setConfigValue(group, channelconfig.MSPKey, mspConfig); err != nil {
+		return nil, err
+	}
+
+	policies := map[string]*common.SignaturePolicyEnvelope{
+		channelconfig.ReadersPolicyKey: cauthdsl.SignedByMspMember(mspID),
+		channelconfig.WritersPolicyKey: cauthdsl.SignedByMspMember(mspID),
+		channelconfig.AdminsPolicyKey:  cauthdsl.SignedByMspAdmin(mspID),
+	}
+	for name, policy := range policies {
+		policyBytes, err := proto.Marshal(policy)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal policy failed")
+		}
+		group.Policies[name] = &common.ConfigPolicy{
+			ModPolicy: channelconfig.AdminsPolicyKey,
+			Policy:    &common.Policy{Type: int32(common.Policy_SIGNATURE), Value: policyBytes},
+		}
+	}
+
+	return group, nil
+}
+
+func setConfigValue(group *common.ConfigGroup, key string, value proto.Message) error {
+	valueBytes, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "marshal %s failed", key)
+	}
+	group.Values[key] = &common.ConfigValue{ModPolicy: channelconfig.AdminsPolicyKey, Value: valueBytes}
+	return nil
+}
+
+func orgGroupKeys(includeOrderer bool) []string {
+	groupKeys := []string{string(fab.ApplicationGroupKey)}
+	if includeOrderer {
+		groupKeys = append(groupKeys, string(fab.OrdererGroupKey))
+	}
+	return groupKeys
+}
+
+// channelGroup returns the given top level group (e.g. the Application group) of the channel configuration
+func channelGroup(config *common.Config, groupKey string) (*common.ConfigGroup, error) {
+	if config.ChannelGroup == nil {
+		return nil, errors.New("channel group not found in config")
+	}
+	group, ok := config.ChannelGroup.Groups[groupKey]
+	if !ok {
+		return nil, errors.Errorf("%s group not found in config", groupKey)
+	}
+	return group, nil
+}
+
+func addOrgGroup(group *common.ConfigGroup, name string, org *common.ConfigGroup, mspID string) error {
+	if _, err := orgGroup(group, mspID); err == nil {
+		return errors.Errorf("organization %s already exists", mspID)
+	}
+	if _, ok := group.Groups[name]; ok {
+		return errors.Errorf("organization group %s already exists", name)
+	}
+
+	if group.Groups == nil {
+		group.Groups = make(map[string]*common.ConfigGroup)
+	}
+	group.Groups[name] = org
+
+	return nil
+}
+
+func removeOrgGroup(group *common.ConfigGroup, mspID string) error {
+	org, err := orgGroup(group, mspID)
+	if err != nil {
+		return err
+	}
+
+	for name, g := range group.Groups {
+		if g == org {
+			delete(group.Groups, name)
+		}
+	}
+
+	return nil
+}
+
+// verifyAdminSignatures checks whether the signers (identified by their MSP IDs) satisfy the modification policy of the given group, if the
+// policy is an implicit meta policy over the organizations of the group (e.g. MAJORITY Admins). Signers are
+// assumed to be admins of their organizations; other policies are left to the orderer to evaluate. Nothing is
+// checked if there are no signers.
+func verifyAdminSignatures(group *common.ConfigGroup, groupKey string, signers []string) error {
+	if len(signers) == 0 {
+		return nil
+	}
+
+	policy, err := modImplicitMetaPolicy(group)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	orgs, err := groupMSPIDs(group)
+	if err != nil || len(orgs) == 0 {
+		return err
+	}
+
+	signed := 0
+	for _, mspID := range signers {
+		if done, ok := orgs[mspID]; ok && !done {
+			orgs[mspID] = true
+			signed++
+		}
+	}
+
+	required := requiredSignatures(policy.Rule, len(orgs))
+	if signed < required {
+		return errors.Errorf("%s policy of %s group requires signatures from %d of %d organizations but %d provided", group.ModPolicy, groupKey, required, len(orgs), signed)
+	}
+
+	return nil
+}
+
+// modImplicitMetaPolicy returns the modification policy of the group, or nil if it isn't an implicit meta policy
+func modImplicitMetaPolicy(group *common.ConfigGroup) (*common.ImplicitMetaPolicy, error) {
+	configPolicy, ok := group.Policies[group.ModPolicy]
+	if !ok || configPolicy.Policy == nil || configPolicy.Policy.Type != int32(common.Policy_IMPLICIT_META) {
+		return nil, nil
+	}
+
+	policy := &common.ImplicitMetaPolicy{}
+	if err := proto.Unmarshal(configPolicy.Policy.Value, policy); err != nil {
+		return nil, errors.Wrap(err, "unmarshal implicit meta policy failed")
+	}
+	return policy, nil
+}
+
+// groupMSPIDs returns the MSP IDs of the organizations of the group, mapped to false
+func groupMSPIDs(group *common.ConfigGroup) (map[string]bool, error) {
+	orgs := make(map[string]bool)
+	for _, org := range group.Groups {
+		mspID, err := orgMSPID(org)
+		if err != nil {
+			return nil, err
+		}
+		orgs[mspID] = false
+	}
+	return orgs, nil
+}
+
+// requiredSignatures returns the number of organizations that must sign to satisfy the implicit meta policy rule
+func requiredSignatures(rule common.ImplicitMetaPolicy_Rule, orgs int) int {
+	switch rule {
+	case common.ImplicitMetaPolicy_ANY:
+		return 1
+	case common.ImplicitMetaPolicy_ALL:
+		return orgs
+	case common.ImplicitMetaPolicy_MAJORITY:
+		return orgs/2 + 1
+	default:
+		return 0
+	}
+}
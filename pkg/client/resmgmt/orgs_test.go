@@ -0,0 +1,184 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const org3MSPDir = "../../../test/fixtures/fabric/v1/crypto-config/peerOrganizations/org1.example.com/msp"
+
+func TestAddOrgError(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	_, err := rc.AddOrg(AddOrgRequest{ChannelID: "mychannel", Org: OrgDefinition{MSPDir: org3MSPDir}})
+	assert.Error(t, err, "expecting error for missing MSP ID")
+
+	_, err = rc.AddOrg(AddOrgRequest{ChannelID: "mychannel", Org: OrgDefinition{MSPID: "Org3MSP"}})
+	assert.Error(t, err, "expecting error for missing MSP config")
+
+	_, err = rc.RemoveOrg(RemoveOrgRequest{ChannelID: "mychannel"})
+	assert.Error(t, err, "expecting error for missing MSP ID")
+
+	orderer := fcmocks.NewMockOrderer("", nil)
+	defer orderer.Close()
+	orderer.EnqueueForSendDeliver(fcmocks.NewSimpleMockError())
+
+	_, err = rc.AddOrg(AddOrgRequest{ChannelID: "mychannel", Org: OrgDefinition{MSPID: "Org3MSP", MSPDir: org3MSPDir}}, WithOrderer(orderer))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "LastConfigFromOrderer failed")
+}
+
+func TestAddAndRemoveOrgGroup(t *testing.T) {
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+
+	org, err := newOrgGroups(OrgDefinition{
+		MSPID:       "Org3MSP",
+		Name:        "Org3",
+		MSPDir:      org3MSPDir,
+		AnchorPeers: []AnchorPeer{{Host: "peer0.org3.example.com", Port: 7051}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Org3", org.name)
+	assert.Contains(t, org.groups["Application"].Values, "AnchorPeers")
+	assert.NotContains(t, org.groups["Orderer"].Values, "AnchorPeers")
+	assert.Contains(t, org.groups["Orderer"].Values, "MSP")
+	for _, group := range org.groups {
+		assert.Len(t, group.Policies, 3)
+		assert.Equal(t, "Admins", group.ModPolicy)
+	}
+
+	updated := proto.Clone(config).(*common.Config)
+	application := updated.ChannelGroup.Groups["Application"]
+	require.NoError(t, addOrgGroup(application, org.name, org.groups["Application"], "Org3MSP"))
+
+	mspID, err := orgMSPID(application.Groups["Org3"])
+	require.NoError(t, err)
+	assert.Equal(t, "Org3MSP", mspID)
+
+	assert.Error(t, addOrgGroup(application, "Org3MSP", org.groups["Application"], "Org3MSP"), "expecting error since organization already exists")
+	assert.Error(t, addOrgGroup(application, "Org1MSP", org.groups["Application"], "Org4MSP"), "expecting error since group already exists")
+
+	// Adding an organization changes the membership of the application group
+	configUpdate, err := resource.ComputeConfigUpdate("mychannel", config, updated)
+	require.NoError(t, err)
+	writeSet := configUpdate.WriteSet.Groups["Application"]
+	assert.Equal(t, config.ChannelGroup.Groups["Application"].Version+1, writeSet.Version)
+	assert.Contains(t, writeSet.Groups, "Org3")
+	assert.Contains(t, writeSet.Groups, "Org1MSP")
+
+	anchorPeers := &pb.AnchorPeers{}
+	require.NoError(t, proto.Unmarshal(writeSet.Groups["Org3"].Values["AnchorPeers"].Value, anchorPeers))
+	assert.Equal(t, "peer0.org3.example.com", anchorPeers.AnchorPeers[0].Host)
+
+	require.NoError(t, removeOrgGroup(application, "Org3MSP"))
+	assert.NotContains(t, application.Groups, "Org3")
+	assert.Error(t, removeOrgGroup(application, "Org3MSP"), "expecting error since organization doesn't exist")
+
+	require.NoError(t, removeOrgGroup(application, "Org2MSP"))
+	assert.NotContains(t, application.Groups, "Org2MSP")
+}
+
+func TestVerifyAdminSignatures(t *testing.T) {
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+
+	application := config.ChannelGroup.Groups["Application"]
+
+	// The mock configuration uses signature policies which aren't evaluated
+	assert.NoError(t, verifyAdminSignatures(application, "Application", []string{"OtherMSP"}))
+
+	policyBytes, err := proto.Marshal(&common.ImplicitMetaPolicy{SubPolicy: "Admins", Rule: common.ImplicitMetaPolicy_MAJORITY})
+	require.NoError(t, err)
+	application.Policies["Admins"] = &common.ConfigPolicy{Policy: &common.Policy{Type: int32(common.Policy_IMPLICIT_META), Value: policyBytes}}
+
+	// Signers are not known (e.g. when the config update is created for external signing)
+	assert.NoError(t, verifyAdminSignatures(application, "Application", nil))
+
+	err = verifyAdminSignatures(application, "Application", []string{"Org1MSP", "OtherMSP"})
+	assert.Error(t, err, "expecting error since majority of admins haven't signed")
+
+	err = verifyAdminSignatures(application, "Application", []string{"Org1MSP", "Org1MSP"})
+	assert.Error(t, err, "expecting error since majority of admins haven't signed")
+
+	err = verifyAdminSignatures(application, "Application", []string{"Org1MSP", "Org2MSP"})
+	assert.NoError(t, err)
+}
+
+func TestConfigSignerMSPIDs(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	org2Signature := newConfigSignature(t, "Org2MSP")
+
+	// The client signs if there are no other signers
+	mspIDs, err := rc.configSignerMSPIDs(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Org1MSP"}, mspIDs)
+
+	mspIDs, err = rc.configSignerMSPIDs(nil, WithConfigSignatures(org2Signature))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Org2MSP"}, mspIDs)
+
+	mspIDs, err = rc.configSignerMSPIDs([]msp.SigningIdentity{mspmocks.NewMockSigningIdentity("admin", "Org3MSP")}, WithConfigSignatures(org2Signature))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Org3MSP", "Org2MSP"}, mspIDs)
+
+	_, err = rc.configSignerMSPIDs(nil, WithConfigSignatures(&common.ConfigSignature{SignatureHeader: []byte("invalid")}))
+	assert.Error(t, err, "expecting error for invalid signature header")
+}
+
+func TestAddOrgWithConfigSignatures(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(t, ctx)
+
+	config, err := resource.ExtractConfigFromBlock(newMockConfigBlock())
+	require.NoError(t, err)
+
+	policyBytes, err := proto.Marshal(&common.ImplicitMetaPolicy{SubPolicy: "Admins", Rule: common.ImplicitMetaPolicy_MAJORITY})
+	require.NoError(t, err)
+	config.ChannelGroup.Groups["Application"].Policies["Admins"] = &common.ConfigPolicy{Policy: &common.Policy{Type: int32(common.Policy_IMPLICIT_META), Value: policyBytes}}
+
+	// Signatures collected from the admins of both organizations
+	signatures := []*common.ConfigSignature{newConfigSignature(t, "Org1MSP"), newConfigSignature(t, "Org2MSP")}
+
+	req := AddOrgRequest{ChannelID: "mychannel", Org: OrgDefinition{MSPID: "Org3MSP", MSPDir: org3MSPDir}}
+
+	// A single external signature doesn't satisfy the majority policy
+	_, err = rc.AddOrg(req, WithOrderer(&configOrderer{t: t, configs: []*common.Config{config}}), WithConfigSignatures(signatures[1]))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Admins policy of Application group requires signatures from 2 of 2 organizations but 1 provided")
+
+	// The external signatures satisfy the majority policy, so the update is submitted (and rejected by the
+	// test orderer, since the signatures weren't made over the config update)
+	_, err = rc.AddOrg(req, WithOrderer(&configOrderer{t: t, configs: []*common.Config{config}}), WithConfigSignatures(signatures...))
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "policy of Application group requires signatures")
+	assert.Contains(t, err.Error(), "config signature validation failed")
+}
+
+// newConfigSignature returns a config signature created by an admin of the given MSP
+func newConfigSignature(t *testing.T, mspID string) *common.ConfigSignature {
+	creator, err := proto.Marshal(&mb.SerializedIdentity{Mspid: mspID, IdBytes: []byte("admin")})
+	require.NoError(t, err)
+	signatureHeader, err := proto.Marshal(&common.SignatureHeader{Creator: creator, Nonce: []byte("nonce")})
+	require.NoError(t, err)
+	return &common.ConfigSignature{SignatureHeader: signatureHeader, Signature: []byte("signature")}
+}
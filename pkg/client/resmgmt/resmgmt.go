@@ -7,7 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 // Package resmgmt enables creation and update of resources on a Fabric network.
 // It allows administrators to create and/or update channnels, and for peers to join channels.
 // Administrators can also perform chaincode related operations on a peer, such as
-// installing, instantiating, and upgrading chaincode.
+// installing, instantiating, and upgrading chaincode. Chaincode of the Fabric 2.x chaincode lifecycle is
+// installed with InstallLifecycleCC and its definition is approved and committed with ApproveCC and CommitCC.
 //
 //  Basic Flow:
 //  1) Prepare client context
@@ -21,6 +22,7 @@ package resmgmt
 
 import (
 	reqContext "context"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"math/rand"
@@ -54,6 +56,10 @@ type InstallCCRequest struct {
 	Path    string
 	Version string
 	Package *resource.CCPackage
+	// DeploymentPackage is a pre-built chaincode deployment package (for example, a signed deployment package
+	// endorsed by the chaincode owners) which is installed as is instead of Package. The name, path and version
+	// default to those of the deployment package.
+	DeploymentPackage []byte
 }
 
 // InstallCCResponse contains install chaincode response status
@@ -101,6 +107,7 @@ type requestOptions struct {
 	Timeouts      map[fab.TimeoutType]time.Duration //timeout options for resmgmt operations
 	ParentContext reqContext.Context                //parent grpc context for resmgmt operations
 	Retry         retry.Opts
+	Signatures    []*common.ConfigSignature // pre-signed config signatures for save channel
 }
 
 //SaveChannelRequest holds parameters for save channel request
@@ -108,8 +115,7 @@ type SaveChannelRequest struct {
 	ChannelID         string
 	ChannelConfig     io.Reader             // ChannelConfig data source
 	ChannelConfigPath string                // Convenience option to use the named file as ChannelConfig reader
-	SigningIdentities []msp.SigningIdentity // Users that sign channel configuration
-	// TODO: support pre-signed signature blocks
+	SigningIdentities []msp.SigningIdentity // Users that sign channel configuration (pre-signed signatures may be provided with WithConfigSignatures)
 }
 
 // SaveChannelResponse contains response parameters for save channel
@@ -361,7 +367,12 @@ func (rc *Client) InstallCC(req InstallCCRequest, options ...RequestOption) ([]I
 	// For each peer query if chaincode installed. If cc is installed treat as success with message 'already installed'.
 	// If cc is not installed try to install, and if that fails add to the list with error and peer name.
 
-	err := checkRequiredInstallCCParams(req)
+	req, err := resolveInstallCCRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkRequiredInstallCCParams(req)
 	if err != nil {
 		return nil, err
 	}
@@ -420,7 +431,7 @@ func (rc *Client) InstallCC(req InstallCCRequest, options ...RequestOption) ([]I
 }
 
 func (rc *Client) sendIntallCCRequest(req InstallCCRequest, reqCtx reqContext.Context, newTargets []fab.Peer, responses []InstallCCResponse) []InstallCCResponse {
-	icr := resource.InstallChaincodeRequest{Name: req.Name, Path: req.Path, Version: req.Version, Package: req.Package}
+	icr := resource.InstallChaincodeRequest{Name: req.Name, Path: req.Path, Version: req.Version, Package: req.Package, DeploymentPackage: req.DeploymentPackage}
 	transactionProposalResponse, _, _ := resource.InstallChaincode(reqCtx, icr, peer.PeersToTxnProcessors(newTargets))
 	for _, v := range transactionProposalResponse {
 		logger.Debugf("Install chaincode '%s' endorser '%s' returned ProposalResponse status:%v", req.Name, v.Endorser, v.Status)
@@ -462,8 +473,25 @@ func (rc *Client) adjustTargets(targets []fab.Peer, req InstallCCRequest, retry
 
 }
 
+// resolveInstallCCRequest sets the chaincode name, path and version of a request with a deployment package
+func resolveInstallCCRequest(req InstallCCRequest) (InstallCCRequest, error) {
+	if req.DeploymentPackage == nil {
+		return req, nil
+	}
+
+	icr, err := resource.ResolveDeploymentPackage(resource.InstallChaincodeRequest{Name: req.Name, Path: req.Path, Version: req.Version, Package: req.Package, DeploymentPackage: req.DeploymentPackage})
+	if err != nil {
+		return req, errors.WithMessage(err, "invalid chaincode deployment package")
+	}
+
+	req.Name = icr.Name
+	req.Path = icr.Path
+	req.Version = icr.Version
+	return req, nil
+}
+
 func checkRequiredInstallCCParams(req InstallCCRequest) error {
-	if req.Name == "" || req.Version == "" || req.Path == "" || req.Package == nil {
+	if req.Name == "" || req.Version == "" || req.Path == "" || (req.Package == nil && req.DeploymentPackage == nil) {
 		return errors.New("Chaincode name, version, path and chaincode package are required")
 	}
 	return nil
@@ -553,6 +581,27 @@ func (rc *Client) QueryInstantiatedChaincodes(channelID string, options ...Reque
 		return nil, err
 	}
 
+	l, target, v, err := rc.prepareChannelQuery(channelID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.PeerResponse)
+	defer cancel()
+
+	responses, err := l.QueryInstantiatedChaincodes(reqCtx, []fab.ProposalProcessor{target}, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses[0], nil
+}
+
+// prepareChannelQuery returns the ledger of the channel, the target peer of the query and the verifier of the
+// query response. If peer is not specified in options the target is a random channel peer of the client's MSP
+// (since the LSCC only allows local calls).
+func (rc *Client) prepareChannelQuery(channelID string, opts requestOptions) (*channel.Ledger, fab.ProposalProcessor, *verifier.Signature, error) {
+
 	chCtx, err := contextImpl.NewChannel(
 		func() (context.Client, error) {
 			return rc.ctx, nil
@@ -560,7 +609,7 @@ func (rc *Client) QueryInstantiatedChaincodes(channelID string, options ...Reque
 		channelID,
 	)
 	if err != nil {
-		return nil, errors.WithMessage(err, "failed to create channel context")
+		return nil, nil, nil, errors.WithMessage(err, "failed to create channel context")
 	}
 
 	var target fab.ProposalProcessor
@@ -572,14 +621,14 @@ func (rc *Client) QueryInstantiatedChaincodes(channelID string, options ...Reque
 		// default filter will be applied (if any)
 		targets, err2 := rc.getDefaultTargets(discovery)
 		if err2 != nil {
-			return nil, errors.WithMessage(err2, "failed to get default target for query instantiated chaincodes")
+			return nil, nil, nil, errors.WithMessage(err2, "failed to get default target for channel query")
 		}
 
 		// Filter by MSP since the LSCC only allows local calls
 		targets = filterTargets(targets, &mspFilter{mspID: chCtx.Identifier().MSPID})
 
 		if len(targets) == 0 {
-			return nil, errors.Errorf("no targets in MSP [%s]", chCtx.Identifier().MSPID)
+			return nil, nil, nil, errors.Errorf("no targets in MSP [%s]", chCtx.Identifier().MSPID)
 		}
 
 		// select random channel peer
@@ -589,26 +638,18 @@ func (rc *Client) QueryInstantiatedChaincodes(channelID string, options ...Reque
 
 	l, err := channel.NewLedger(channelID)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
-	reqCtx, cancel := rc.createRequestContext(opts, fab.PeerResponse)
-	defer cancel()
-
 	// Channel service membership is required to verify signature
 	channelService := chCtx.ChannelService()
 
 	membership, err := channelService.Membership()
 	if err != nil {
-		return nil, errors.WithMessage(err, "membership creation failed")
-	}
-
-	responses, err := l.QueryInstantiatedChaincodes(reqCtx, []fab.ProposalProcessor{target}, &verifier.Signature{Membership: membership})
-	if err != nil {
-		return nil, err
+		return nil, nil, nil, errors.WithMessage(err, "membership creation failed")
 	}
 
-	return responses[0], nil
+	return l, target, &verifier.Signature{Membership: membership}, nil
 }
 
 // QueryChannels queries the names of all the channels that a peer has joined.
@@ -637,7 +678,7 @@ func (rc *Client) QueryChannels(options ...RequestOption) (*pb.ChannelQueryRespo
 }
 
 // validateSendCCProposal
-func (rc *Client) getCCProposalTargets(channelID string, req InstantiateCCRequest, opts requestOptions) ([]fab.Peer, error) {
+func (rc *Client) getCCProposalTargets(channelID string, opts requestOptions) ([]fab.Peer, error) {
 
 	chCtx, err := contextImpl.NewChannel(
 		func() (context.Client, error) {
@@ -709,7 +750,7 @@ func (rc *Client) sendCCProposal(reqCtx reqContext.Context, ccProposalType chain
 		return fab.EmptyTransactionID, err
 	}
 
-	targets, err := rc.getCCProposalTargets(channelID, req, opts)
+	targets, err := rc.getCCProposalTargets(channelID, opts)
 	if err != nil {
 		return fab.EmptyTransactionID, err
 	}
@@ -748,12 +789,12 @@ func (rc *Client) sendCCProposal(reqCtx reqContext.Context, ccProposalType chain
 	}
 
 	// send transaction and check event
-	return rc.sendTransactionAndCheckEvent(eventService, tp, txProposalResponse, transactor, reqCtx)
+	return rc.sendTransactionAndCheckEvent(eventService, tp, txProposalResponse, transactor, reqCtx, "instantiateOrUpgradeCC")
 
 }
 
 func (rc *Client) sendTransactionAndCheckEvent(eventService fab.EventService, tp *fab.TransactionProposal, txProposalResponse []*fab.TransactionProposalResponse,
-	transac fab.Transactor, reqCtx reqContext.Context) (fab.TransactionID, error) {
+	transac fab.Transactor, reqCtx reqContext.Context, operation string) (fab.TransactionID, error) {
 	// Register for commit event
 	reg, statusNotifier, err := eventService.RegisterTxStatusEvent(string(tp.TxnID))
 	if err != nil {
@@ -774,9 +815,9 @@ func (rc *Client) sendTransactionAndCheckEvent(eventService fab.EventService, tp
 		if txStatus.TxValidationCode == pb.TxValidationCode_VALID {
 			return fab.TransactionID(txStatus.TxID), nil
 		}
-		return fab.TransactionID(txStatus.TxID), status.New(status.EventServerStatus, int32(txStatus.TxValidationCode), "instantiateOrUpgradeCC failed", nil)
+		return fab.TransactionID(txStatus.TxID), status.New(status.EventServerStatus, int32(txStatus.TxValidationCode), fmt.Sprintf("%s failed", operation), nil)
 	case <-reqCtx.Done():
-		return tp.TxnID, errors.New("instantiateOrUpgradeCC timed out or cancelled")
+		return tp.TxnID, errors.Errorf("%s timed out or cancelled", operation)
 	}
 }
 
@@ -818,7 +859,11 @@ func peersToTxnProcessors(peers []fab.Peer) []fab.ProposalProcessor {
 	return tpp
 }
 
-// SaveChannel creates or updates channel.
+// SaveChannel creates or updates channel. The channel configuration is signed by the signing identities of the
+// request and/or by signatures collected from other parties (see WithConfigSignatures and CreateConfigSignature).
+// If neither are provided then the configuration is signed by the client's identity. Collected signatures are
+// validated against the MSPs of the channel, except when the channel is being created: the orderer then validates
+// them against the channel creation policy of the consortium.
 //  Parameters:
 //  req holds info about mandatory channel name and configuration
 //  options holds optional request options
@@ -832,30 +877,9 @@ func (rc *Client) SaveChannel(req SaveChannelRequest, options ...RequestOption)
 		return SaveChannelResponse{}, err
 	}
 
-	if req.ChannelConfigPath != "" {
-		configReader, err1 := os.Open(req.ChannelConfigPath)
-		if err1 != nil {
-			return SaveChannelResponse{}, errors.Wrapf(err1, "opening channel config file failed")
-		}
-		defer loggedClose(configReader)
-		req.ChannelConfig = configReader
-	}
-
-	err = rc.validateSaveChannelRequest(req)
+	chConfig, err := rc.readChannelConfig(req)
 	if err != nil {
-		return SaveChannelResponse{}, errors.WithMessage(err, "reading channel config file failed")
-	}
-
-	logger.Debugf("saving channel: %s", req.ChannelID)
-
-	configTx, err := ioutil.ReadAll(req.ChannelConfig)
-	if err != nil {
-		return SaveChannelResponse{}, errors.WithMessage(err, "reading channel config file failed")
-	}
-
-	chConfig, err := resource.ExtractChannelConfig(configTx)
-	if err != nil {
-		return SaveChannelResponse{}, errors.WithMessage(err, "extracting channel config failed")
+		return SaveChannelResponse{}, err
 	}
 
 	orderer, err := rc.requestOrderer(&opts, req.ChannelID)
@@ -863,7 +887,13 @@ func (rc *Client) SaveChannel(req SaveChannelRequest, options ...RequestOption)
 		return SaveChannelResponse{}, errors.WithMessage(err, "failed to find orderer for request")
 	}
 
-	configSignatures, err := rc.getConfigSignatures(req, chConfig)
+	if len(opts.Signatures) > 0 {
+		if err = rc.validateConfigSignatures(opts, orderer, req.ChannelID, chConfig, opts.Signatures); err != nil {
+			return SaveChannelResponse{}, errors.WithMessage(err, "config signature validation failed")
+		}
+	}
+
+	configSignatures, err := rc.getConfigSignatures(req, chConfig, opts.Signatures)
 	if err != nil {
 		return SaveChannelResponse{}, err
 	}
@@ -886,6 +916,36 @@ func (rc *Client) SaveChannel(req SaveChannelRequest, options ...RequestOption)
 	return SaveChannelResponse{TransactionID: txID}, nil
 }
 
+// readChannelConfig reads the channel configuration transaction of the request and returns the channel config
+func (rc *Client) readChannelConfig(req SaveChannelRequest) ([]byte, error) {
+	if req.ChannelConfigPath != "" {
+		configReader, err := os.Open(req.ChannelConfigPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "opening channel config file failed")
+		}
+		defer loggedClose(configReader)
+		req.ChannelConfig = configReader
+	}
+
+	err := rc.validateSaveChannelRequest(req)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading channel config file failed")
+	}
+
+	logger.Debugf("saving channel: %s", req.ChannelID)
+
+	configTx, err := ioutil.ReadAll(req.ChannelConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading channel config file failed")
+	}
+
+	chConfig, err := resource.ExtractChannelConfig(configTx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "extracting channel config failed")
+	}
+	return chConfig, nil
+}
+
 func (rc *Client) validateSaveChannelRequest(req SaveChannelRequest) error {
 
 	if req.ChannelID == "" || req.ChannelConfig == nil {
@@ -894,7 +954,7 @@ func (rc *Client) validateSaveChannelRequest(req SaveChannelRequest) error {
 	return nil
 }
 
-func (rc *Client) getConfigSignatures(req SaveChannelRequest, chConfig []byte) ([]*common.ConfigSignature, error) {
+func (rc *Client) getConfigSignatures(req SaveChannelRequest, chConfig []byte, preSigned []*common.ConfigSignature) ([]*common.ConfigSignature, error) {
 
 	// Signing user has to belong to one of configured channel organisations
 	// In case that order org is one of channel orgs we can use context user
@@ -906,23 +966,20 @@ func (rc *Client) getConfigSignatures(req SaveChannelRequest, chConfig []byte) (
 				signers = append(signers, id)
 			}
 		}
-	} else if rc.ctx != nil {
+	} else if len(preSigned) == 0 {
+		// No signatures were collected from other parties
+		if rc.ctx == nil {
+			return nil, errors.New("must provide signing user")
+		}
 		signers = append(signers, rc.ctx)
-	} else {
-		return nil, errors.New("must provide signing user")
 	}
 
-	var configSignatures []*common.ConfigSignature
+	configSignatures := append([]*common.ConfigSignature{}, preSigned...)
 	for _, signer := range signers {
 
-		sigCtx := contextImpl.Client{
-			SigningIdentity: signer,
-			Providers:       rc.ctx,
-		}
-
-		configSignature, err1 := resource.CreateConfigSignature(&sigCtx, chConfig)
+		configSignature, err1 := rc.signChannelConfig(signer, chConfig)
 		if err1 != nil {
-			return nil, errors.WithMessage(err1, "signing configuration failed")
+			return nil, err1
 		}
 		configSignatures = append(configSignatures, configSignature)
 	}
@@ -931,6 +988,21 @@ func (rc *Client) getConfigSignatures(req SaveChannelRequest, chConfig []byte) (
 
 }
 
+func (rc *Client) signChannelConfig(signer msp.SigningIdentity, chConfig []byte) (*common.ConfigSignature, error) {
+
+	sigCtx := contextImpl.Client{
+		SigningIdentity: signer,
+		Providers:       rc.ctx,
+	}
+
+	configSignature, err := resource.CreateConfigSignature(&sigCtx, chConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "signing configuration failed")
+	}
+
+	return configSignature, nil
+}
+
 func loggedClose(c io.Closer) {
 	err := c.Close()
 	if err != nil {
@@ -617,6 +617,48 @@ func TestInstallCC(t *testing.T) {
 	}
 }
 
+func TestInstallCCWithDeploymentPackage(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	response := &pb.ChaincodeQueryResponse{Chaincodes: []*pb.ChaincodeInfo{{Name: "name", Path: "path", Version: "version"}}}
+	responseBytes, err := proto.Marshal(response)
+	assert.Nil(t, err, "marshal should not have failed")
+
+	peer1 := fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com",
+		Status: http.StatusOK, MockRoles: []string{}, MockCert: nil, MockMSP: "Org1MSP", Payload: responseBytes}
+
+	// The chaincode ID is taken from the deployment package
+	req := InstallCCRequest{DeploymentPackage: newDeploymentPackage(t, "name", "path", "version")}
+	responses, err := rc.InstallCC(req, WithTargets(&peer1))
+	assert.NoError(t, err)
+	assert.Len(t, responses, 1)
+	assert.Contains(t, responses[0].Info, "already installed")
+
+	// Chaincode not found (it will be installed)
+	req = InstallCCRequest{Name: "ID", DeploymentPackage: newDeploymentPackage(t, "ID", "path", "v0")}
+	responses, err = rc.InstallCC(req, WithTargets(&peer1))
+	assert.NoError(t, err)
+	assert.Len(t, responses, 1)
+	assert.Equal(t, int32(http.StatusOK), responses[0].Status)
+	assert.Empty(t, responses[0].Info)
+
+	req = InstallCCRequest{Name: "ID", Version: "v1", DeploymentPackage: newDeploymentPackage(t, "ID", "path", "v0")}
+	_, err = rc.InstallCC(req, WithTargets(&peer1))
+	assert.Error(t, err, "expecting error since version doesn't match the deployment package")
+
+	req = InstallCCRequest{DeploymentPackage: []byte("invalid")}
+	_, err = rc.InstallCC(req, WithTargets(&peer1))
+	assert.Error(t, err, "expecting error for invalid deployment package")
+}
+
+func newDeploymentPackage(t *testing.T, name, path, version string) []byte {
+	cds, err := resource.CreateChaincodeDeploymentSpec(name, path, version, &resource.CCPackage{Type: 1, Code: []byte("code")})
+	assert.NoError(t, err)
+	cdsBytes, err := proto.Marshal(cds)
+	assert.NoError(t, err)
+	return cdsBytes
+}
+
 func TestInstallCCRequiredParameters(t *testing.T) {
 
 	rc := setupDefaultResMgmtClient(t)
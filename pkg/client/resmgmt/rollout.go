@@ -0,0 +1,330 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// rolloutPollInterval is the interval at which the peers are polled while waiting for them to be ready
+// to execute the deployed chaincode
+const rolloutPollInterval = time.Second
+
+// RolloutStage identifies a stage of a chaincode rollout
+type RolloutStage string
+
+// Chaincode rollout stages
+const (
+	RolloutInstall RolloutStage = "install" // The chaincode is installed on the peers of an organization
+	RolloutDeploy  RolloutStage = "deploy"  // The chaincode is instantiated or upgraded on the channel
+	RolloutVerify  RolloutStage = "verify"  // The peers of an organization are ready to execute the chaincode
+)
+
+// RolloutOrg is an organization taking part in a chaincode rollout
+type RolloutOrg struct {
+	Name    string   // Name of the organization (for example, its MSP ID), used in progress reports and errors
+	Client  *Client  // Resource management client with the context of the organization's admin
+	Targets []string // Endpoints of the organization's peers (defaults to the local peers of the admin's MSP)
+}
+
+// RolloutProgress reports the completion of a stage of a chaincode rollout
+type RolloutProgress struct {
+	Stage   RolloutStage
+	Org     string   // Organization (empty for the deploy stage)
+	Targets []string // Peers of the organization (empty for the deploy stage)
+	Skipped bool     // The stage had already been completed, for example by a previous rollout that failed part way through
+}
+
+// RolloutCCRequest holds parameters for rolling out a chaincode across organizations
+type RolloutCCRequest struct {
+	Orgs       []RolloutOrg
+	Install    InstallCCRequest // Chaincode name, path, version and package (or deployment package)
+	Args       [][]byte         // Instantiate or upgrade arguments
+	Policy     *common.SignaturePolicyEnvelope
+	CollConfig []*common.CollectionConfig
+	Progress   func(RolloutProgress) // Called when a stage is completed (optional)
+}
+
+// RolloutCCResponse contains the results of a chaincode rollout
+type RolloutCCResponse struct {
+	Installed     map[string][]InstallCCResponse // Install responses per organization (only for organizations that required an install)
+	Upgraded      bool                           // True if the chaincode was upgraded rather than instantiated
+	TransactionID fab.TransactionID              // Instantiate or upgrade transaction (empty if the version was already deployed)
+}
+
+// RolloutCC rolls out a chaincode across organizations: the chaincode is installed on the peers of each organization
+// with the organization's admin, it is then instantiated (or upgraded, if another version is instantiated) on the
+// channel by this client and, finally, each peer is polled until it is ready to execute the new version.
+// Each stage is skipped if it has already been completed, so a rollout that failed part way through may be resumed
+// by repeating the request. The stage that failed is identified by the returned error.
+//  Parameters:
+//  channelID is mandatory channel ID
+//  req holds info about the organizations, the chaincode and its policy
+//  options holds optional request options, which apply to all the requests of the rollout (except for targets,
+//  which only apply to the instantiate or upgrade)
+//
+//  Returns:
+//  rollout response with install responses and the instantiate or upgrade transaction ID
+func (rc *Client) RolloutCC(channelID string, req RolloutCCRequest, options ...RequestOption) (RolloutCCResponse, error) {
+
+	install, err := validateRolloutCCRequest(channelID, req)
+	if err != nil {
+		return RolloutCCResponse{}, err
+	}
+
+	resp := RolloutCCResponse{Installed: make(map[string][]InstallCCResponse)}
+
+	for _, org := range req.Orgs {
+		progress, installResp, err := rolloutInstall(org, install, options...)
+		if err != nil {
+			return resp, errors.WithMessage(err, fmt.Sprintf("installing chaincode %s:%s for %s failed", install.Name, install.Version, org.Name))
+		}
+		if installResp != nil {
+			resp.Installed[org.Name] = installResp
+		}
+		req.reportProgress(progress)
+	}
+
+	resp.Upgraded, resp.TransactionID, err = rc.rolloutDeploy(channelID, install, req, options...)
+	if err != nil {
+		return resp, errors.WithMessage(err, fmt.Sprintf("deploying chaincode %s:%s on channel %s failed", install.Name, install.Version, channelID))
+	}
+	req.reportProgress(RolloutProgress{Stage: RolloutDeploy, Skipped: resp.TransactionID == ""})
+
+	for _, org := range req.Orgs {
+		progress, err := rolloutVerify(channelID, org, install, options...)
+		if err != nil {
+			return resp, errors.WithMessage(err, fmt.Sprintf("verifying chaincode %s:%s for %s failed", install.Name, install.Version, org.Name))
+		}
+		req.reportProgress(progress)
+	}
+
+	return resp, nil
+}
+
+// validateRolloutCCRequest validates the request and returns its resolved install request
+func validateRolloutCCRequest(channelID string, req RolloutCCRequest) (InstallCCRequest, error) {
+	if len(req.Orgs) == 0 {
+		return InstallCCRequest{}, errors.New("must provide at least one organization")
+	}
+
+	names := make(map[string]bool)
+	for _, org := range req.Orgs {
+		if org.Name == "" || org.Client == nil {
+			return InstallCCRequest{}, errors.New("organization name and client are required")
+		}
+		if names[org.Name] {
+			return InstallCCRequest{}, errors.Errorf("duplicate organization: %s", org.Name)
+		}
+		names[org.Name] = true
+	}
+
+	install, err := resolveInstallCCRequest(req.Install)
+	if err != nil {
+		return InstallCCRequest{}, err
+	}
+
+	if err := checkRequiredInstallCCParams(install); err != nil {
+		return InstallCCRequest{}, err
+	}
+
+	if err := checkRequiredCCProposalParams(channelID, InstantiateCCRequest{Name: install.Name, Path: install.Path, Version: install.Version, Policy: req.Policy}); err != nil {
+		return InstallCCRequest{}, err
+	}
+
+	return install, nil
+}
+
+func (req RolloutCCRequest) reportProgress(progress RolloutProgress) {
+	logger.Debugf("chaincode rollout stage [%s] completed for [%s] (skipped: %t)", progress.Stage, progress.Org, progress.Skipped)
+	if req.Progress != nil {
+		req.Progress(progress)
+	}
+}
+
+// rolloutTargets returns the peers of the organization
+func rolloutTargets(org RolloutOrg, options ...RequestOption) ([]fab.Peer, error) {
+	opts, err := org.Client.prepareRequestOpts(withOptions(options, WithTargetEndpoints(org.Targets...))...)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultTargets, err := org.Client.resolveDefaultTargets(&opts)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get default targets")
+	}
+
+	targets, err := org.Client.calculateTargets(defaultTargets, opts.TargetFilter)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to determine target peers")
+	}
+
+	if len(targets) == 0 {
+		return nil, errors.WithStack(status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "no targets available", nil))
+	}
+	return targets, nil
+}
+
+// rolloutInstall installs the chaincode on the peers of the organization where it isn't installed yet.
+// Install responses are nil if the chaincode was already installed on all the peers.
+func rolloutInstall(org RolloutOrg, install InstallCCRequest, options ...RequestOption) (RolloutProgress, []InstallCCResponse, error) {
+	targets, err := rolloutTargets(org, options...)
+	if err != nil {
+		return RolloutProgress{}, nil, err
+	}
+
+	progress := RolloutProgress{Stage: RolloutInstall, Org: org.Name, Targets: peerURLs(targets)}
+	targetOpts := withOptions(options, WithTargets(targets...))
+
+	installed, err := isChaincodeInstalledOnAll(org.Client, install, targetOpts...)
+	if err != nil {
+		return progress, nil, err
+	}
+	if installed {
+		progress.Skipped = true
+		return progress, nil, nil
+	}
+
+	installResp, err := org.Client.InstallCC(install, targetOpts...)
+	if err != nil {
+		return progress, installResp, err
+	}
+
+	// Install failures aren't always reported, so verify the install
+	installed, err = isChaincodeInstalledOnAll(org.Client, install, targetOpts...)
+	if err != nil {
+		return progress, installResp, err
+	}
+	if !installed {
+		return progress, installResp, errors.New("chaincode isn't installed on all the peers")
+	}
+	return progress, installResp, nil
+}
+
+// isChaincodeInstalledOnAll returns true if the chaincode is installed on all the target peers. An error is returned
+// if a peer has the same chaincode version installed from different code.
+func isChaincodeInstalledOnAll(client *Client, install InstallCCRequest, options ...RequestOption) (bool, error) {
+	verifyResp, err := client.VerifyInstalledCC(install, options...)
+	if err != nil {
+		return false, err
+	}
+
+	installed := true
+	for _, r := range verifyResp {
+		if r.Mismatch {
+			// Installing can't fix this: a chaincode version can only be installed once on a peer
+			return false, errors.Errorf("chaincode installed on %s was installed from different code", r.Target)
+		}
+		installed = installed && r.Installed
+	}
+	return installed, nil
+}
+
+// rolloutDeploy instantiates the chaincode or, if another version is instantiated, upgrades it. An empty transaction ID
+// is returned if the version is already instantiated.
+func (rc *Client) rolloutDeploy(channelID string, install InstallCCRequest, req RolloutCCRequest, options ...RequestOption) (bool, fab.TransactionID, error) {
+	instantiated, err := rc.QueryInstantiatedChaincodes(channelID, options...)
+	if err != nil {
+		return false, "", errors.WithMessage(err, "querying instantiated chaincodes failed")
+	}
+
+	upgrade := false
+	for _, chaincode := range instantiated.Chaincodes {
+		if chaincode.Name == install.Name {
+			if chaincode.Version == install.Version {
+				logger.Debugf("chaincode %s:%s is already instantiated on channel %s", install.Name, install.Version, channelID)
+				return false, "", nil
+			}
+			upgrade = true
+		}
+	}
+
+	deploy := InstantiateCCRequest{Name: install.Name, Path: install.Path, Version: install.Version, Args: req.Args, Policy: req.Policy, CollConfig: req.CollConfig}
+	if upgrade {
+		resp, err := rc.UpgradeCC(channelID, UpgradeCCRequest(deploy), options...)
+		return true, resp.TransactionID, err
+	}
+
+	resp, err := rc.InstantiateCC(channelID, deploy, options...)
+	return false, resp.TransactionID, err
+}
+
+// rolloutVerify polls each peer of the organization until it reports the chaincode version as instantiated
+// or the resource management timeout expires
+func rolloutVerify(channelID string, org RolloutOrg, install InstallCCRequest, options ...RequestOption) (RolloutProgress, error) {
+	targets, err := rolloutTargets(org, options...)
+	if err != nil {
+		return RolloutProgress{}, err
+	}
+
+	progress := RolloutProgress{Stage: RolloutVerify, Org: org.Name, Targets: peerURLs(targets)}
+
+	opts, err := org.Client.prepareRequestOpts(options...)
+	if err != nil {
+		return progress, err
+	}
+
+	reqCtx, cancel := org.Client.createRequestContext(opts, fab.ResMgmt)
+	defer cancel()
+
+	for _, target := range targets {
+		for {
+			ready, err := isChaincodeInstantiated(channelID, org.Client, target, install, options...)
+			if err != nil {
+				return progress, err
+			}
+			if ready {
+				break
+			}
+
+			select {
+			case <-reqCtx.Done():
+				return progress, errors.Errorf("timed out waiting for chaincode to be ready on %s", target.URL())
+			case <-time.After(rolloutPollInterval):
+			}
+		}
+	}
+
+	return progress, nil
+}
+
+// isChaincodeInstantiated returns true if the peer reports the chaincode version as instantiated on the channel
+func isChaincodeInstantiated(channelID string, client *Client, target fab.Peer, install InstallCCRequest, options ...RequestOption) (bool, error) {
+	instantiated, err := client.QueryInstantiatedChaincodes(channelID, withOptions(options, WithTargets(target))...)
+	if err != nil {
+		return false, errors.WithMessage(err, "querying instantiated chaincodes on "+target.URL()+" failed")
+	}
+
+	for _, chaincode := range instantiated.Chaincodes {
+		if chaincode.Name == install.Name && chaincode.Version == install.Version {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// withOptions returns a copy of the caller's options followed by the additional options, so that appending
+// never writes to the backing array of the caller's slice
+func withOptions(options []RequestOption, additional ...RequestOption) []RequestOption {
+	opts := make([]RequestOption, 0, len(options)+len(additional))
+	opts = append(opts, options...)
+	return append(opts, additional...)
+}
+
+func peerURLs(peers []fab.Peer) []string {
+	urls := make([]string, len(peers))
+	for i, peer := range peers {
+		urls[i] = peer.URL()
+	}
+	return urls
+}
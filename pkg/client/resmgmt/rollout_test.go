@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloutCCResume(t *testing.T) {
+	install := InstallCCRequest{Name: "name", Path: "path", Version: "version", Package: &resource.CCPackage{Type: pb.ChaincodeSpec_GOLANG, Code: []byte("code")}}
+	fingerprint, err := chaincodeFingerprint(install)
+	require.NoError(t, err)
+
+	// The chaincode is installed and instantiated, as if a previous rollout had failed while verifying the peers
+	chaincode := &pb.ChaincodeInfo{Name: "name", Path: "path", Version: "version", Id: fingerprint}
+	org1Peer := newInstalledCCPeer(t, "http://peer1.org1.com", chaincode)
+	org2Peer := newInstalledCCPeer(t, "http://peer1.org2.com", chaincode)
+	org2Peer.MockMSP = "Org2MSP"
+
+	org1 := RolloutOrg{Name: "Org1MSP", Client: setupResMgmtClientWithLocalPeers(t, setupTestContext("test", "Org1MSP"), []fab.Peer{org1Peer})}
+	org2 := RolloutOrg{Name: "Org2MSP", Client: setupResMgmtClientWithLocalPeers(t, setupTestContext("test", "Org2MSP"), []fab.Peer{org2Peer})}
+
+	var progress []RolloutProgress
+	req := RolloutCCRequest{
+		Orgs:     []RolloutOrg{org1, org2},
+		Install:  install,
+		Policy:   cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP"}),
+		Progress: func(p RolloutProgress) { progress = append(progress, p) },
+	}
+
+	resp, err := org1.Client.RolloutCC("mychannel", req, WithTargets(org1Peer))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Installed)
+	assert.Empty(t, resp.TransactionID)
+	assert.False(t, resp.Upgraded)
+
+	assert.Equal(t, []RolloutProgress{
+		{Stage: RolloutInstall, Org: "Org1MSP", Targets: []string{"http://peer1.org1.com"}, Skipped: true},
+		{Stage: RolloutInstall, Org: "Org2MSP", Targets: []string{"http://peer1.org2.com"}, Skipped: true},
+		{Stage: RolloutDeploy, Skipped: true},
+		{Stage: RolloutVerify, Org: "Org1MSP", Targets: []string{"http://peer1.org1.com"}},
+		{Stage: RolloutVerify, Org: "Org2MSP", Targets: []string{"http://peer1.org2.com"}},
+	}, progress)
+}
+
+func TestRolloutCCMismatch(t *testing.T) {
+	install := InstallCCRequest{Name: "name", Path: "path", Version: "version", Package: &resource.CCPackage{Type: pb.ChaincodeSpec_GOLANG, Code: []byte("code")}}
+
+	// The same version was installed from different code
+	peer := newInstalledCCPeer(t, "http://peer1.org1.com", &pb.ChaincodeInfo{Name: "name", Path: "path", Version: "version", Id: []byte("other")})
+	org1 := RolloutOrg{Name: "Org1MSP", Client: setupResMgmtClientWithLocalPeers(t, setupTestContext("test", "Org1MSP"), []fab.Peer{peer})}
+
+	req := RolloutCCRequest{Orgs: []RolloutOrg{org1}, Install: install, Policy: cauthdsl.SignedByMspMember("Org1MSP")}
+	_, err := org1.Client.RolloutCC("mychannel", req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "installing chaincode name:version for Org1MSP failed")
+	assert.Contains(t, err.Error(), "chaincode installed on http://peer1.org1.com was installed from different code")
+}
+
+func TestRolloutCCRequiredParameters(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	install := InstallCCRequest{Name: "name", Path: "path", Version: "version", Package: &resource.CCPackage{Code: []byte("code")}}
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+	org1 := RolloutOrg{Name: "Org1MSP", Client: rc}
+
+	tests := []struct {
+		channelID string
+		req       RolloutCCRequest
+		err       string
+	}{
+		{channelID: "mychannel", req: RolloutCCRequest{Install: install, Policy: policy}, err: "must provide at least one organization"},
+		{channelID: "mychannel", req: RolloutCCRequest{Orgs: []RolloutOrg{{Name: "Org1MSP"}}, Install: install, Policy: policy}, err: "organization name and client are required"},
+		{channelID: "mychannel", req: RolloutCCRequest{Orgs: []RolloutOrg{org1, org1}, Install: install, Policy: policy}, err: "duplicate organization: Org1MSP"},
+		{channelID: "mychannel", req: RolloutCCRequest{Orgs: []RolloutOrg{org1}, Install: InstallCCRequest{Name: "name", Path: "path", Version: "version"}, Policy: policy}, err: "Chaincode name, version, path and chaincode package are required"},
+		{channelID: "mychannel", req: RolloutCCRequest{Orgs: []RolloutOrg{org1}, Install: install}, err: "Chaincode name, version, path and policy are required"},
+		{channelID: "", req: RolloutCCRequest{Orgs: []RolloutOrg{org1}, Install: install, Policy: policy}, err: "must provide channel ID"},
+	}
+
+	for _, test := range tests {
+		_, err := rc.RolloutCC(test.channelID, test.req)
+		assert.EqualError(t, err, test.err)
+	}
+}
+
+func TestRolloutWithOptions(t *testing.T) {
+	options := make([]RequestOption, 1, 2)
+	options[0] = WithTargetEndpoints("peer1")
+
+	opts := withOptions(options, WithTargetEndpoints("peer2"))
+	assert.Len(t, opts, 2)
+	opts[0] = nil
+	assert.NotNil(t, options[0], "caller's options must not be modified")
+
+	// The caller's backing array has room for another option, which must not be written
+	opts = withOptions(options, WithTargetEndpoints("peer3"))
+	assert.Nil(t, options[:2][1], "caller's options must not be appended to")
+	assert.Len(t, opts, 2)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"bytes"
+	reqContext "context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/pkg/errors"
+)
+
+// VerifyInstalledCCResponse contains the result of the verification of the chaincode installed on a target peer
+type VerifyInstalledCCResponse struct {
+	Target string
+	// Installed is true if a chaincode with the same name and version is installed on the target
+	Installed bool
+	// Fingerprint is the fingerprint of the chaincode installed on the target (nil if it isn't installed)
+	Fingerprint []byte
+	// Mismatch is true if the chaincode installed on the target was installed from different code
+	Mismatch bool
+}
+
+// VerifyInstalledCC verifies that the chaincode installed on the peers was installed from the given chaincode package,
+// by comparing the fingerprint of the package with the fingerprint (ID) of the installed chaincode reported by the peers.
+// Peers where a chaincode with the same name and version was installed from different code are flagged as mismatched.
+// If peer(s) are not specified in options it will default to all peers that belong to admin's MSP.
+//  Parameters:
+//  req holds info about mandatory chaincode name, path, version and package (or deployment package)
+//  options holds optional request options
+//
+//  Returns:
+//  verification responses from peer(s)
+func (rc *Client) VerifyInstalledCC(req InstallCCRequest, options ...RequestOption) ([]VerifyInstalledCCResponse, error) {
+
+	req, err := resolveInstallCCRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkRequiredInstallCCParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint, err := chaincodeFingerprint(req)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get opts for VerifyInstalledCC")
+	}
+
+	targets, err := rc.verifyInstalledCCTargets(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.ResMgmt)
+	defer cancel()
+
+	errs := multi.Errors{}
+	responses := make([]VerifyInstalledCCResponse, 0, len(targets))
+	for _, target := range targets {
+		response, err := verifyInstalledCC(reqCtx, target, req, fingerprint, opts.Retry)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, errs.ToError()
+}
+
+// verifyInstalledCC compares the fingerprint with the fingerprint of the chaincode installed on the target
+func verifyInstalledCC(reqCtx reqContext.Context, target fab.Peer, req InstallCCRequest, fingerprint []byte, retry retry.Opts) (VerifyInstalledCCResponse, error) {
+	response := VerifyInstalledCCResponse{Target: target.URL()}
+
+	chaincodeQueryResponse, err := resource.QueryInstalledChaincodes(reqCtx, target, resource.WithRetry(retry))
+	if err != nil {
+		return response, errors.WithMessage(err, "unable to query installed chaincodes on "+target.URL())
+	}
+
+	for _, chaincode := range chaincodeQueryResponse.Chaincodes {
+		if chaincode.Name == req.Name && chaincode.Version == req.Version {
+			response.Installed = true
+			response.Fingerprint = chaincode.Id
+			response.Mismatch = !bytes.Equal(chaincode.Id, fingerprint)
+			break
+		}
+	}
+	if response.Mismatch {
+		logger.Warnf("chaincode %s:%s installed on %s was installed from different code", req.Name, req.Version, target.URL())
+	}
+
+	return response, nil
+}
+
+// verifyInstalledCCTargets returns the target peers of VerifyInstalledCC: the peers specified in the options or, if
+// none are specified, all peers that belong to admin's MSP
+func (rc *Client) verifyInstalledCCTargets(opts *requestOptions) ([]fab.Peer, error) {
+	defaultTargets, err := rc.resolveDefaultTargets(opts)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get default targets for VerifyInstalledCC")
+	}
+
+	targets, err := rc.calculateTargets(defaultTargets, opts.TargetFilter)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to determine target peers for VerifyInstalledCC")
+	}
+
+	if len(targets) == 0 {
+		return nil, errors.WithStack(status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "no targets available", nil))
+	}
+
+	return targets, nil
+}
+
+// chaincodeFingerprint computes the fingerprint of the chaincode package (or deployment package) of the request
+func chaincodeFingerprint(req InstallCCRequest) ([]byte, error) {
+	deploymentPackage := req.DeploymentPackage
+	if deploymentPackage == nil {
+		cds, err := resource.CreateChaincodeDeploymentSpec(req.Name, req.Path, req.Version, req.Package)
+		if err != nil {
+			return nil, err
+		}
+		deploymentPackage, err = proto.Marshal(cds)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal of chaincode deployment spec failed")
+		}
+	}
+
+	pkg, err := resource.UnmarshalCCDeploymentPackage(deploymentPackage)
+	if err != nil {
+		return nil, err
+	}
+	return pkg.Fingerprint(), nil
+}
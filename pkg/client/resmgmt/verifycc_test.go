@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyInstalledCC(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	ccPkg := &resource.CCPackage{Type: pb.ChaincodeSpec_GOLANG, Code: []byte("code")}
+	req := InstallCCRequest{Name: "name", Path: "path", Version: "version", Package: ccPkg}
+
+	fingerprint, err := chaincodeFingerprint(req)
+	require.NoError(t, err)
+
+	peer1 := newInstalledCCPeer(t, "http://peer1.com", &pb.ChaincodeInfo{Name: "name", Path: "path", Version: "version", Id: fingerprint})
+	peer2 := newInstalledCCPeer(t, "http://peer2.com", &pb.ChaincodeInfo{Name: "name", Path: "path", Version: "version", Id: []byte("other")})
+	peer3 := newInstalledCCPeer(t, "http://peer3.com", &pb.ChaincodeInfo{Name: "name", Path: "path", Version: "other", Id: fingerprint})
+
+	responses, err := rc.VerifyInstalledCC(req, WithTargets(peer1, peer2, peer3))
+	require.NoError(t, err)
+	assert.Equal(t, []VerifyInstalledCCResponse{
+		{Target: "http://peer1.com", Installed: true, Fingerprint: fingerprint},
+		{Target: "http://peer2.com", Installed: true, Fingerprint: []byte("other"), Mismatch: true},
+		{Target: "http://peer3.com"},
+	}, responses)
+
+	// The same package as a deployment package
+	cds, err := resource.CreateChaincodeDeploymentSpec("name", "path", "version", ccPkg)
+	require.NoError(t, err)
+	cdsBytes, err := proto.Marshal(cds)
+	require.NoError(t, err)
+
+	responses, err = rc.VerifyInstalledCC(InstallCCRequest{DeploymentPackage: cdsBytes}, WithTargets(peer1, peer2))
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	assert.False(t, responses[0].Mismatch)
+	assert.True(t, responses[1].Mismatch)
+}
+
+func TestVerifyInstalledCCError(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	peer1 := newInstalledCCPeer(t, "http://peer1.com")
+	peer2 := newInstalledCCPeer(t, "http://peer2.com")
+	peer2.Error = fmt.Errorf("Test error message")
+	peer2.Status = http.StatusInternalServerError
+
+	_, err := rc.VerifyInstalledCC(InstallCCRequest{Name: "name", Path: "path", Version: "version"}, WithTargets(peer1))
+	assert.Error(t, err, "expecting error for missing chaincode package")
+
+	req := InstallCCRequest{Name: "name", Path: "path", Version: "version", Package: &resource.CCPackage{Code: []byte("code")}}
+	responses, err := rc.VerifyInstalledCC(req, WithTargets(peer1, peer2))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Test error message")
+	assert.Len(t, responses, 1, "expecting response of peer that was queried successfully")
+}
+
+func newInstalledCCPeer(t *testing.T, url string, chaincodes ...*pb.ChaincodeInfo) *fcmocks.MockPeer {
+	payload, err := proto.Marshal(&pb.ChaincodeQueryResponse{Chaincodes: chaincodes})
+	require.NoError(t, err)
+
+	return &fcmocks.MockPeer{MockName: url, MockURL: url, Status: http.StatusOK, MockMSP: "Org1MSP", Payload: payload}
+}
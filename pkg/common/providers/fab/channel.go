@@ -8,6 +8,7 @@ package fab
 
 import (
 	reqContext "context"
+	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	mspCfg "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
@@ -55,6 +56,36 @@ type ChannelCfg interface {
 	Orderers() []string
 	Versions() *Versions
 	HasCapability(group ConfigGroupKey, capability string) bool
+	// OrdererSettings returns the settings of the ordering service, or nil if the channel config has no orderer group
+	OrdererSettings() (*OrdererSettings, error)
+	// ACLs returns the policies (for example, "/Channel/Application/Writers") of the channel's ACLs,
+	// keyed by resource (for example, "peer/Propose")
+	ACLs() map[string]string
+	// Policies returns the policy tree of the channel config, starting at the channel group (/Channel)
+	Policies() *PolicyGroup
+}
+
+// BatchSize contains the block cutting parameters of the ordering service
+type BatchSize struct {
+	// MaxMessageCount is the maximum number of transactions in a block
+	MaxMessageCount uint32
+	// AbsoluteMaxBytes is the absolute maximum number of bytes of the transactions in a block
+	AbsoluteMaxBytes uint32
+	// PreferredMaxBytes is the preferred maximum number of bytes of the transactions in a block
+	PreferredMaxBytes uint32
+}
+
+// OrdererSettings contains the settings of the ordering service for a channel
+type OrdererSettings struct {
+	ConsensusType string
+	// ConsensusMetadata is the consensus type specific metadata (for example, the etcdraft options and consenters)
+	ConsensusMetadata []byte
+	BatchSize         BatchSize
+	// BatchTimeout is the amount of time to wait before cutting a block
+	BatchTimeout time.Duration
+	KafkaBrokers []string
+	// MaxChannels is the maximum number of channels allowed by the ordering service (0 if unlimited)
+	MaxChannels uint64
 }
 
 // ChannelMembership helps identify a channel's members
@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fab
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PolicyType is the type of a channel config policy
+type PolicyType string
+
+const (
+	// SignaturePolicyType is the type of policies that require signatures from a set of principals
+	SignaturePolicyType PolicyType = "Signature"
+	// ImplicitMetaPolicyType is the type of policies that aggregate the policies of the sub-groups
+	ImplicitMetaPolicyType PolicyType = "ImplicitMeta"
+	// UnknownPolicyType is the type of policies that are not parsed (for example, MSP policies)
+	UnknownPolicyType PolicyType = "Unknown"
+)
+
+// PolicyGroup contains the policies of a config group along with the policies of its sub-groups
+type PolicyGroup struct {
+	// Name is the name of the config group (for example, Org1MSP)
+	Name string
+	// Path is the path of the config group (for example, /Channel/Application/Org1MSP)
+	Path      string
+	ModPolicy string
+	Policies  map[string]*Policy
+	Groups    map[string]*PolicyGroup
+}
+
+// Policy returns the policy with the given path (for example, /Channel/Application/Admins) or nil if the
+// policy doesn't exist. A path that isn't absolute is relative to this group.
+func (g *PolicyGroup) Policy(path string) *Policy {
+	if !strings.HasPrefix(path, "/") {
+		path = g.Path + "/" + path
+	}
+	if !strings.HasPrefix(path, g.Path+"/") {
+		return nil
+	}
+
+	elements := strings.Split(strings.TrimPrefix(path, g.Path+"/"), "/")
+	group := g
+	for _, name := range elements[:len(elements)-1] {
+		group = group.Groups[name]
+		if group == nil {
+			return nil
+		}
+	}
+	return group.Policies[elements[len(elements)-1]]
+}
+
+// Policy is a policy of the channel configuration
+type Policy struct {
+	// Name is the name of the policy (for example, Admins)
+	Name string
+	// Path is the path of the policy (for example, /Channel/Application/Org1MSP/Admins)
+	Path      string
+	ModPolicy string
+	Type      PolicyType
+	// Signature is set for signature policies
+	Signature *SignaturePolicy
+	// ImplicitMeta is set for implicit meta policies
+	ImplicitMeta *ImplicitMetaPolicy
+}
+
+// String returns the policy as a readable expression
+func (p *Policy) String() string {
+	switch {
+	case p.Type == SignaturePolicyType && p.Signature != nil:
+		return p.Signature.String()
+	case p.Type == ImplicitMetaPolicyType && p.ImplicitMeta != nil:
+		return p.ImplicitMeta.String()
+	default:
+		return string(p.Type)
+	}
+}
+
+// SignaturePolicy is a rule of a signature policy. The rule is either satisfied by the signature of a principal
+// or, if no principal is set, by N of the sub-rules.
+type SignaturePolicy struct {
+	Principal *Principal
+	N         int32
+	Rules     []*SignaturePolicy
+}
+
+// String returns the rule as an expression, for example OutOf(2, 'Org1MSP.admin', 'Org2MSP.admin')
+func (p *SignaturePolicy) String() string {
+	if p.Principal != nil {
+		return p.Principal.String()
+	}
+
+	args := []string{fmt.Sprintf("%d", p.N)}
+	for _, rule := range p.Rules {
+		args = append(args, rule.String())
+	}
+	return fmt.Sprintf("OutOf(%s)", strings.Join(args, ", "))
+}
+
+// PrincipalClassification is the way a principal is identified
+type PrincipalClassification string
+
+const (
+	// RolePrincipal identifies the principal by role within an MSP
+	RolePrincipal PrincipalClassification = "ROLE"
+	// OrganizationUnitPrincipal identifies the principal by organizational unit within an MSP
+	OrganizationUnitPrincipal PrincipalClassification = "ORGANIZATION_UNIT"
+	// IdentityPrincipal identifies the principal by identity
+	IdentityPrincipal PrincipalClassification = "IDENTITY"
+)
+
+// Principal is a principal whose signature satisfies a signature policy rule
+type Principal struct {
+	Classification PrincipalClassification
+	MSPID          string
+	// Role (member, admin, client or peer) is set for ROLE principals
+	Role string
+	// OrganizationalUnit is set for ORGANIZATION_UNIT principals
+	OrganizationalUnit string
+}
+
+// String returns the principal as an expression, for example 'Org1MSP.admin'
+func (p *Principal) String() string {
+	switch p.Classification {
+	case RolePrincipal:
+		return fmt.Sprintf("'%s.%s'", p.MSPID, p.Role)
+	case OrganizationUnitPrincipal:
+		return fmt.Sprintf("'%s.ou(%s)'", p.MSPID, p.OrganizationalUnit)
+	case IdentityPrincipal:
+		return fmt.Sprintf("'%s.identity'", p.MSPID)
+	default:
+		return fmt.Sprintf("'%s'", p.Classification)
+	}
+}
+
+// ImplicitMetaPolicy is satisfied if the given number (ANY, ALL or MAJORITY) of the sub-policies with the given
+// name in the sub-groups are satisfied
+type ImplicitMetaPolicy struct {
+	Rule      string
+	SubPolicy string
+}
+
+// String returns the policy as an expression, for example MAJORITY Admins
+func (p *ImplicitMetaPolicy) String() string {
+	return fmt.Sprintf("%s %s", p.Rule, p.SubPolicy)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gopackager
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// platformImports are the import path prefixes of the packages that are provided by the chaincode build
+// environment of the peer (fabric-ccenv), so they don't have to be packaged with the chaincode
+var platformImports = []string{"github.com/hyperledger/fabric/"}
+
+// resolveFunc returns the directory of the package with the given import path, as imported by the package in
+// the given directory. The returned directory is empty if the package can't be resolved.
+type resolveFunc func(importPath string, importerDir string) string
+
+// findDependencies performs the same import analysis as 'go list -deps' for the packages in the given
+// directories: the imports of every package are resolved (transitively) using the given resolve function.
+// The directories of all packages (including the given ones) are returned. Imports of the standard library
+// and of the packages provided by the peer are not resolved, other imports that can't be resolved are
+// returned as an error since the chaincode would fail to build on the peer.
+func findDependencies(pkgDirs []string, resolve resolveFunc) ([]string, error) {
+	ctx := buildContext()
+
+	visited := make(map[string]bool)
+	var dirs []string
+	queue := append([]string{}, pkgDirs...)
+	for _, dir := range pkgDirs {
+		visited[dir] = true
+	}
+
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		dirs = append(dirs, dir)
+
+		pkg, err := ctx.ImportDir(dir, 0)
+		if _, ok := err.(*build.NoGoError); ok && contains(pkgDirs, dir) {
+			// For example, a directory that only contains tests
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "import analysis of package in %s failed", dir)
+		}
+
+		depDirs, err := resolveImports(pkg, dir, resolve)
+		if err != nil {
+			return nil, err
+		}
+		for _, depDir := range depDirs {
+			if !visited[depDir] {
+				visited[depDir] = true
+				queue = append(queue, depDir)
+			}
+		}
+	}
+
+	return dirs, nil
+}
+
+// resolveImports returns the directories of the packages imported by the package in the given directory,
+// excluding the standard library and the packages provided by the peer
+func resolveImports(pkg *build.Package, dir string, resolve resolveFunc) ([]string, error) {
+	var depDirs []string
+	for _, importPath := range pkg.Imports {
+		if isStandardImportPath(importPath) {
+			continue
+		}
+
+		depDir := resolve(importPath, dir)
+		if depDir == "" {
+			if isPlatformImportPath(importPath) {
+				continue
+			}
+			return nil, errors.Errorf("cannot resolve import %s of package in %s", importPath, dir)
+		}
+		depDirs = append(depDirs, depDir)
+	}
+	return depDirs, nil
+}
+
+// buildContext returns the build context of the chaincode build environment on the peer
+func buildContext() build.Context {
+	ctx := build.Default
+	ctx.GOOS = "linux"
+	ctx.GOARCH = "amd64"
+	ctx.CgoEnabled = true
+	return ctx
+}
+
+// isStandardImportPath reports whether the import path is part of the standard library (or is the
+// pseudo-package "C"), using the same rule as the go tool: the first path element doesn't contain a dot
+func isStandardImportPath(importPath string) bool {
+	elem := importPath
+	if i := strings.Index(importPath, "/"); i >= 0 {
+		elem = importPath[:i]
+	}
+	return !strings.Contains(elem, ".")
+}
+
+func isPlatformImportPath(importPath string) bool {
+	for _, prefix := range platformImports {
+		if strings.HasPrefix(importPath, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// vendorDir returns the directory of the vendored package with the given import path, looking up the vendor
+// directories from the importing package's directory up to (and including) the given root directory
+func vendorDir(importPath string, importerDir string, rootDir string) string {
+	for dir := importerDir; ; dir = filepath.Dir(dir) {
+		if !isSubDir(rootDir, dir) {
+			return ""
+		}
+
+		if depDir := filepath.Join(dir, "vendor", filepath.FromSlash(importPath)); isDir(depDir) {
+			return depDir
+		}
+
+		if dir == rootDir || dir == filepath.Dir(dir) {
+			return ""
+		}
+	}
+}
+
+// hasGoFiles returns true if the directory contains Go source files
+func hasGoFiles(dir string) bool {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	return err == nil && len(files) > 0
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isDir(dir string) bool {
+	fileInfo, err := os.Stat(dir)
+	return err == nil && fileInfo.IsDir()
+}
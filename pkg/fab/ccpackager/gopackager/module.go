@@ -0,0 +1,218 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gopackager
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/internal/tarball"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/metadata"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	goModFile = "go.mod"
+	goSumFile = "go.sum"
+)
+
+// NewModuleCCPackage creates a new go lang chaincode package for a chaincode that is part of a Go module, so
+// that it doesn't have to live under the GOPATH. The module (the closest parent directory with a go.mod file)
+// is packaged at its import path, i.e. as if it were in the GOPATH. Only the packages of the module and of its
+// vendor directory that are imported (directly or indirectly) by the chaincode are packaged, along with the
+// go.mod and go.sum files and the META-INF directory of the chaincode. An error is returned if an import can't
+// be resolved from the module or its vendor directory.
+//  Parameters:
+//  chaincodeDir is the directory of the chaincode's main package
+//
+//  Returns:
+//  the chaincode package and the chaincode path (the import path of the chaincode) to be used in install requests
+func NewModuleCCPackage(chaincodeDir string) (*resource.CCPackage, string, error) {
+
+	if chaincodeDir == "" {
+		return nil, "", errors.New("chaincode directory must be provided")
+	}
+
+	ccDir, err := filepath.Abs(chaincodeDir)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "invalid chaincode directory")
+	}
+	if !isDir(ccDir) {
+		return nil, "", errors.Errorf("chaincode directory %s not found", chaincodeDir)
+	}
+
+	moduleRoot, err := findModuleRoot(ccDir)
+	if err != nil {
+		return nil, "", err
+	}
+	modulePath, err := readModulePath(filepath.Join(moduleRoot, goModFile))
+	if err != nil {
+		return nil, "", err
+	}
+
+	relPath, err := filepath.Rel(moduleRoot, ccDir)
+	if err != nil {
+		return nil, "", err
+	}
+	ccPath := path.Join(modulePath, filepath.ToSlash(relPath))
+
+	logger.Debugf("module %s in %s, chaincode path=%s", modulePath, moduleRoot, ccPath)
+
+	descriptors, err := findModuleSource(moduleRoot, modulePath, ccDir)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := metadata.ValidateFiles(tarball.Files(descriptors)); err != nil {
+		return nil, "", err
+	}
+	tarBytes, err := tarball.GenerateTarGz(descriptors)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &resource.CCPackage{Type: pb.ChaincodeSpec_GOLANG, Code: tarBytes}, ccPath, nil
+}
+
+// findModuleSource returns the descriptors of the module's files that are packaged
+func findModuleSource(moduleRoot string, modulePath string, ccDir string) ([]*Descriptor, error) {
+	if !hasGoFiles(ccDir) {
+		return nil, errors.Errorf("no Go source files in chaincode directory %s", ccDir)
+	}
+
+	dirs, err := findDependencies([]string{ccDir}, moduleResolver(moduleRoot, modulePath))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := path.Join("src", modulePath)
+
+	var descriptors []*Descriptor
+	for _, dir := range dirs {
+		pkgDescriptors, err := findPackageSource(moduleRoot, dir, prefix)
+		if err != nil {
+			return nil, err
+		}
+		descriptors = append(descriptors, pkgDescriptors...)
+	}
+
+	for _, name := range []string{goModFile, goSumFile} {
+		if fileInfo, err := os.Stat(filepath.Join(moduleRoot, name)); err == nil && fileInfo.Mode().IsRegular() {
+			descriptors = append(descriptors, &Descriptor{Name: path.Join(prefix, name), Path: filepath.Join(moduleRoot, name)})
+		}
+	}
+
+	metadataFiles, err := findMetadata(filepath.Join(ccDir, metadata.Dir))
+	if err != nil {
+		return nil, err
+	}
+	descriptors = append(descriptors, metadataFiles...)
+
+	sort.Slice(descriptors, func(i, j int) bool { return descriptors[i].Name < descriptors[j].Name })
+
+	return descriptors, nil
+}
+
+// moduleResolver resolves imports from the packages of the module and from the module's vendor directory
+func moduleResolver(moduleRoot string, modulePath string) resolveFunc {
+	return func(importPath string, importerDir string) string {
+		if importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/") {
+			dir := filepath.Join(moduleRoot, filepath.FromSlash(strings.TrimPrefix(importPath, modulePath)))
+			if hasGoFiles(dir) {
+				return dir
+			}
+			return ""
+		}
+		if dir := filepath.Join(moduleRoot, "vendor", filepath.FromSlash(importPath)); hasGoFiles(dir) {
+			return dir
+		}
+		return ""
+	}
+}
+
+// findMetadata returns the descriptors of the files in the chaincode's META-INF directory, if it exists
+func findMetadata(dir string) ([]*Descriptor, error) {
+	if !isDir(dir) {
+		return nil, nil
+	}
+
+	var descriptors []*Descriptor
+	err := filepath.Walk(dir,
+		func(filePath string, fileInfo os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !fileInfo.Mode().IsRegular() || !isSource(filePath) {
+				return nil
+			}
+			relPath, err := filepath.Rel(dir, filePath)
+			if err != nil {
+				return err
+			}
+			descriptors = append(descriptors, &Descriptor{Name: path.Join(metadata.Dir, filepath.ToSlash(relPath)), Path: filePath})
+			return nil
+		})
+
+	return descriptors, err
+}
+
+// findModuleRoot returns the closest directory (starting with the given directory) that contains a go.mod file
+func findModuleRoot(dir string) (string, error) {
+	for {
+		if fileInfo, err := os.Stat(filepath.Join(dir, goModFile)); err == nil && fileInfo.Mode().IsRegular() {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.mod not found in chaincode directory or any of its parent directories")
+		}
+		dir = parent
+	}
+}
+
+// readModulePath returns the module path declared in the given go.mod file
+func readModulePath(goModPath string) (string, error) {
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return "", errors.Wrap(err, "opening go.mod failed")
+	}
+	defer func() {
+		err := file.Close()
+		if err != nil {
+			logger.Warnf("error file close %v", err)
+		}
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "module" {
+			continue
+		}
+
+		modulePath := fields[1]
+		if unquoted, err := strconv.Unquote(modulePath); err == nil {
+			modulePath = unquoted
+		}
+		return modulePath, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", errors.Wrap(err, "reading go.mod failed")
+	}
+
+	return "", errors.Errorf("module path not found in %s", goModPath)
+}
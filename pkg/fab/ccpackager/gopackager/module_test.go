@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gopackager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/internal/packagertest"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const moduleCCDir = "../../../../test/fixtures/testdata/gomodule/chaincode"
+
+// Test packaging of a chaincode in a Go module outside of the GOPATH
+func TestNewModuleCCPackage(t *testing.T) {
+	ccPackage, ccPath, err := NewModuleCCPackage(moduleCCDir)
+	require.NoError(t, err)
+	assert.Equal(t, pb.ChaincodeSpec_GOLANG, ccPackage.Type)
+	assert.Equal(t, "github.com/example/modcc/chaincode", ccPath)
+
+	// The unused package isn't packaged
+	assert.Equal(t, []string{
+		"META-INF/statedb/couchdb/indexes/indexOwner.json",
+		"src/github.com/example/modcc/chaincode/main.go",
+		"src/github.com/example/modcc/go.mod",
+		"src/github.com/example/modcc/lib/lib.go",
+		"src/github.com/example/modcc/vendor/github.com/example/dep/dep.go",
+	}, packagertest.PackagedFiles(t, ccPackage.Code))
+}
+
+func TestNewModuleCCPackageError(t *testing.T) {
+	_, _, err := NewModuleCCPackage("")
+	assert.Error(t, err, "expecting error for missing chaincode directory")
+
+	_, _, err = NewModuleCCPackage(filepath.Join(moduleCCDir, "missing"))
+	assert.Error(t, err, "expecting error for invalid chaincode directory")
+
+	_, _, err = NewModuleCCPackage(filepath.Join(moduleCCDir, "META-INF"))
+	assert.Error(t, err, "expecting error since chaincode directory has no Go files")
+
+	_, _, err = NewModuleCCPackage(filepath.Join(moduleCCDir, "../unused"))
+	assert.Error(t, err, "expecting error for unresolved import")
+
+	moduleDir, err := ioutil.TempDir("", "gomodule")
+	require.NoError(t, err)
+	defer os.RemoveAll(moduleDir)
+
+	packagertest.WriteFile(t, moduleDir, "cc/main.go", "package main\n\nfunc main() {}\n")
+	_, _, err = NewModuleCCPackage(filepath.Join(moduleDir, "cc"))
+	assert.Error(t, err, "expecting error since go.mod doesn't exist")
+
+	packagertest.WriteFile(t, moduleDir, "go.mod", "// no module\n")
+	_, _, err = NewModuleCCPackage(filepath.Join(moduleDir, "cc"))
+	assert.Error(t, err, "expecting error since go.mod doesn't declare the module path")
+
+	packagertest.WriteFile(t, moduleDir, "go.mod", "module example.com/cc\n")
+	packagertest.WriteFile(t, moduleDir, "cc/META-INF/statedb/couchdb/indexes/index.json", "{")
+	_, _, err = NewModuleCCPackage(filepath.Join(moduleDir, "cc"))
+	assert.Error(t, err, "expecting error for invalid CouchDB index")
+}
+
+func TestReadModulePath(t *testing.T) {
+	moduleDir, err := ioutil.TempDir("", "gomodule")
+	require.NoError(t, err)
+	defer os.RemoveAll(moduleDir)
+
+	packagertest.WriteFile(t, moduleDir, "go.mod", "// comment\nmodule \"example.com/cc\" // module path\n\nrequire example.com/dep v1.0.0\n")
+	modulePath, err := readModulePath(filepath.Join(moduleDir, "go.mod"))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/cc", modulePath)
+}
+
+// Test packaging of the packages in the GOPATH that are imported by the chaincode
+func TestNewCCPackageDependencies(t *testing.T) {
+	goPath, err := ioutil.TempDir("", "gopath")
+	require.NoError(t, err)
+	defer os.RemoveAll(goPath)
+
+	packagertest.WriteFile(t, goPath, "src/example.com/cc/main.go", "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/util\"\n\t\"example.com/vendored\"\n\t\"github.com/hyperledger/fabric/core/chaincode/shim\"\n)\n")
+	packagertest.WriteFile(t, goPath, "src/example.com/cc/vendor/example.com/vendored/vendored.go", "package vendored\n")
+	packagertest.WriteFile(t, goPath, "src/example.com/cc/testdata/test.go", "package test\n\nimport \"example.com/missing\"\n")
+	packagertest.WriteFile(t, goPath, "src/example.com/util/util.go", "package util\n\nimport \"example.com/util/internal\"\n")
+	packagertest.WriteFile(t, goPath, "src/example.com/util/util_test.go", "package util\n\nimport \"example.com/missing\"\n")
+	packagertest.WriteFile(t, goPath, "src/example.com/util/internal/internal.go", "package internal\n")
+	packagertest.WriteFile(t, goPath, "src/example.com/other/other.go", "package other\n")
+
+	ccPackage, err := NewCCPackage("example.com/cc", goPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"src/example.com/cc/main.go",
+		"src/example.com/cc/testdata/test.go",
+		"src/example.com/cc/vendor/example.com/vendored/vendored.go",
+		"src/example.com/util/util.go",
+		"src/example.com/util/util_test.go",
+		"src/example.com/util/internal/internal.go",
+	}, packagertest.PackagedFiles(t, ccPackage.Code))
+
+	packagertest.WriteFile(t, goPath, "src/example.com/cc/lib/lib.go", "package lib\n\nimport \"example.com/missing\"\n")
+	_, err = NewCCPackage("example.com/cc", goPath)
+	assert.Error(t, err, "expecting error for unresolved import")
+}
@@ -7,32 +7,25 @@ SPDX-License-Identifier: Apache-2.0
 package gopackager
 
 import (
-	"archive/tar"
-	"bytes"
-	"compress/gzip"
 	"go/build"
-	"io"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
-	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/internal/tarball"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/metadata"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
 	"github.com/pkg/errors"
 
-	"fmt"
-
 	"strings"
 
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
-// Descriptor ...
-type Descriptor struct {
-	name string
-	fqp  string
-}
+// Descriptor describes a file of the chaincode package
+type Descriptor = tarball.Descriptor
 
 // A list of file extensions that should be packaged into the .tar.gz.
 // Files with all other file extenstions will be excluded to minimize the size
@@ -70,7 +63,16 @@ func NewCCPackage(chaincodePath string, goPath string) (*resource.CCPackage, err
 	if err != nil {
 		return nil, err
 	}
-	tarBytes, err := generateTarGz(descriptors)
+	deps, err := findGoPathDependencies(gp, projDir)
+	if err != nil {
+		return nil, err
+	}
+	descriptors = append(descriptors, deps...)
+
+	if err := metadata.ValidateFiles(tarball.Files(descriptors)); err != nil {
+		return nil, err
+	}
+	tarBytes, err := tarball.GenerateTarGz(descriptors)
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +105,7 @@ func findSource(goPath string, filePath string) ([]*Descriptor, error) {
 				if strings.Contains(relPath, "/META-INF/") {
 					relPath = relPath[strings.Index(relPath, "/META-INF/")+1:]
 				}
-				descriptors = append(descriptors, &Descriptor{name: relPath, fqp: path})
+				descriptors = append(descriptors, &Descriptor{Name: relPath, Path: path})
 			}
 			return nil
 
@@ -112,103 +114,125 @@ func findSource(goPath string, filePath string) ([]*Descriptor, error) {
 	return descriptors, err
 }
 
-// -------------------------------------------------------------------------
-// isSource(path)
-// -------------------------------------------------------------------------
-// predicate function for determining whether a given path should be
-// considered valid source code, based entirely on the extension.  It is
-// assumed that other checks for file type have already been performed.
-// -------------------------------------------------------------------------
-func isSource(filePath string) bool {
-	var extension = filepath.Ext(filePath)
-	for _, v := range keep {
-		if v == extension {
-			return true
-		}
+// findGoPathDependencies returns the descriptors of the packages outside of the project directory that are
+// imported by the chaincode, such as sibling packages of the chaincode in the GOPATH. Imports are resolved
+// from the vendor directories of the project and from the GOPATH.
+func findGoPathDependencies(goPath string, projDir string) ([]*Descriptor, error) {
+	pkgDirs, err := findPackageDirs(projDir)
+	if err != nil {
+		return nil, err
 	}
-	return false
-}
 
-// -------------------------------------------------------------------------
-// generateTarGz(descriptors)
-// -------------------------------------------------------------------------
-// creates an .tar.gz stream from the provided descriptor entries
-// -------------------------------------------------------------------------
-func generateTarGz(descriptors []*Descriptor) ([]byte, error) {
-	// set up the gzip writer
-	var codePackage bytes.Buffer
-	gw := gzip.NewWriter(&codePackage)
-	tw := tar.NewWriter(gw)
-	for _, v := range descriptors {
-		logger.Debugf("generateTarGz for %s", v.fqp)
-		err := packEntry(tw, gw, v)
+	dirs, err := findDependencies(pkgDirs, goPathResolver(goPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var descriptors []*Descriptor
+	for _, dir := range dirs {
+		if isSubDir(projDir, dir) {
+			// Already packaged
+			continue
+		}
+		logger.Debugf("packaging imported package in %s", dir)
+		pkgDescriptors, err := findPackageSource(goPath, dir, "")
 		if err != nil {
-			err1 := closeStream(tw, gw)
-			if err1 != nil {
-				return nil, errors.Wrap(err, fmt.Sprintf("packEntry failed and close error %v", err1))
-			}
-			return nil, errors.Wrap(err, "packEntry failed")
+			return nil, err
 		}
+		descriptors = append(descriptors, pkgDescriptors...)
 	}
-	err := closeStream(tw, gw)
-	if err != nil {
-		return nil, errors.Wrap(err, "closeStream failed")
-	}
-	return codePackage.Bytes(), nil
 
+	return descriptors, nil
 }
 
-func closeStream(tw io.Closer, gw io.Closer) error {
-	err := tw.Close()
-	if err != nil {
-		return err
+// goPathResolver resolves imports in the same way as the go tool in GOPATH mode
+func goPathResolver(goPath string) resolveFunc {
+	srcDir := filepath.Join(goPath, "src")
+	return func(importPath string, importerDir string) string {
+		if dir := vendorDir(importPath, importerDir, srcDir); dir != "" {
+			return dir
+		}
+		if isPlatformImportPath(importPath) {
+			return ""
+		}
+		if dir := filepath.Join(srcDir, filepath.FromSlash(importPath)); hasGoFiles(dir) {
+			return dir
+		}
+		return ""
 	}
-	err = gw.Close()
-	return err
 }
 
-func packEntry(tw *tar.Writer, gw *gzip.Writer, descriptor *Descriptor) error {
-	file, err := os.Open(descriptor.fqp)
+// findPackageDirs returns the directories of the Go packages in the given directory. Vendored packages are
+// not included (they are only analyzed when they are imported), nor are the directories ignored by the go tool.
+func findPackageDirs(rootDir string) ([]string, error) {
+	var dirs []string
+	err := filepath.Walk(rootDir,
+		func(path string, fileInfo os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !fileInfo.IsDir() {
+				return nil
+			}
+			name := fileInfo.Name()
+			if path != rootDir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			if hasGoFiles(path) {
+				dirs = append(dirs, path)
+			}
+			return nil
+		})
+
+	return dirs, err
+}
+
+// findPackageSource returns the descriptors of the source files of the package in the given directory (sub
+// directories are not included). The names of the files are relative to the root directory, prefixed with
+// the given prefix.
+func findPackageSource(rootDir string, dir string, prefix string) ([]*Descriptor, error) {
+	fileInfos, err := ioutil.ReadDir(dir)
 	if err != nil {
-		return err
+		return nil, errors.Wrapf(err, "reading package directory %s failed", dir)
 	}
-	defer func() {
-		err := file.Close()
-		if err != nil {
-			logger.Warnf("error file close %v", err)
+
+	var descriptors []*Descriptor
+	for _, fileInfo := range fileInfos {
+		filePath := filepath.Join(dir, fileInfo.Name())
+		if !fileInfo.Mode().IsRegular() || !isSource(filePath) {
+			continue
 		}
-	}()
-
-	if stat, err := file.Stat(); err == nil {
-
-		// now lets create the header as needed for this file within the tarball
-		header := new(tar.Header)
-		header.Name = descriptor.name
-		header.Size = stat.Size()
-		header.Mode = int64(stat.Mode())
-		// Use a deterministic "zero-time" for all date fields
-		header.ModTime = time.Time{}
-		header.AccessTime = time.Time{}
-		header.ChangeTime = time.Time{}
-		// write the header to the tarball archive
-		if err := tw.WriteHeader(header); err != nil {
-			return err
+		relPath, err := filepath.Rel(rootDir, filePath)
+		if err != nil {
+			return nil, err
 		}
+		descriptors = append(descriptors, &Descriptor{Name: path.Join(prefix, filepath.ToSlash(relPath)), Path: filePath})
+	}
 
-		// copy the file data to the tarball
+	return descriptors, nil
+}
 
-		if _, err := io.Copy(tw, file); err != nil {
-			return err
-		}
-		if err := tw.Flush(); err != nil {
-			return err
-		}
-		if err := gw.Flush(); err != nil {
-			return err
-		}
+// isSubDir returns true if dir is the given parent directory or one of its sub directories
+func isSubDir(parentDir string, dir string) bool {
+	rel, err := filepath.Rel(parentDir, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
 
+// -------------------------------------------------------------------------
+// isSource(path)
+// -------------------------------------------------------------------------
+// predicate function for determining whether a given path should be
+// considered valid source code, based entirely on the extension.  It is
+// assumed that other checks for file type have already been performed.
+// -------------------------------------------------------------------------
+func isSource(filePath string) bool {
+	var extension = filepath.Ext(filePath)
+	for _, v := range keep {
+		if v == extension {
+			return true
+		}
 	}
-	return nil
+	return false
 }
 
 // defaultGoPath returns the system's default GOPATH. If the system
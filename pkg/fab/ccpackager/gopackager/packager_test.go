@@ -85,28 +85,13 @@ func TestBadPackagePathGoLangCC(t *testing.T) {
 
 // Test isSource set to true for any go readable files used in ChainCode packaging
 func TestIsSourcePath(t *testing.T) {
+	// reset keep
+	defer func(k []string) { keep = k }(keep)
+
 	keep = []string{}
 	isSrcVal := isSource("../")
 
 	if isSrcVal {
 		t.Fatalf("error expected when calling isSource %v", isSrcVal)
 	}
-
-	// reset keep
-	keep = []string{".go", ".c", ".h"}
-}
-
-// Test packEntry and generateTarGz with empty file Descriptor
-func TestEmptyPackEntry(t *testing.T) {
-	emptyDescriptor := &Descriptor{"NewFile", ""}
-	err := packEntry(nil, nil, emptyDescriptor)
-	if err == nil {
-		t.Fatal("packEntry call with empty descriptor info must throw an error")
-	}
-
-	_, err = generateTarGz([]*Descriptor{emptyDescriptor})
-	if err == nil {
-		t.Fatal("generateTarGz call with empty descriptor info must throw an error")
-	}
-
 }
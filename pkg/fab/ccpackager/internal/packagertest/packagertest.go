@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package packagertest provides the test helpers of the chaincode packagers.
+package packagertest
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// PackagedFiles returns the names of the files in the given .tar.gz code package
+func PackagedFiles(t *testing.T, code []byte) []string {
+	gzf, err := gzip.NewReader(bytes.NewReader(code))
+	require.NoError(t, err)
+
+	var files []string
+	tarReader := tar.NewReader(gzf)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		files = append(files, header.Name)
+	}
+	return files
+}
+
+// WriteFile writes a file with the given name (a slash-separated path relative to dir) and content, creating its
+// parent directories
+func WriteFile(t *testing.T, dir, name, content string) {
+	fileName := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0755))
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(content), 0644))
+}
@@ -0,0 +1,118 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tarball creates the .tar.gz code packages of the chaincode packagers.
+package tarball
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/fab")
+
+// Descriptor describes a file of a chaincode package
+type Descriptor struct {
+	// Name is the name of the file in the package
+	Name string
+	// Path is the path of the file in the file system
+	Path string
+}
+
+// Files maps the names of the descriptors to the paths of their files
+func Files(descriptors []*Descriptor) map[string]string {
+	files := make(map[string]string, len(descriptors))
+	for _, d := range descriptors {
+		files[d.Name] = d.Path
+	}
+	return files
+}
+
+// GenerateTarGz creates an .tar.gz stream from the provided descriptor entries
+func GenerateTarGz(descriptors []*Descriptor) ([]byte, error) {
+	// set up the gzip writer
+	var codePackage bytes.Buffer
+	gw := gzip.NewWriter(&codePackage)
+	tw := tar.NewWriter(gw)
+	for _, v := range descriptors {
+		logger.Debugf("generateTarGz for %s", v.Path)
+		err := packEntry(tw, gw, v)
+		if err != nil {
+			err1 := closeStream(tw, gw)
+			if err1 != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("packEntry failed and close error %v", err1))
+			}
+			return nil, errors.Wrap(err, "packEntry failed")
+		}
+	}
+	err := closeStream(tw, gw)
+	if err != nil {
+		return nil, errors.Wrap(err, "closeStream failed")
+	}
+	return codePackage.Bytes(), nil
+
+}
+
+func closeStream(tw io.Closer, gw io.Closer) error {
+	err := tw.Close()
+	if err != nil {
+		return err
+	}
+	err = gw.Close()
+	return err
+}
+
+func packEntry(tw *tar.Writer, gw *gzip.Writer, descriptor *Descriptor) error {
+	file, err := os.Open(descriptor.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := file.Close()
+		if err != nil {
+			logger.Warnf("error file close %v", err)
+		}
+	}()
+
+	if stat, err := file.Stat(); err == nil {
+
+		// now lets create the header as needed for this file within the tarball
+		header := new(tar.Header)
+		header.Name = descriptor.Name
+		header.Size = stat.Size()
+		header.Mode = int64(stat.Mode())
+		// Use a deterministic "zero-time" for all date fields
+		header.ModTime = time.Time{}
+		header.AccessTime = time.Time{}
+		header.ChangeTime = time.Time{}
+		// write the header to the tarball archive
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		// copy the file data to the tarball
+
+		if _, err := io.Copy(tw, file); err != nil {
+			return err
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if err := gw.Flush(); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
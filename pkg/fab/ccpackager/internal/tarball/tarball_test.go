@@ -0,0 +1,26 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tarball
+
+import (
+	"testing"
+)
+
+// Test packEntry and GenerateTarGz with empty file Descriptor
+func TestEmptyPackEntry(t *testing.T) {
+	emptyDescriptor := &Descriptor{"NewFile", ""}
+	err := packEntry(nil, nil, emptyDescriptor)
+	if err == nil {
+		t.Fatal("packEntry call with empty descriptor info must throw an error")
+	}
+
+	_, err = GenerateTarGz([]*Descriptor{emptyDescriptor})
+	if err == nil {
+		t.Fatal("GenerateTarGz call with empty descriptor info must throw an error")
+	}
+
+}
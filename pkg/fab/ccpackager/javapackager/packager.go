@@ -0,0 +1,142 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package javapackager
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/internal/tarball"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/metadata"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	// sourceDir is the directory of the package that contains the chaincode project
+	sourceDir = "src"
+)
+
+// buildFiles are the build files of the supported build tools (Gradle and Maven). The peer builds
+// the chaincode using the first build file found in the project.
+var buildFiles = []string{"build.gradle", "build.gradle.kts", "pom.xml"}
+
+// excludedDirs are the build output directories of the chaincode project, which are not packaged
+// since the chaincode is built by the peer
+var excludedDirs = []string{"target", "build", "out", ".gradle"}
+
+// excludedFileTypes are the file extensions of compiled classes, which are not packaged
+var excludedFileTypes = []string{".class"}
+
+var logger = logging.NewLogger("fabsdk/fab")
+
+// NewCCPackage creates a new Java chaincode package from the Gradle or Maven project in the given directory.
+// The project files are packaged in the src directory and the files of the project's META-INF directory
+// (for example, CouchDB indexes) in the META-INF directory of the package. Build output directories
+// (target, build, out and .gradle) and compiled classes are excluded.
+func NewCCPackage(chaincodePath string) (*resource.CCPackage, error) {
+
+	if chaincodePath == "" {
+		return nil, errors.New("chaincode path must be provided")
+	}
+
+	projDir, err := filepath.Abs(chaincodePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid chaincode path")
+	}
+	fileInfo, err := os.Stat(projDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid chaincode path")
+	}
+	if !fileInfo.IsDir() {
+		return nil, errors.Errorf("chaincode path %s is not a directory", chaincodePath)
+	}
+	if !hasBuildFile(projDir) {
+		return nil, errors.Errorf("chaincode path %s doesn't contain a Gradle or Maven build file", chaincodePath)
+	}
+
+	logger.Debugf("projDir variable=%s", projDir)
+
+	descriptors, err := findSource(projDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := metadata.ValidateFiles(tarball.Files(descriptors)); err != nil {
+		return nil, err
+	}
+	tarBytes, err := tarball.GenerateTarGz(descriptors)
+	if err != nil {
+		return nil, err
+	}
+
+	ccPkg := &resource.CCPackage{Type: pb.ChaincodeSpec_JAVA, Code: tarBytes}
+
+	return ccPkg, nil
+}
+
+func hasBuildFile(projDir string) bool {
+	for _, name := range buildFiles {
+		if fileInfo, err := os.Stat(filepath.Join(projDir, name)); err == nil && fileInfo.Mode().IsRegular() {
+			return true
+		}
+	}
+	return false
+}
+
+// findSource returns the descriptors of the files of the chaincode project that are packaged
+func findSource(projDir string) ([]*tarball.Descriptor, error) {
+	var descriptors []*tarball.Descriptor
+	err := filepath.Walk(projDir,
+		func(filePath string, fileInfo os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(projDir, filePath)
+			if err != nil {
+				return err
+			}
+			if relPath == "." {
+				return nil
+			}
+			relPath = filepath.ToSlash(relPath)
+
+			if fileInfo.IsDir() {
+				if contains(excludedDirs, fileInfo.Name()) {
+					logger.Debugf("excluding %s from chaincode package", relPath)
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if !fileInfo.Mode().IsRegular() || contains(excludedFileTypes, filepath.Ext(filePath)) {
+				return nil
+			}
+
+			name := path.Join(sourceDir, relPath)
+			if strings.HasPrefix(relPath, metadata.Dir+"/") {
+				name = relPath
+			}
+			descriptors = append(descriptors, &tarball.Descriptor{Name: name, Path: filePath})
+			return nil
+		})
+
+	return descriptors, err
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package javapackager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/internal/packagertest"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleCCPath = "../../../../test/fixtures/testdata/java/example_cc"
+
+// Test Java chaincode packaging
+func TestNewCCPackage(t *testing.T) {
+	ccPackage, err := NewCCPackage(exampleCCPath)
+	require.NoError(t, err)
+	assert.Equal(t, pb.ChaincodeSpec_JAVA, ccPackage.Type)
+
+	files := packagertest.PackagedFiles(t, ccPackage.Code)
+	assert.Equal(t, []string{
+		"META-INF/statedb/couchdb/indexes/indexOwner.json",
+		"src/build.gradle",
+		"src/settings.gradle",
+		"src/src/main/java/org/hyperledger/fabric/example/SimpleChaincode.java",
+	}, files)
+}
+
+func TestNewCCPackageExclusions(t *testing.T) {
+	projDir, err := ioutil.TempDir("", "javacc")
+	require.NoError(t, err)
+	defer os.RemoveAll(projDir)
+
+	packagertest.WriteFile(t, projDir, "pom.xml", "<project/>")
+	packagertest.WriteFile(t, projDir, "src/main/java/Chaincode.java", "")
+	packagertest.WriteFile(t, projDir, "src/main/java/Chaincode.class", "")
+	packagertest.WriteFile(t, projDir, "target/chaincode.jar", "")
+	packagertest.WriteFile(t, projDir, "build/libs/chaincode.jar", "")
+	packagertest.WriteFile(t, projDir, "out/production/Chaincode.class", "")
+	packagertest.WriteFile(t, projDir, ".gradle/4.8/fileHashes.bin", "")
+	packagertest.WriteFile(t, projDir, "META-INF/statedb/couchdb/indexes/index.json", `{"index":{"fields":["owner"]}}`)
+
+	ccPackage, err := NewCCPackage(projDir)
+	require.NoError(t, err)
+
+	files := packagertest.PackagedFiles(t, ccPackage.Code)
+	assert.Equal(t, []string{
+		"META-INF/statedb/couchdb/indexes/index.json",
+		"src/pom.xml",
+		"src/src/main/java/Chaincode.java",
+	}, files)
+}
+
+func TestNewCCPackageError(t *testing.T) {
+	_, err := NewCCPackage("")
+	assert.Error(t, err, "expecting error for missing chaincode path")
+
+	_, err = NewCCPackage("../../../../test/fixturesABC")
+	assert.Error(t, err, "expecting error for invalid chaincode path")
+
+	_, err = NewCCPackage(filepath.Join(exampleCCPath, "build.gradle"))
+	assert.Error(t, err, "expecting error since chaincode path isn't a directory")
+
+	_, err = NewCCPackage(filepath.Join(exampleCCPath, "src"))
+	assert.Error(t, err, "expecting error since chaincode path doesn't contain a build file")
+
+	projDir, err := ioutil.TempDir("", "javacc")
+	require.NoError(t, err)
+	defer os.RemoveAll(projDir)
+
+	packagertest.WriteFile(t, projDir, "build.gradle", "")
+	packagertest.WriteFile(t, projDir, "META-INF/statedb/couchdb/collections/collection1/indexes/index.json", `{"index":{"fields":["owner"]},"type":"text"}`)
+	_, err = NewCCPackage(projDir)
+	assert.Error(t, err, "expecting error for invalid CouchDB index")
+}
@@ -0,0 +1,206 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package lifecycle creates chaincode packages in the format of the Fabric 2.x chaincode lifecycle: a .tar.gz file
+// that contains the chaincode's metadata (metadata.json) and its code package (code.tar.gz).
+//
+// Packages are installed on peers with the lifecycle operations of the resource management client (see
+// resmgmt.Client.InstallLifecycleCC).
+package lifecycle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	// metadataFile is the file of the package that contains the chaincode's metadata
+	metadataFile = "metadata.json"
+	// codePackageFile is the file of the package that contains the chaincode's code package
+	codePackageFile = "code.tar.gz"
+)
+
+// labelRegexp is the format of package labels accepted by the peer
+var labelRegexp = regexp.MustCompile(`^[[:alnum:]][[:alnum:]_.+-]*$`)
+
+// supportedTypes are the chaincode types supported by the Fabric 2.x lifecycle
+var supportedTypes = []pb.ChaincodeSpec_Type{pb.ChaincodeSpec_GOLANG, pb.ChaincodeSpec_NODE, pb.ChaincodeSpec_JAVA}
+
+// Metadata is the metadata of a lifecycle chaincode package (metadata.json)
+type Metadata struct {
+	Path  string `json:"path"`
+	Type  string `json:"type"`
+	Label string `json:"label"`
+}
+
+// NewCCPackage creates a lifecycle chaincode package from a chaincode code package, as created by the Go,
+// Node or Java chaincode packagers.
+//  Parameters:
+//  label is the label of the package, which identifies the chaincode when its definition is approved (for example, mycc_1)
+//  chaincodePath is the path of the chaincode (for Go chaincode, its import path)
+//  ccPackage is the code package of the chaincode
+//
+//  Returns:
+//  the bytes of the lifecycle chaincode package (.tar.gz)
+func NewCCPackage(label string, chaincodePath string, ccPackage *resource.CCPackage) ([]byte, error) {
+	if err := validatePackageArgs(label, chaincodePath, ccPackage); err != nil {
+		return nil, err
+	}
+
+	metadata, err := json.Marshal(&Metadata{Path: chaincodePath, Type: strings.ToLower(ccPackage.Type.String()), Label: label})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of package metadata failed")
+	}
+
+	var pkg bytes.Buffer
+	gw := gzip.NewWriter(&pkg)
+	tw := tar.NewWriter(gw)
+
+	if err := writeEntry(tw, metadataFile, metadata); err != nil {
+		return nil, err
+	}
+	if err := writeEntry(tw, codePackageFile, ccPackage.Code); err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "closing package archive failed")
+	}
+	if err := gw.Close(); err != nil {
+		return nil, errors.Wrap(err, "closing package archive failed")
+	}
+	return pkg.Bytes(), nil
+}
+
+func validatePackageArgs(label string, chaincodePath string, ccPackage *resource.CCPackage) error {
+	if !labelRegexp.MatchString(label) {
+		return errors.Errorf("invalid label '%s': the label must start with a letter or number and may only contain letters, numbers, '_', '.', '+' and '-'", label)
+	}
+	if chaincodePath == "" {
+		return errors.New("chaincode path must be provided")
+	}
+	if ccPackage == nil || len(ccPackage.Code) == 0 {
+		return errors.New("chaincode package must be provided")
+	}
+	if !isSupportedType(ccPackage.Type) {
+		return errors.Errorf("chaincode type %s isn't supported by the chaincode lifecycle", ccPackage.Type)
+	}
+	return nil
+}
+
+// ReadCCPackage reads the metadata and the code package of a lifecycle chaincode package
+//  Parameters:
+//  pkgBytes are the bytes of the lifecycle chaincode package (.tar.gz)
+//
+//  Returns:
+//  the metadata and the code package of the chaincode
+func ReadCCPackage(pkgBytes []byte) (*Metadata, *resource.CCPackage, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(pkgBytes))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid chaincode package")
+	}
+
+	metadata, code, err := readEntries(tar.NewReader(gr))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if metadata == nil || code == nil {
+		return nil, nil, errors.New("chaincode package must contain metadata.json and code.tar.gz")
+	}
+
+	ccType, ok := pb.ChaincodeSpec_Type_value[strings.ToUpper(metadata.Type)]
+	if !ok || !isSupportedType(pb.ChaincodeSpec_Type(ccType)) {
+		return nil, nil, errors.Errorf("chaincode type %s isn't supported by the chaincode lifecycle", metadata.Type)
+	}
+
+	return metadata, &resource.CCPackage{Type: pb.ChaincodeSpec_Type(ccType), Code: code}, nil
+}
+
+// readEntries reads the metadata and the code package from the package archive. They are nil if missing from the archive.
+func readEntries(tr *tar.Reader) (*Metadata, []byte, error) {
+	var metadata *Metadata
+	var code []byte
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return metadata, code, nil
+		}
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid chaincode package")
+		}
+
+		switch header.Name {
+		case metadataFile:
+			metadata = &Metadata{}
+			if err := json.NewDecoder(tr).Decode(metadata); err != nil {
+				return nil, nil, errors.Wrap(err, "unmarshal of package metadata failed")
+			}
+		case codePackageFile:
+			if code, err = ioutil.ReadAll(tr); err != nil {
+				return nil, nil, errors.Wrap(err, "reading code package failed")
+			}
+		default:
+			return nil, nil, errors.Errorf("unexpected file in chaincode package: %s", header.Name)
+		}
+	}
+}
+
+// ComputePackageID returns the ID that the peer assigns to an installed lifecycle chaincode package,
+// which is the label of the package followed by the hex encoded SHA256 hash of the package.
+//  Parameters:
+//  label is the label of the package
+//  pkgBytes are the bytes of the lifecycle chaincode package (.tar.gz)
+//
+//  Returns:
+//  the package ID
+func ComputePackageID(label string, pkgBytes []byte) string {
+	hash := sha256.Sum256(pkgBytes)
+	return fmt.Sprintf("%s:%s", label, hex.EncodeToString(hash[:]))
+}
+
+func writeEntry(tw *tar.Writer, name string, contents []byte) error {
+	header := &tar.Header{
+		Name: name,
+		Size: int64(len(contents)),
+		Mode: 0100644,
+		// Use a deterministic "zero-time" for all date fields
+		ModTime:    time.Time{},
+		AccessTime: time.Time{},
+		ChangeTime: time.Time{},
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return errors.Wrapf(err, "writing header of %s failed", name)
+	}
+	if _, err := tw.Write(contents); err != nil {
+		return errors.Wrapf(err, "writing %s failed", name)
+	}
+	return nil
+}
+
+func isSupportedType(ccType pb.ChaincodeSpec_Type) bool {
+	for _, t := range supportedTypes {
+		if t == ccType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/gopackager"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCCPackage(t *testing.T) {
+	pwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	ccPackage, err := gopackager.NewCCPackage("github.com/example_cc", path.Join(pwd, "../../../../test/fixtures/testdata"))
+	require.NoError(t, err)
+
+	pkgBytes, err := NewCCPackage("example_cc_1.0", "github.com/example_cc", ccPackage)
+	require.NoError(t, err)
+
+	metadata, code, err := ReadCCPackage(pkgBytes)
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{Path: "github.com/example_cc", Type: "golang", Label: "example_cc_1.0"}, metadata)
+	assert.Equal(t, ccPackage, code)
+
+	// The package is deterministic, so that every org computes the same package ID
+	pkgBytes2, err := NewCCPackage("example_cc_1.0", "github.com/example_cc", ccPackage)
+	require.NoError(t, err)
+	assert.Equal(t, pkgBytes, pkgBytes2)
+
+	hash := sha256.Sum256(pkgBytes)
+	assert.Equal(t, "example_cc_1.0:"+hex.EncodeToString(hash[:]), ComputePackageID("example_cc_1.0", pkgBytes))
+}
+
+func TestNewCCPackageError(t *testing.T) {
+	ccPackage := &resource.CCPackage{Type: pb.ChaincodeSpec_NODE, Code: []byte("code")}
+
+	_, err := NewCCPackage("", "path", ccPackage)
+	assert.Error(t, err, "expecting error for missing label")
+
+	_, err = NewCCPackage("-mycc", "path", ccPackage)
+	assert.Error(t, err, "expecting error for invalid label")
+
+	_, err = NewCCPackage("mycc:1", "path", ccPackage)
+	assert.Error(t, err, "expecting error for invalid label")
+
+	_, err = NewCCPackage("mycc", "", ccPackage)
+	assert.EqualError(t, err, "chaincode path must be provided")
+
+	_, err = NewCCPackage("mycc", "path", nil)
+	assert.EqualError(t, err, "chaincode package must be provided")
+
+	_, err = NewCCPackage("mycc", "path", &resource.CCPackage{Type: pb.ChaincodeSpec_CAR, Code: []byte("code")})
+	assert.EqualError(t, err, "chaincode type CAR isn't supported by the chaincode lifecycle")
+}
+
+func TestReadCCPackageError(t *testing.T) {
+	_, _, err := ReadCCPackage([]byte("invalid"))
+	assert.Error(t, err, "expecting error for invalid package")
+
+	_, _, err = ReadCCPackage(newArchive(t, map[string]string{metadataFile: `{"path":"path","type":"node","label":"mycc"}`}))
+	assert.EqualError(t, err, "chaincode package must contain metadata.json and code.tar.gz")
+
+	_, _, err = ReadCCPackage(newArchive(t, map[string]string{metadataFile: `{"path":"path","type":"car","label":"mycc"}`, codePackageFile: "code"}))
+	assert.EqualError(t, err, "chaincode type car isn't supported by the chaincode lifecycle")
+
+	_, _, err = ReadCCPackage(newArchive(t, map[string]string{"other.txt": "other"}))
+	assert.EqualError(t, err, "unexpected file in chaincode package: other.txt")
+}
+
+func newArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, contents := range files {
+		require.NoError(t, writeEntry(tw, name, []byte(contents)))
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
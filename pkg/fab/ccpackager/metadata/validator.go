@@ -0,0 +1,213 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package metadata validates the state database metadata files (the files in the META-INF/statedb directory) of
+// chaincode packages in the same way as the peer does when the chaincode is installed or instantiated. Other files
+// in the META-INF directory are packaged as they are, without validation.
+package metadata
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Dir is the directory of a chaincode package that contains the chaincode metadata
+	Dir = "META-INF"
+
+	// statedbDir is the directory of the metadata for the state database
+	statedbDir = Dir + "/statedb/"
+)
+
+// indexDirs matches the directories of CouchDB index definitions: the indexes of the chaincode's state and the
+// indexes of the chaincode's private data collections
+var indexDirs = []*regexp.Regexp{
+	regexp.MustCompile(`^META-INF/statedb/couchdb/indexes$`),
+	regexp.MustCompile(`^META-INF/statedb/couchdb/collections/[a-zA-Z0-9_-]+/indexes$`),
+}
+
+// Validate validates the metadata file with the given name (the path of the file in the chaincode package, for
+// example META-INF/statedb/couchdb/indexes/indexOwner.json). Files in the state database metadata directory must be
+// CouchDB index definitions in one of the index directories; other files aren't validated.
+//  Parameters:
+//  name is the path of the file in the chaincode package
+//  content is the content of the file
+//
+//  Returns:
+//  an error if the file isn't valid
+func Validate(name string, content []byte) error {
+	if !strings.HasPrefix(name, statedbDir) {
+		return nil
+	}
+
+	dir := path.Dir(name)
+	if !isIndexDir(dir) {
+		return errors.Errorf("metadata file [%s] must be in directory META-INF/statedb/couchdb/indexes or META-INF/statedb/couchdb/collections/<collection_name>/indexes", name)
+	}
+
+	if path.Ext(name) != ".json" {
+		return errors.Errorf("index metadata file [%s] does not have a .json extension", name)
+	}
+
+	if err := validateIndex(content); err != nil {
+		return errors.WithMessage(err, "index metadata file ["+name+"] is not a valid CouchDB index definition")
+	}
+
+	return nil
+}
+
+// ValidateFile validates the metadata file with the given name (see Validate), reading its content from the
+// given file if the file needs to be validated
+func ValidateFile(name string, filePath string) error {
+	if !strings.HasPrefix(name, statedbDir) {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "reading metadata file [%s] failed", name)
+	}
+
+	return Validate(name, content)
+}
+
+// ValidateFiles validates the metadata files among the files of a chaincode package (see ValidateFile). The files
+// are validated in the order of their names.
+//  Parameters:
+//  files maps the path of each file in the chaincode package to the path of the file on disk
+//
+//  Returns:
+//  an error if one of the metadata files isn't valid
+func ValidateFiles(files map[string]string) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := ValidateFile(name, files[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isIndexDir(dir string) bool {
+	for _, r := range indexDirs {
+		if r.MatchString(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateIndex validates a CouchDB index definition, for example:
+//  {"index":{"fields":["owner",{"size":"desc"}]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}
+func validateIndex(content []byte) error {
+	var definition map[string]interface{}
+	if err := json.Unmarshal(content, &definition); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	index, ok := definition["index"]
+	if !ok {
+		return errors.New("index definition must include an index")
+	}
+
+	if err := validateIndexFields(index); err != nil {
+		return err
+	}
+
+	for key, value := range definition {
+		if err := validateIndexEntry(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateIndexEntry validates an entry of an index definition other than the index itself
+func validateIndexEntry(key string, value interface{}) error {
+	switch key {
+	case "index":
+		return nil
+	case "ddoc", "name":
+		if s, ok := value.(string); !ok || s == "" {
+			return errors.Errorf("%s must be a non-empty string", key)
+		}
+		return nil
+	case "type":
+		if value != "json" {
+			return errors.Errorf("unsupported index type: %v", value)
+		}
+		return nil
+	default:
+		return errors.Errorf("invalid entry: %s", key)
+	}
+}
+
+// validateIndexFields validates the index object, which must contain the fields of the index
+func validateIndexFields(index interface{}) error {
+	indexMap, ok := index.(map[string]interface{})
+	if !ok {
+		return errors.New("index must be an object")
+	}
+
+	fields, ok := indexMap["fields"]
+	if !ok {
+		return errors.New("index must include fields")
+	}
+	for key := range indexMap {
+		if key != "fields" && key != "partial_filter_selector" {
+			return errors.Errorf("invalid index entry: %s", key)
+		}
+	}
+
+	fieldList, ok := fields.([]interface{})
+	if !ok || len(fieldList) == 0 {
+		return errors.New("index fields must be a non-empty array")
+	}
+
+	for _, field := range fieldList {
+		if err := validateIndexField(field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateIndexField validates a field of an index, which is either the name of the field or a sorted field, for
+// example {"size": "desc"}
+func validateIndexField(field interface{}) error {
+	switch f := field.(type) {
+	case string:
+		if f == "" {
+			return errors.New("index field must not be empty")
+		}
+		return nil
+	case map[string]interface{}:
+		if len(f) != 1 {
+			return errors.Errorf("sorted index field must have a single entry: %v", f)
+		}
+		for name, order := range f {
+			if name == "" || (order != "asc" && order != "desc") {
+				return errors.Errorf("invalid sorted index field: %v", f)
+			}
+		}
+		return nil
+	default:
+		return errors.Errorf("invalid index field: %v", field)
+	}
+}
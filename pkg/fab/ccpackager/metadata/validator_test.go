@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metadata
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validIndex = `{"index":{"fields":["docType","owner",{"size":"desc"}]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("META-INF/statedb/couchdb/indexes/indexOwner.json", []byte(validIndex)))
+	assert.NoError(t, Validate("META-INF/statedb/couchdb/collections/collectionMarbles/indexes/indexOwner.json", []byte(validIndex)))
+	assert.NoError(t, Validate("META-INF/statedb/couchdb/indexes/indexSize.json", []byte(`{"index":{"fields":["size"]}}`)))
+
+	// Other metadata files aren't validated
+	assert.NoError(t, Validate("META-INF/sample-json/event.json", []byte("not JSON")))
+	assert.NoError(t, Validate("src/github.com/example_cc/statedb/couchdb/indexes/index.json", []byte("not JSON")))
+}
+
+func TestValidateInvalidPath(t *testing.T) {
+	invalidPaths := []string{
+		"META-INF/statedb/couchdb/index.json",
+		"META-INF/statedb/couchdb/indexes/sub/index.json",
+		"META-INF/statedb/couchdb/collections/indexes/index.json",
+		"META-INF/statedb/couchdb/collections/collection$/indexes/index.json",
+		"META-INF/statedb/leveldb/indexes/index.json",
+	}
+	for _, name := range invalidPaths {
+		assert.Error(t, Validate(name, []byte(validIndex)), "expecting error for invalid path %s", name)
+	}
+
+	err := Validate("META-INF/statedb/couchdb/indexes/index.yaml", []byte(validIndex))
+	assert.Error(t, err, "expecting error for non-JSON index file")
+}
+
+func TestValidateInvalidIndex(t *testing.T) {
+	invalidIndexes := []string{
+		`{"index":{"fields":["owner"]}`,
+		`[]`,
+		`{"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`,
+		`{"index":"owner"}`,
+		`{"index":{}}`,
+		`{"index":{"fields":[]}}`,
+		`{"index":{"fields":"owner"}}`,
+		`{"index":{"fields":[""]}}`,
+		`{"index":{"fields":[1]}}`,
+		`{"index":{"fields":[{"size":"up"}]}}`,
+		`{"index":{"fields":[{"size":"asc","owner":"asc"}]}}`,
+		`{"index":{"fields":["owner"],"unknown":true}}`,
+		`{"index":{"fields":["owner"]},"ddoc":1}`,
+		`{"index":{"fields":["owner"]},"name":""}`,
+		`{"index":{"fields":["owner"]},"type":"text"}`,
+		`{"index":{"fields":["owner"]},"unknown":"value"}`,
+	}
+	for _, index := range invalidIndexes {
+		err := Validate("META-INF/statedb/couchdb/indexes/index.json", []byte(index))
+		assert.Error(t, err, "expecting error for invalid index %s", index)
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metadata")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "index.json")
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(`{"index":{}}`), 0644))
+
+	err = ValidateFile("META-INF/statedb/couchdb/indexes/index.json", fileName)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "META-INF/statedb/couchdb/indexes/index.json")
+
+	assert.NoError(t, ValidateFile("META-INF/other/index.json", filepath.Join(dir, "missing.json")), "file shouldn't be read")
+	assert.Error(t, ValidateFile("META-INF/statedb/couchdb/indexes/index.json", filepath.Join(dir, "missing.json")))
+}
+
+func TestValidateFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metadata")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	validFile := filepath.Join(dir, "valid.json")
+	require.NoError(t, ioutil.WriteFile(validFile, []byte(`{"index":{"fields":["owner"]}}`), 0644))
+	invalidFile := filepath.Join(dir, "invalid.json")
+	require.NoError(t, ioutil.WriteFile(invalidFile, []byte(`{"index":{}}`), 0644))
+
+	files := map[string]string{
+		"src/chaincode/main.go":                            filepath.Join(dir, "main.go"),
+		"META-INF/statedb/couchdb/indexes/indexOwner.json": validFile,
+	}
+	assert.NoError(t, ValidateFiles(files))
+	assert.NoError(t, ValidateFiles(nil))
+
+	files["META-INF/statedb/couchdb/indexes/b.json"] = invalidFile
+	files["META-INF/statedb/couchdb/indexes/a.json"] = filepath.Join(dir, "missing.json")
+	err = ValidateFiles(files)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "META-INF/statedb/couchdb/indexes/a.json", "expecting the files to be validated in order")
+}
@@ -0,0 +1,227 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nodepackager
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/internal/tarball"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/metadata"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	// sourceDir is the directory of the package that contains the chaincode project
+	sourceDir = "src"
+	// ignoreFile lists the files of the chaincode project that are not packaged
+	ignoreFile = ".npmignore"
+)
+
+// excluded are the directories of the chaincode project that are never packaged. The dependencies of the
+// chaincode are installed by the peer when the chaincode container is built.
+var excluded = []string{"node_modules"}
+
+var logger = logging.NewLogger("fabsdk/fab")
+
+// NewCCPackage creates a new Node.js chaincode package from the chaincode project in the given directory.
+// The project files are packaged in the src directory and the files of the project's META-INF directory
+// (for example, CouchDB indexes) in the META-INF directory of the package. The node_modules directory and
+// the files matching the patterns of the project's .npmignore file are excluded.
+func NewCCPackage(chaincodePath string) (*resource.CCPackage, error) {
+
+	if chaincodePath == "" {
+		return nil, errors.New("chaincode path must be provided")
+	}
+
+	projDir, err := filepath.Abs(chaincodePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid chaincode path")
+	}
+	fileInfo, err := os.Stat(projDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid chaincode path")
+	}
+	if !fileInfo.IsDir() {
+		return nil, errors.Errorf("chaincode path %s is not a directory", chaincodePath)
+	}
+
+	logger.Debugf("projDir variable=%s", projDir)
+
+	ignored, err := loadIgnorePatterns(filepath.Join(projDir, ignoreFile))
+	if err != nil {
+		return nil, err
+	}
+
+	descriptors, err := findSource(projDir, ignored)
+	if err != nil {
+		return nil, err
+	}
+	if err := metadata.ValidateFiles(tarball.Files(descriptors)); err != nil {
+		return nil, err
+	}
+	tarBytes, err := tarball.GenerateTarGz(descriptors)
+	if err != nil {
+		return nil, err
+	}
+
+	ccPkg := &resource.CCPackage{Type: pb.ChaincodeSpec_NODE, Code: tarBytes}
+
+	return ccPkg, nil
+}
+
+// findSource returns the descriptors of the files of the chaincode project that are packaged
+func findSource(projDir string, ignored []ignorePattern) ([]*tarball.Descriptor, error) {
+	var descriptors []*tarball.Descriptor
+	err := filepath.Walk(projDir,
+		func(filePath string, fileInfo os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(projDir, filePath)
+			if err != nil {
+				return err
+			}
+			if relPath == "." {
+				return nil
+			}
+			relPath = filepath.ToSlash(relPath)
+
+			if isExcluded(relPath, fileInfo.IsDir(), ignored) {
+				logger.Debugf("excluding %s from chaincode package", relPath)
+				if fileInfo.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if !fileInfo.Mode().IsRegular() {
+				return nil
+			}
+
+			name := path.Join(sourceDir, relPath)
+			if strings.HasPrefix(relPath, metadata.Dir+"/") {
+				name = relPath
+			}
+			descriptors = append(descriptors, &tarball.Descriptor{Name: name, Path: filePath})
+			return nil
+		})
+
+	return descriptors, err
+}
+
+// isExcluded returns true if the given file (or directory) of the chaincode project must not be packaged
+func isExcluded(relPath string, isDir bool, ignored []ignorePattern) bool {
+	if relPath == ignoreFile {
+		return true
+	}
+	if isDir {
+		for _, dir := range excluded {
+			if path.Base(relPath) == dir {
+				return true
+			}
+		}
+	}
+
+	exclude := false
+	for _, p := range ignored {
+		if p.matches(relPath, isDir) {
+			exclude = !p.negate
+		}
+	}
+	return exclude
+}
+
+// ignorePattern is a pattern of an ignore file, which uses the same syntax as .gitignore files
+// (except for the ** wildcard, which isn't supported)
+type ignorePattern struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// matches returns true if the file with the given path (relative to the project directory) matches the pattern.
+// Patterns without a slash match the name of a file at any level of the project.
+func (p ignorePattern) matches(relPath string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	if p.anchored {
+		matched, _ := path.Match(p.pattern, relPath)
+		return matched
+	}
+	matched, _ := path.Match(p.pattern, path.Base(relPath))
+	return matched
+}
+
+// loadIgnorePatterns reads the patterns of the given ignore file, if it exists
+func loadIgnorePatterns(fileName string) ([]ignorePattern, error) {
+	file, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "opening ignore file failed")
+	}
+	defer func() {
+		err := file.Close()
+		if err != nil {
+			logger.Warnf("error file close %v", err)
+		}
+	}()
+
+	var patterns []ignorePattern
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		p, err := parseIgnorePattern(line)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading ignore file failed")
+	}
+
+	return patterns, nil
+}
+
+// parseIgnorePattern parses a (non-empty, non-comment) line of an ignore file
+func parseIgnorePattern(line string) (ignorePattern, error) {
+	p := ignorePattern{}
+	pattern := line
+	if strings.HasPrefix(pattern, "!") {
+		p.negate = true
+		pattern = pattern[1:]
+	}
+	if strings.HasSuffix(pattern, "/") {
+		p.dirOnly = true
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	if strings.Contains(pattern, "/") {
+		p.anchored = true
+		pattern = strings.TrimPrefix(pattern, "/")
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return ignorePattern{}, errors.Wrapf(err, "invalid pattern in ignore file: %s", line)
+	}
+	p.pattern = pattern
+	return p, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nodepackager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/internal/packagertest"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleCCPath = "../../../../test/fixtures/testdata/node/example_cc"
+
+// Test Node.js chaincode packaging
+func TestNewCCPackage(t *testing.T) {
+	ccPackage, err := NewCCPackage(exampleCCPath)
+	require.NoError(t, err)
+	assert.Equal(t, pb.ChaincodeSpec_NODE, ccPackage.Type)
+
+	files := packagertest.PackagedFiles(t, ccPackage.Code)
+	assert.Equal(t, []string{
+		"META-INF/statedb/couchdb/indexes/indexOwner.json",
+		"src/example_cc.js",
+		"src/package.json",
+	}, files)
+}
+
+func TestNewCCPackageExclusions(t *testing.T) {
+	projDir, err := ioutil.TempDir("", "nodecc")
+	require.NoError(t, err)
+	defer os.RemoveAll(projDir)
+
+	packagertest.WriteFile(t, projDir, "package.json", `{"name": "cc"}`)
+	packagertest.WriteFile(t, projDir, "index.js", "")
+	packagertest.WriteFile(t, projDir, "lib/util.js", "")
+	packagertest.WriteFile(t, projDir, "node_modules/fabric-shim/index.js", "")
+	packagertest.WriteFile(t, projDir, "lib/node_modules/dep/index.js", "")
+	packagertest.WriteFile(t, projDir, "debug.log", "")
+	packagertest.WriteFile(t, projDir, "lib/trace.log", "")
+	packagertest.WriteFile(t, projDir, "keep.log", "")
+	packagertest.WriteFile(t, projDir, "test/cc_test.js", "")
+	packagertest.WriteFile(t, projDir, "docs/README.md", "")
+	packagertest.WriteFile(t, projDir, "lib/docs/README.md", "")
+	packagertest.WriteFile(t, projDir, "META-INF/statedb/couchdb/indexes/index.json", `{"index":{"fields":["owner"]}}`)
+	packagertest.WriteFile(t, projDir, ".npmignore", "# comment\n\n*.log\n!keep.log\ntest/\n/docs\n")
+
+	ccPackage, err := NewCCPackage(projDir)
+	require.NoError(t, err)
+
+	files := packagertest.PackagedFiles(t, ccPackage.Code)
+	assert.Equal(t, []string{
+		"META-INF/statedb/couchdb/indexes/index.json",
+		"src/index.js",
+		"src/keep.log",
+		"src/lib/docs/README.md",
+		"src/lib/util.js",
+		"src/package.json",
+	}, files)
+}
+
+func TestNewCCPackageError(t *testing.T) {
+	_, err := NewCCPackage("")
+	assert.Error(t, err, "expecting error for missing chaincode path")
+
+	_, err = NewCCPackage("../../../../test/fixturesABC")
+	assert.Error(t, err, "expecting error for invalid chaincode path")
+
+	_, err = NewCCPackage(filepath.Join(exampleCCPath, "package.json"))
+	assert.Error(t, err, "expecting error since chaincode path isn't a directory")
+
+	projDir, err := ioutil.TempDir("", "nodecc")
+	require.NoError(t, err)
+	defer os.RemoveAll(projDir)
+
+	packagertest.WriteFile(t, projDir, ".npmignore", "[\n")
+	_, err = NewCCPackage(projDir)
+	assert.Error(t, err, "expecting error for invalid ignore pattern")
+
+	packagertest.WriteFile(t, projDir, ".npmignore", "")
+	packagertest.WriteFile(t, projDir, "META-INF/statedb/couchdb/indexes/index.json", `{"index":{"fields":"owner"}}`)
+	_, err = NewCCPackage(projDir)
+	assert.Error(t, err, "expecting error for invalid CouchDB index")
+}
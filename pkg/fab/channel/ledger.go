@@ -18,17 +18,13 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
 var logger = logging.NewLogger("fabsdk/fab")
 
-const (
-	lscc           = "lscc"
-	lsccChaincodes = "getchaincodes"
-)
-
 // Ledger is a client that provides access to the underlying ledger of a channel.
 type Ledger struct {
 	chName string
@@ -207,6 +203,42 @@ func createChaincodeQueryResponse(tpr *fab.TransactionProposalResponse) (*pb.Cha
 	return &response, nil
 }
 
+// QueryChaincodeData queries the data (the definition) of a chaincode instantiated on this channel.
+// This query will be made to specified targets.
+func (c *Ledger) QueryChaincodeData(reqCtx reqContext.Context, chaincodeName string, targets []fab.ProposalProcessor, verifier ResponseVerifier) ([]*ccprovider.ChaincodeData, error) {
+	cir := createChaincodeDataInvokeRequest(c.chName, chaincodeName)
+	tprs, errs := queryChaincode(reqCtx, c.chName, cir, targets, verifier)
+
+	responses := []*ccprovider.ChaincodeData{}
+	for _, tpr := range tprs {
+		r := &ccprovider.ChaincodeData{}
+		if err := proto.Unmarshal(tpr.ProposalResponse.GetResponse().Payload, r); err != nil {
+			errs = multi.Append(errs, errors.Wrap(err, "unmarshal of chaincode data failed from target: "+tpr.Endorser))
+		} else {
+			responses = append(responses, r)
+		}
+	}
+	return responses, errs
+}
+
+// QueryCollectionsConfig queries the collections config of a chaincode instantiated on this channel.
+// This query will be made to specified targets.
+func (c *Ledger) QueryCollectionsConfig(reqCtx reqContext.Context, chaincodeName string, targets []fab.ProposalProcessor, verifier ResponseVerifier) ([]*common.CollectionConfigPackage, error) {
+	cir := createCollectionsConfigInvokeRequest(chaincodeName)
+	tprs, errs := queryChaincode(reqCtx, c.chName, cir, targets, verifier)
+
+	responses := []*common.CollectionConfigPackage{}
+	for _, tpr := range tprs {
+		r := &common.CollectionConfigPackage{}
+		if err := proto.Unmarshal(tpr.ProposalResponse.GetResponse().Payload, r); err != nil {
+			errs = multi.Append(errs, errors.Wrap(err, "unmarshal of collections config failed from target: "+tpr.Endorser))
+		} else {
+			responses = append(responses, r)
+		}
+	}
+	return responses, errs
+}
+
 // QueryConfigBlock returns the current configuration block for the specified channel. If the
 // peer doesn't belong to the channel, return error
 func (c *Ledger) QueryConfigBlock(reqCtx reqContext.Context, targets []fab.ProposalProcessor, verifier ResponseVerifier) (*common.Block, error) {
@@ -265,11 +297,3 @@ func filterResponses(responses []*fab.TransactionProposalResponse, errs error, v
 
 	return filteredResponses, errs
 }
-
-func createChaincodeInvokeRequest() fab.ChaincodeInvokeRequest {
-	cir := fab.ChaincodeInvokeRequest{
-		ChaincodeID: lscc,
-		Fcn:         lsccChaincodes,
-	}
-	return cir
-}
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	"github.com/stretchr/testify/assert"
 )
@@ -99,6 +100,49 @@ func TestQueryInstantiatedChaincodes(t *testing.T) {
 
 }
 
+func TestQueryChaincodeData(t *testing.T) {
+	channel, _ := setupTestLedger()
+
+	payload, err := proto.Marshal(&ccprovider.ChaincodeData{Name: "examplecc", Version: "v1"})
+	assert.Nil(t, err)
+	peer := mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockRoles: []string{}, MockCert: nil, Status: 200, Payload: payload}
+
+	reqCtx, cancel := context.NewRequest(setupContext(), context.WithTimeout(10*time.Second))
+	defer cancel()
+
+	res, err := channel.QueryChaincodeData(reqCtx, "examplecc", []fab.ProposalProcessor{&peer}, nil)
+	assert.Nil(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "examplecc", res[0].Name)
+	assert.Equal(t, "v1", res[0].Version)
+
+	peer.Payload = []byte("invalid")
+	_, err = channel.QueryChaincodeData(reqCtx, "examplecc", []fab.ProposalProcessor{&peer}, nil)
+	assert.NotNil(t, err, "expecting error for invalid chaincode data")
+}
+
+func TestQueryCollectionsConfig(t *testing.T) {
+	channel, _ := setupTestLedger()
+
+	collConfig := &common.CollectionConfigPackage{Config: []*common.CollectionConfig{{Payload: &common.CollectionConfig_StaticCollectionConfig{
+		StaticCollectionConfig: &common.StaticCollectionConfig{Name: "collection1", RequiredPeerCount: 1, MaximumPeerCount: 2}}}}}
+	payload, err := proto.Marshal(collConfig)
+	assert.Nil(t, err)
+	peer := mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockRoles: []string{}, MockCert: nil, Status: 200, Payload: payload}
+
+	reqCtx, cancel := context.NewRequest(setupContext(), context.WithTimeout(10*time.Second))
+	defer cancel()
+
+	res, err := channel.QueryCollectionsConfig(reqCtx, "examplecc", []fab.ProposalProcessor{&peer}, nil)
+	assert.Nil(t, err)
+	assert.Len(t, res, 1)
+	assert.True(t, proto.Equal(collConfig, res[0]))
+
+	peer.Payload = []byte("invalid")
+	_, err = channel.QueryCollectionsConfig(reqCtx, "examplecc", []fab.ProposalProcessor{&peer}, nil)
+	assert.NotNil(t, err, "expecting error for invalid collections config")
+}
+
 func TestQueryTransaction(t *testing.T) {
 	channel, _ := setupTestLedger()
 	peer := mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockRoles: []string{}, MockCert: nil, Status: 200}
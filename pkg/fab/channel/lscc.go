@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+const (
+	lscc                  = "lscc"
+	lsccChaincodes        = "getchaincodes"
+	lsccChaincodeData     = "getccdata"
+	lsccCollectionsConfig = "getcollectionsconfig"
+)
+
+func createChaincodeInvokeRequest() fab.ChaincodeInvokeRequest {
+	cir := fab.ChaincodeInvokeRequest{
+		ChaincodeID: lscc,
+		Fcn:         lsccChaincodes,
+	}
+	return cir
+}
+
+func createChaincodeDataInvokeRequest(channelID string, chaincodeName string) fab.ChaincodeInvokeRequest {
+	var args [][]byte
+	args = append(args, []byte(channelID))
+	args = append(args, []byte(chaincodeName))
+
+	cir := fab.ChaincodeInvokeRequest{
+		ChaincodeID: lscc,
+		Fcn:         lsccChaincodeData,
+		Args:        args,
+	}
+	return cir
+}
+
+func createCollectionsConfigInvokeRequest(chaincodeName string) fab.ChaincodeInvokeRequest {
+	var args [][]byte
+	args = append(args, []byte(chaincodeName))
+
+	cir := fab.ChaincodeInvokeRequest{
+		ChaincodeID: lscc,
+		Fcn:         lsccCollectionsConfig,
+		Args:        args,
+	}
+	return cir
+}
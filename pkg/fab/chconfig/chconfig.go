@@ -9,6 +9,8 @@ package chconfig
 import (
 	reqContext "context"
 	"math/rand"
+	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 
@@ -24,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	ob "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
@@ -64,13 +67,17 @@ type ChannelConfig struct {
 
 // ChannelCfg contains channel configuration
 type ChannelCfg struct {
-	id           string
-	blockNumber  uint64
-	msps         []*mb.MSPConfig
-	anchorPeers  []*fab.OrgAnchorPeer
-	orderers     []string
-	versions     *fab.Versions
-	capabilities map[fab.ConfigGroupKey]map[string]bool
+	id              string
+	blockNumber     uint64
+	msps            []*mb.MSPConfig
+	anchorPeers     []*fab.OrgAnchorPeer
+	orderers        []string
+	versions        *fab.Versions
+	capabilities    map[fab.ConfigGroupKey]map[string]bool
+	ordererSettings *fab.OrdererSettings
+	batchTimeout    string
+	acls            map[string]string
+	policies        *fab.PolicyGroup
 }
 
 // NewChannelCfg creates channel cfg
@@ -125,6 +132,35 @@ func (cfg *ChannelCfg) HasCapability(group fab.ConfigGroupKey, capability string
 	return false
 }
 
+// OrdererSettings returns the settings of the ordering service (batch size, batch timeout, consensus type, etc.)
+// or nil if the channel config doesn't contain an orderer group. An error is returned if the batch timeout
+// isn't a valid duration.
+func (cfg *ChannelCfg) OrdererSettings() (*fab.OrdererSettings, error) {
+	if cfg.ordererSettings == nil {
+		return nil, nil
+	}
+
+	settings := *cfg.ordererSettings
+	if cfg.batchTimeout != "" {
+		timeout, err := time.ParseDuration(cfg.batchTimeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid batch timeout [%s]", cfg.batchTimeout)
+		}
+		settings.BatchTimeout = timeout
+	}
+	return &settings, nil
+}
+
+// ACLs returns the policies of the channel's ACLs keyed by resource
+func (cfg *ChannelCfg) ACLs() map[string]string {
+	return cfg.acls
+}
+
+// Policies returns the policy tree of the channel config
+func (cfg *ChannelCfg) Policies() *fab.PolicyGroup {
+	return cfg.policies
+}
+
 // New channel config implementation
 func New(channelID string, options ...Option) (*ChannelConfig, error) {
 	opts, err := prepareOpts(options...)
@@ -346,6 +382,11 @@ func prepareOpts(options ...Option) (Opts, error) {
 	return opts, nil
 }
 
+// ExtractConfigFromBlock extracts the channel configuration from the given config block
+func ExtractConfigFromBlock(channelID string, block *common.Block) (*ChannelCfg, error) {
+	return extractConfig(channelID, block)
+}
+
 func extractConfig(channelID string, block *common.Block) (*ChannelCfg, error) {
 	if block.Header == nil {
 		return nil, errors.New("expected header in block")
@@ -356,7 +397,15 @@ func extractConfig(channelID string, block *common.Block) (*ChannelCfg, error) {
 		return nil, err
 	}
 
-	group := configEnvelope.Config.ChannelGroup
+	return newChannelCfg(channelID, block.Header.Number, configEnvelope.Config)
+}
+
+func newChannelCfg(channelID string, blockNumber uint64, cfg *common.Config) (*ChannelCfg, error) {
+	if cfg == nil {
+		return nil, errors.New("expected config in config envelope")
+	}
+
+	group := cfg.ChannelGroup
 
 	versions := &fab.Versions{
 		Channel: &common.ConfigGroup{},
@@ -364,15 +413,17 @@ func extractConfig(channelID string, block *common.Block) (*ChannelCfg, error) {
 
 	config := &ChannelCfg{
 		id:           channelID,
-		blockNumber:  block.Header.Number,
+		blockNumber:  blockNumber,
 		msps:         []*mb.MSPConfig{},
 		anchorPeers:  []*fab.OrgAnchorPeer{},
 		orderers:     []string{},
 		versions:     versions,
 		capabilities: make(map[fab.ConfigGroupKey]map[string]bool),
+		acls:         make(map[string]string),
+		policies:     &fab.PolicyGroup{Name: "Channel", Path: rootGroupPath},
 	}
 
-	err = loadConfig(config, config.versions.Channel, group, "", "")
+	err := loadConfig(config, config.versions.Channel, config.policies, group, "", "")
 	if err != nil {
 		return nil, errors.WithMessage(err, "load config items from config group failed")
 	}
@@ -383,7 +434,7 @@ func extractConfig(channelID string, block *common.Block) (*ChannelCfg, error) {
 
 }
 
-func loadConfig(configItems *ChannelCfg, versionsGroup *common.ConfigGroup, group *common.ConfigGroup, name string, org string) error {
+func loadConfig(configItems *ChannelCfg, versionsGroup *common.ConfigGroup, policyGroup *fab.PolicyGroup, group *common.ConfigGroup, name string, org string) error {
 	logger.Debugf("loadConfigGroup - %s - START groups Org: %s", name, org)
 	if group == nil {
 		return nil
@@ -391,16 +442,19 @@ func loadConfig(configItems *ChannelCfg, versionsGroup *common.ConfigGroup, grou
 
 	logger.Debugf("loadConfigGroup - %s   - version %v", name, group.Version)
 	logger.Debugf("loadConfigGroup - %s   - mod policy %s", name, group.ModPolicy)
+	policyGroup.ModPolicy = group.ModPolicy
 	logger.Debugf("loadConfigGroup - %s - >> groups", name)
 
 	groups := group.GetGroups()
 	if groups != nil {
 		versionsGroup.Groups = make(map[string]*common.ConfigGroup)
+		policyGroup.Groups = make(map[string]*fab.PolicyGroup)
 		for key, configGroup := range groups {
 			logger.Debugf("loadConfigGroup - %s - found config group ==> %s", name, key)
 			// The Application group is where config settings are that we want to find
 			versionsGroup.Groups[key] = &common.ConfigGroup{}
-			err := loadConfig(configItems, versionsGroup.Groups[key], configGroup, key, key)
+			policyGroup.Groups[key] = &fab.PolicyGroup{Name: key, Path: policyGroup.Path + "/" + key}
+			err := loadConfig(configItems, versionsGroup.Groups[key], policyGroup.Groups[key], configGroup, key, key)
 			if err != nil {
 				return err
 			}
@@ -427,7 +481,7 @@ func loadConfig(configItems *ChannelCfg, versionsGroup *common.ConfigGroup, grou
 	}
 	logger.Debugf("loadConfigGroup - %s - << values", name)
 
-	err := loadConfigGroupPolicies(name, org, configItems, versionsGroup, group)
+	err := loadConfigGroupPolicies(name, org, configItems, versionsGroup, policyGroup, group)
 	if err != nil {
 		return err
 	}
@@ -436,17 +490,21 @@ func loadConfig(configItems *ChannelCfg, versionsGroup *common.ConfigGroup, grou
 	return nil
 }
 
-func loadConfigGroupPolicies(name, org string, configItems *ChannelCfg, versionsGroup *common.ConfigGroup, group *common.ConfigGroup) error {
+func loadConfigGroupPolicies(name, org string, configItems *ChannelCfg, versionsGroup *common.ConfigGroup, policyGroup *fab.PolicyGroup, group *common.ConfigGroup) error {
 	logger.Debugf("loadConfigGroup - %s - >> policies", name)
 	policies := group.GetPolicies()
 	if policies != nil {
 		versionsGroup.Policies = make(map[string]*common.ConfigPolicy)
+		policyGroup.Policies = make(map[string]*fab.Policy)
 		for key, configPolicy := range policies {
 			versionsGroup.Policies[key] = &common.ConfigPolicy{}
-			err := loadConfigPolicy(configItems, key, versionsGroup.Policies[key], configPolicy, name, org)
+			policy, err := loadConfigPolicy(configItems, key, versionsGroup.Policies[key], configPolicy, name, org)
 			if err != nil {
 				return err
 			}
+			policy.Name = key
+			policy.Path = policyGroup.Path + "/" + key
+			policyGroup.Policies[key] = policy
 		}
 	} else {
 		logger.Debugf("loadConfigGroup - %s - no policies", name)
@@ -456,16 +514,26 @@ func loadConfigGroupPolicies(name, org string, configItems *ChannelCfg, versions
 
 }
 
-func loadConfigPolicy(configItems *ChannelCfg, key string, versionsPolicy *common.ConfigPolicy, configPolicy *common.ConfigPolicy, groupName string, org string) error {
+func loadConfigPolicy(configItems *ChannelCfg, key string, versionsPolicy *common.ConfigPolicy, configPolicy *common.ConfigPolicy, groupName string, org string) (*fab.Policy, error) {
 	logger.Debugf("loadConfigPolicy - %s - name: %s", groupName, key)
 	logger.Debugf("loadConfigPolicy - %s - version: %d", groupName, configPolicy.Version)
 	logger.Debugf("loadConfigPolicy - %s - mod_policy: %s", groupName, configPolicy.ModPolicy)
 
 	versionsPolicy.Version = configPolicy.Version
-	return loadPolicy(configPolicy.Policy, groupName)
+
+	if configPolicy.Policy == nil {
+		return nil, errors.Errorf("policy %s is missing in group %s", key, groupName)
+	}
+
+	policy, err := loadPolicy(configPolicy.Policy, groupName)
+	if err != nil {
+		return nil, err
+	}
+	policy.ModPolicy = configPolicy.ModPolicy
+	return policy, nil
 }
 
-func loadPolicy(policy *common.Policy, groupName string) error {
+func loadPolicy(policy *common.Policy, groupName string) (*fab.Policy, error) {
 
 	policyType := common.Policy_PolicyType(policy.Type)
 
@@ -474,10 +542,16 @@ func loadPolicy(policy *common.Policy, groupName string) error {
 		sigPolicyEnv := &common.SignaturePolicyEnvelope{}
 		err := proto.Unmarshal(policy.Value, sigPolicyEnv)
 		if err != nil {
-			return errors.Wrap(err, "unmarshal signature policy envelope from config failed")
+			return nil, errors.Wrap(err, "unmarshal signature policy envelope from config failed")
 		}
 		logger.Debugf("loadConfigPolicy - %s - policy SIGNATURE :: %v", groupName, sigPolicyEnv.Rule)
-		// TODO: Do something with this value
+		signaturePolicy, err := newSignaturePolicy(sigPolicyEnv.Rule, sigPolicyEnv.Identities)
+		if err != nil {
+			// The policy is rejected by the orderer and peers; it shouldn't prevent the rest of the config from being used
+			logger.Warnf("loadConfigPolicy - %s - invalid signature policy: %s", groupName, err)
+			return &fab.Policy{Type: fab.UnknownPolicyType}, nil
+		}
+		return &fab.Policy{Type: fab.SignaturePolicyType, Signature: signaturePolicy}, nil
 
 	case common.Policy_MSP:
 		// TODO: Not implemented yet
@@ -487,18 +561,89 @@ func loadPolicy(policy *common.Policy, groupName string) error {
 		implicitMetaPolicy := &common.ImplicitMetaPolicy{}
 		err := proto.Unmarshal(policy.Value, implicitMetaPolicy)
 		if err != nil {
-			return errors.Wrap(err, "unmarshal implicit meta policy from config failed")
+			return nil, errors.Wrap(err, "unmarshal implicit meta policy from config failed")
 		}
 		logger.Debugf("loadConfigPolicy - %s - policy IMPLICIT_META :: %v", groupName, implicitMetaPolicy)
-		// TODO: Do something with this value
+		return &fab.Policy{
+			Type: fab.ImplicitMetaPolicyType,
+			ImplicitMeta: &fab.ImplicitMetaPolicy{
+				Rule:      implicitMetaPolicy.Rule.String(),
+				SubPolicy: implicitMetaPolicy.SubPolicy,
+			},
+		}, nil
 	case common.Policy_UNKNOWN:
 		// TODO: Not implemented yet
 		logger.Debugf("loadConfigPolicy - %s - policy UNKNOWN ", groupName)
 
 	default:
-		return errors.Errorf("unknown policy type %v", policyType)
+		return nil, errors.Errorf("unknown policy type %v", policyType)
+	}
+	return &fab.Policy{Type: fab.UnknownPolicyType}, nil
+}
+
+// NewSignaturePolicy converts a signature policy (for example, the endorsement policy of a chaincode) to the
+// signature policy model of the channel config, which can be rendered as an expression
+func NewSignaturePolicy(envelope *common.SignaturePolicyEnvelope) (*fab.SignaturePolicy, error) {
+	if envelope == nil {
+		return nil, errors.New("missing signature policy")
+	}
+	return newSignaturePolicy(envelope.Rule, envelope.Identities)
+}
+
+// newSignaturePolicy converts a signature policy rule, whose leaves refer to the given principals by index
+func newSignaturePolicy(rule *common.SignaturePolicy, identities []*mb.MSPPrincipal) (*fab.SignaturePolicy, error) {
+	if rule == nil {
+		return nil, errors.New("missing signature policy rule")
+	}
+
+	switch t := rule.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= len(identities) {
+			return nil, errors.Errorf("identity index %d out of range", t.SignedBy)
+		}
+		principal, err := newPrincipal(identities[t.SignedBy])
+		if err != nil {
+			return nil, err
+		}
+		return &fab.SignaturePolicy{Principal: principal}, nil
+	case *common.SignaturePolicy_NOutOf_:
+		policy := &fab.SignaturePolicy{N: t.NOutOf.N}
+		for _, r := range t.NOutOf.Rules {
+			subPolicy, err := newSignaturePolicy(r, identities)
+			if err != nil {
+				return nil, err
+			}
+			policy.Rules = append(policy.Rules, subPolicy)
+		}
+		return policy, nil
+	default:
+		return nil, errors.Errorf("unknown signature policy rule type %T", t)
+	}
+}
+
+func newPrincipal(mspPrincipal *mb.MSPPrincipal) (*fab.Principal, error) {
+	switch mspPrincipal.PrincipalClassification {
+	case mb.MSPPrincipal_ROLE:
+		role := &mb.MSPRole{}
+		if err := proto.Unmarshal(mspPrincipal.Principal, role); err != nil {
+			return nil, errors.Wrap(err, "unmarshal MSP role failed")
+		}
+		return &fab.Principal{Classification: fab.RolePrincipal, MSPID: role.MspIdentifier, Role: strings.ToLower(role.Role.String())}, nil
+	case mb.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mb.OrganizationUnit{}
+		if err := proto.Unmarshal(mspPrincipal.Principal, ou); err != nil {
+			return nil, errors.Wrap(err, "unmarshal organization unit failed")
+		}
+		return &fab.Principal{Classification: fab.OrganizationUnitPrincipal, MSPID: ou.MspIdentifier, OrganizationalUnit: ou.OrganizationalUnitIdentifier}, nil
+	case mb.MSPPrincipal_IDENTITY:
+		identity := &mb.SerializedIdentity{}
+		if err := proto.Unmarshal(mspPrincipal.Principal, identity); err != nil {
+			return nil, errors.Wrap(err, "unmarshal serialized identity failed")
+		}
+		return &fab.Principal{Classification: fab.IdentityPrincipal, MSPID: identity.Mspid}, nil
+	default:
+		return &fab.Principal{Classification: fab.PrincipalClassification(mspPrincipal.PrincipalClassification.String())}, nil
 	}
-	return nil
 }
 
 func loadAnchorPeers(configValue *common.ConfigValue, configItems *ChannelCfg, groupName, org string) error {
@@ -553,6 +698,100 @@ func loadOrdererAddressesKey(configValue *common.ConfigValue, configItems *Chann
 
 }
 
+func loadOrdererSettings(configValue *common.ConfigValue, configItems *ChannelCfg, key string, groupName string) error {
+	if groupName != string(fab.OrdererGroupKey) {
+		logger.Debugf("loadConfigValue - %s   - ignoring orderer setting %s outside of the orderer group", groupName, key)
+		return nil
+	}
+
+	if configItems.ordererSettings == nil {
+		configItems.ordererSettings = &fab.OrdererSettings{}
+	}
+
+	switch key {
+	case channelConfig.ConsensusTypeKey:
+		return loadConsensusType(configValue, configItems.ordererSettings, groupName)
+	case channelConfig.BatchSizeKey:
+		return loadBatchSize(configValue, configItems.ordererSettings, groupName)
+	case channelConfig.BatchTimeoutKey:
+		return loadBatchTimeout(configValue, configItems, groupName)
+	case channelConfig.ChannelRestrictionsKey:
+		return loadChannelRestrictions(configValue, configItems.ordererSettings, groupName)
+	case channelConfig.KafkaBrokersKey:
+		return loadKafkaBrokers(configValue, configItems.ordererSettings, groupName)
+	}
+	return nil
+}
+
+func loadConsensusType(configValue *common.ConfigValue, settings *fab.OrdererSettings, groupName string) error {
+	consensusType := &ob.ConsensusType{}
+	if err := proto.Unmarshal(configValue.Value, consensusType); err != nil {
+		return errors.Wrap(err, "unmarshal consensus type from config failed")
+	}
+	logger.Debugf("loadConfigValue - %s   - Consensus type value :: %s", groupName, consensusType.Type)
+	settings.ConsensusType = consensusType.Type
+	settings.ConsensusMetadata = consensusType.Metadata
+	return nil
+}
+
+func loadBatchSize(configValue *common.ConfigValue, settings *fab.OrdererSettings, groupName string) error {
+	batchSize := &ob.BatchSize{}
+	if err := proto.Unmarshal(configValue.Value, batchSize); err != nil {
+		return errors.Wrap(err, "unmarshal batch size from config failed")
+	}
+	logger.Debugf("loadConfigValue - %s   - BatchSize  maxMessageCount :: %d", groupName, batchSize.MaxMessageCount)
+	logger.Debugf("loadConfigValue - %s   - BatchSize  absoluteMaxBytes :: %d", groupName, batchSize.AbsoluteMaxBytes)
+	logger.Debugf("loadConfigValue - %s   - BatchSize  preferredMaxBytes :: %d", groupName, batchSize.PreferredMaxBytes)
+	settings.BatchSize = fab.BatchSize{
+		MaxMessageCount:   batchSize.MaxMessageCount,
+		AbsoluteMaxBytes:  batchSize.AbsoluteMaxBytes,
+		PreferredMaxBytes: batchSize.PreferredMaxBytes,
+	}
+	return nil
+}
+
+func loadBatchTimeout(configValue *common.ConfigValue, configItems *ChannelCfg, groupName string) error {
+	batchTimeout := &ob.BatchTimeout{}
+	if err := proto.Unmarshal(configValue.Value, batchTimeout); err != nil {
+		return errors.Wrap(err, "unmarshal batch timeout from config failed")
+	}
+	logger.Debugf("loadConfigValue - %s   - BatchTimeout timeout value :: %s", groupName, batchTimeout.Timeout)
+	configItems.batchTimeout = batchTimeout.Timeout
+	return nil
+}
+
+func loadChannelRestrictions(configValue *common.ConfigValue, settings *fab.OrdererSettings, groupName string) error {
+	channelRestrictions := &ob.ChannelRestrictions{}
+	if err := proto.Unmarshal(configValue.Value, channelRestrictions); err != nil {
+		return errors.Wrap(err, "unmarshal channel restrictions from config failed")
+	}
+	logger.Debugf("loadConfigValue - %s   - ChannelRestrictions max_count value :: %d", groupName, channelRestrictions.MaxCount)
+	settings.MaxChannels = channelRestrictions.MaxCount
+	return nil
+}
+
+func loadKafkaBrokers(configValue *common.ConfigValue, settings *fab.OrdererSettings, groupName string) error {
+	kafkaBrokers := &ob.KafkaBrokers{}
+	if err := proto.Unmarshal(configValue.Value, kafkaBrokers); err != nil {
+		return errors.Wrap(err, "unmarshal kafka brokers from config failed")
+	}
+	logger.Debugf("loadConfigValue - %s   - KafkaBrokers brokers value :: %s", groupName, kafkaBrokers.Brokers)
+	settings.KafkaBrokers = kafkaBrokers.Brokers
+	return nil
+}
+
+func loadACLs(configValue *common.ConfigValue, configItems *ChannelCfg, groupName string) error {
+	acls := &pb.ACLs{}
+	if err := proto.Unmarshal(configValue.Value, acls); err != nil {
+		return errors.Wrap(err, "unmarshal ACLs from config failed")
+	}
+	for resource, apiResource := range acls.Acls {
+		logger.Debugf("loadConfigValue - %s   - ACL for resource [%s] :: %s", groupName, resource, apiResource.PolicyRef)
+		configItems.acls[resource] = apiResource.PolicyRef
+	}
+	return nil
+}
+
 func loadCapabilities(configValue *common.ConfigValue, configItems *ChannelCfg, groupName string) error {
 	capabilities := &common.Capabilities{}
 	err := proto.Unmarshal(configValue.Value, capabilities)
@@ -577,55 +816,14 @@ func loadConfigValue(configItems *ChannelCfg, key string, versionsValue *common.
 
 	switch key {
 	case channelConfig.AnchorPeersKey:
-		if err := loadAnchorPeers(configValue, configItems, groupName, org); err != nil {
-			return err
-		}
+		return loadAnchorPeers(configValue, configItems, groupName, org)
 	case channelConfig.MSPKey:
-		if err := loadMSPKey(configValue, configItems, groupName); err != nil {
-			return err
-		}
+		return loadMSPKey(configValue, configItems, groupName)
 	case channelConfig.CapabilitiesKey:
-		if err := loadCapabilities(configValue, configItems, groupName); err != nil {
-			return err
-		}
-	//case channelConfig.ConsensusTypeKey:
-	//	consensusType := &ab.ConsensusType{}
-	//	err := proto.Unmarshal(configValue.Value, consensusType)
-	//	if err != nil {
-	//		return errors.Wrap(err, "unmarshal ConsensusType from config failed")
-	//	}
-	//
-	//	logger.Debugf("loadConfigValue - %s   - Consensus type value :: %s", groupName, consensusType.Type)
-	//	// TODO: Do something with this value
-	//case channelConfig.BatchSizeKey:
-	//	batchSize := &ab.BatchSize{}
-	//	err := proto.Unmarshal(configValue.Value, batchSize)
-	//	if err != nil {
-	//		return errors.Wrap(err, "unmarshal batch size from config failed")
-	//	}
-	//
-	//	logger.Debugf("loadConfigValue - %s   - BatchSize  maxMessageCount :: %d", groupName, batchSize.MaxMessageCount)
-	//	logger.Debugf("loadConfigValue - %s   - BatchSize  absoluteMaxBytes :: %d", groupName, batchSize.AbsoluteMaxBytes)
-	//	logger.Debugf("loadConfigValue - %s   - BatchSize  preferredMaxBytes :: %d", groupName, batchSize.PreferredMaxBytes)
-	//	// TODO: Do something with this value
-
-	//case channelConfig.BatchTimeoutKey:
-	//	batchTimeout := &ab.BatchTimeout{}
-	//	err := proto.Unmarshal(configValue.Value, batchTimeout)
-	//	if err != nil {
-	//		return errors.Wrap(err, "unmarshal batch timeout from config failed")
-	//	}
-	//	logger.Debugf("loadConfigValue - %s   - BatchTimeout timeout value :: %s", groupName, batchTimeout.Timeout)
-	//	// TODO: Do something with this value
-
-	//case channelConfig.ChannelRestrictionsKey:
-	//	channelRestrictions := &ab.ChannelRestrictions{}
-	//	err := proto.Unmarshal(configValue.Value, channelRestrictions)
-	//	if err != nil {
-	//		return errors.Wrap(err, "unmarshal channel restrictions from config failed")
-	//	}
-	//	logger.Debugf("loadConfigValue - %s   - ChannelRestrictions max_count value :: %d", groupName, channelRestrictions.MaxCount)
-	//	// TODO: Do something with this value
+		return loadCapabilities(configValue, configItems, groupName)
+	case channelConfig.ConsensusTypeKey, channelConfig.BatchSizeKey, channelConfig.BatchTimeoutKey,
+		channelConfig.ChannelRestrictionsKey, channelConfig.KafkaBrokersKey:
+		return loadOrdererSettings(configValue, configItems, key, groupName)
 
 	//case channelConfig.HashingAlgorithmKey:
 	//	hashingAlgorithm := &common.HashingAlgorithm{}
@@ -654,10 +852,11 @@ func loadConfigValue(configItems *ChannelCfg, key string, versionsValue *common.
 	//	logger.Debugf("loadConfigValue - %s   - BlockDataHashingStructure width value :: %s", groupName, bdhstruct.Width)
 	//	// TODO: Do something with this value
 
+	case channelConfig.ACLsKey:
+		return loadACLs(configValue, configItems, groupName)
+
 	case channelConfig.OrdererAddressesKey:
-		if err := loadOrdererAddressesKey(configValue, configItems, groupName); err != nil {
-			return err
-		}
+		return loadOrdererAddressesKey(configValue, configItems, groupName)
 
 	default:
 		logger.Debugf("loadConfigValue - %s   - value: %s", groupName, configValue.Value)
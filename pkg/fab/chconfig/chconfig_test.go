@@ -22,9 +22,13 @@ import (
 
 	"strings"
 
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config"
 	fabImpl "github.com/hyperledger/fabric-sdk-go/pkg/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	ob "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -267,6 +271,171 @@ func TestCapabilities(t *testing.T) {
 	assert.Falsef(t, chConfig.HasCapability(fab.ApplicationGroupKey, capability4), "not expecting application capability [%s]", capability4)
 }
 
+func TestOrdererSettings(t *testing.T) {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP"},
+			OrdererAddress: "localhost:9999",
+			RootCA:         validRootCA,
+		},
+		Index:           0,
+		LastConfigIndex: 0,
+	}
+
+	chConfig, err := extractConfig("mychannel", builder.Build())
+	require.NoError(t, err)
+
+	// The mock batch timeout ("123") has no unit, which doesn't prevent the config from being loaded
+	_, err = chConfig.OrdererSettings()
+	assert.Error(t, err, "expecting error for batch timeout without unit")
+	assert.Equal(t, []string{"localhost:9999"}, chConfig.Orderers())
+
+	config := newMockConfig(t, builder)
+	ordererGroup := config.ChannelGroup.Groups["Orderer"]
+	batchTimeout, err := proto.Marshal(&ob.BatchTimeout{Timeout: "2s"})
+	require.NoError(t, err)
+	ordererGroup.Values[channelConfig.BatchTimeoutKey].Value = batchTimeout
+
+	cfg, err := newChannelCfg("mychannel", 0, config)
+	require.NoError(t, err)
+	settings, err := cfg.OrdererSettings()
+	require.NoError(t, err)
+	require.NotNil(t, settings)
+	assert.Equal(t, "sample-Consensus-Type", settings.ConsensusType)
+	assert.Equal(t, fab.BatchSize{MaxMessageCount: 10, AbsoluteMaxBytes: 103809024, PreferredMaxBytes: 524288}, settings.BatchSize)
+	assert.Equal(t, 2*time.Second, settings.BatchTimeout)
+	assert.Equal(t, uint64(200), settings.MaxChannels)
+	assert.Empty(t, settings.KafkaBrokers)
+
+	consensusType, err := proto.Marshal(&ob.ConsensusType{Type: "kafka", Metadata: []byte("metadata")})
+	require.NoError(t, err)
+	ordererGroup.Values[channelConfig.ConsensusTypeKey].Value = consensusType
+	kafkaBrokers, err := proto.Marshal(&ob.KafkaBrokers{Brokers: []string{"kafka0:9092", "kafka1:9092"}})
+	require.NoError(t, err)
+	ordererGroup.Values[channelConfig.KafkaBrokersKey] = &common.ConfigValue{Value: kafkaBrokers}
+
+	cfg, err = newChannelCfg("mychannel", 0, config)
+	require.NoError(t, err)
+	settings, err = cfg.OrdererSettings()
+	require.NoError(t, err)
+	assert.Equal(t, "kafka", settings.ConsensusType)
+	assert.Equal(t, []byte("metadata"), settings.ConsensusMetadata)
+	assert.Equal(t, []string{"kafka0:9092", "kafka1:9092"}, settings.KafkaBrokers)
+
+	// There are no orderer settings without an orderer group
+	delete(config.ChannelGroup.Groups, "Orderer")
+	cfg, err = newChannelCfg("mychannel", 0, config)
+	require.NoError(t, err)
+	settings, err = cfg.OrdererSettings()
+	require.NoError(t, err)
+	assert.Nil(t, settings)
+}
+
+func TestACLs(t *testing.T) {
+	config := newMockConfig(t, &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			MSPNames: []string{"Org1MSP"},
+			RootCA:   validRootCA,
+		},
+	})
+
+	cfg, err := newChannelCfg("mychannel", 0, config)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.ACLs())
+
+	acls := map[string]string{
+		"peer/Propose":          "/Channel/Application/Writers",
+		"qscc/GetBlockByNumber": "/Channel/Application/Readers",
+	}
+	setACLs(t, config, acls)
+
+	cfg, err = newChannelCfg("mychannel", 0, config)
+	require.NoError(t, err)
+	assert.Equal(t, acls, cfg.ACLs())
+
+	config.ChannelGroup.Groups["Application"].Values["ACLs"].Value = []byte("invalid")
+	_, err = newChannelCfg("mychannel", 0, config)
+	assert.Error(t, err, "expecting error for invalid ACLs")
+}
+
+func TestPolicies(t *testing.T) {
+	config := newMockConfig(t, &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy: "Admins",
+			MSPNames:  []string{"Org1MSP", "Org2MSP"},
+			RootCA:    validRootCA,
+		},
+	})
+
+	application := config.ChannelGroup.Groups["Application"]
+	application.Policies["Admins"] = newConfigPolicy(t, common.Policy_IMPLICIT_META, &common.ImplicitMetaPolicy{SubPolicy: "Admins", Rule: common.ImplicitMetaPolicy_MAJORITY})
+	application.Groups["Org1MSP"].Policies["Admins"] = newConfigPolicy(t, common.Policy_SIGNATURE, cauthdsl.SignedByMspAdmin("Org1MSP"))
+
+	envelope, err := cauthdsl.FromString("OutOf(2, 'Org1MSP.admin', 'Org2MSP.admin', OR('Org1MSP.peer', 'Org2MSP.client'))")
+	require.NoError(t, err)
+	application.Policies["Custom"] = newConfigPolicy(t, common.Policy_SIGNATURE, envelope)
+
+	cfg, err := newChannelCfg("mychannel", 0, config)
+	require.NoError(t, err)
+
+	root := cfg.Policies()
+	require.NotNil(t, root)
+	assert.Equal(t, "/Channel", root.Path)
+	require.Contains(t, root.Groups, "Application")
+	assert.Equal(t, "/Channel/Application/Org1MSP", root.Groups["Application"].Groups["Org1MSP"].Path)
+	assert.Equal(t, "Admins", root.Groups["Application"].ModPolicy)
+
+	admins := root.Policy("/Channel/Application/Admins")
+	require.NotNil(t, admins)
+	assert.Equal(t, "Admins", admins.Name)
+	assert.Equal(t, "/Channel/Application/Admins", admins.Path)
+	assert.Equal(t, "Admins", admins.ModPolicy)
+	assert.Equal(t, fab.ImplicitMetaPolicyType, admins.Type)
+	assert.Equal(t, "MAJORITY Admins", admins.String())
+
+	orgAdmins := root.Groups["Application"].Policy("Org1MSP/Admins")
+	require.NotNil(t, orgAdmins)
+	assert.Equal(t, fab.SignaturePolicyType, orgAdmins.Type)
+	assert.Equal(t, &fab.Principal{Classification: fab.RolePrincipal, MSPID: "Org1MSP", Role: "admin"}, orgAdmins.Signature.Rules[0].Principal)
+	assert.Equal(t, "OutOf(1, 'Org1MSP.admin')", orgAdmins.String())
+
+	custom := root.Policy("/Channel/Application/Custom")
+	require.NotNil(t, custom)
+	assert.Equal(t, "OutOf(2, 'Org1MSP.admin', 'Org2MSP.admin', OutOf(1, 'Org1MSP.peer', 'Org2MSP.client'))", custom.String())
+
+	// The expression can be parsed back into the same policy
+	parsed, err := cauthdsl.FromString(custom.String())
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(envelope, parsed))
+
+	assert.Nil(t, root.Policy("/Channel/Application/Unknown"))
+	assert.Nil(t, root.Policy("/Channel/Unknown/Admins"))
+	assert.Nil(t, root.Groups["Application"].Policy("/Channel/Orderer/Admins"))
+	assert.NotNil(t, root.Groups["Application"].Policy("/Channel/Application/Org2MSP/Admins"))
+}
+
+func TestNewSignaturePolicy(t *testing.T) {
+	envelope, err := cauthdsl.FromString("AND('Org1MSP.member', OR('Org2MSP.admin', 'Org3MSP.admin'))")
+	require.NoError(t, err)
+
+	policy, err := NewSignaturePolicy(envelope)
+	require.NoError(t, err)
+	assert.Equal(t, "OutOf(2, 'Org1MSP.member', OutOf(1, 'Org2MSP.admin', 'Org3MSP.admin'))", policy.String())
+
+	_, err = NewSignaturePolicy(nil)
+	assert.Error(t, err)
+
+	_, err = NewSignaturePolicy(&common.SignaturePolicyEnvelope{Rule: cauthdsl.SignedBy(1), Identities: envelope.Identities[:1]})
+	assert.Error(t, err, "expecting error for invalid identity index")
+}
+
+func newConfigPolicy(t *testing.T, policyType common.Policy_PolicyType, policy proto.Message) *common.ConfigPolicy {
+	value, err := proto.Marshal(policy)
+	require.NoError(t, err)
+	return &common.ConfigPolicy{ModPolicy: "Admins", Policy: &common.Policy{Type: int32(policyType), Value: value}}
+}
+
 func testResolveOptsDefaultValues(t *testing.T, channelID string) {
 	user := mspmocks.NewMockSigningIdentity("test", "test")
 	ctx := mocks.NewMockContext(user)
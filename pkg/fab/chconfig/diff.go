@@ -0,0 +1,307 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+const rootGroupPath = "/Channel"
+
+// ConfigDiff contains the differences between two channel configurations
+type ConfigDiff struct {
+	// MSPs that were added, removed or whose definition changed, identified by the path of their organization's
+	// config group and their MSP ID (for example, /Channel/Application/Org1:Org1MSP), since the same MSP may be
+	// defined in more than one group (for example, in both the Orderer and Application groups)
+	MSPsAdded    []string
+	MSPsRemoved  []string
+	MSPsModified []string
+
+	AnchorPeersAdded   []*fab.OrgAnchorPeer
+	AnchorPeersRemoved []*fab.OrgAnchorPeer
+
+	OrderersAdded   []string
+	OrderersRemoved []string
+
+	// Capabilities added and removed, per config group
+	CapabilitiesAdded   map[fab.ConfigGroupKey][]string
+	CapabilitiesRemoved map[fab.ConfigGroupKey][]string
+
+	// Resources whose ACLs were added, removed or whose policy changed
+	ACLsAdded    []string
+	ACLsRemoved  []string
+	ACLsModified []string
+
+	// Orderer settings before and after the change (both nil if the orderer settings didn't change, otherwise nil
+	// if the config has no orderer group)
+	OrdererSettingsFrom *fab.OrdererSettings
+	OrdererSettingsTo   *fab.OrdererSettings
+
+	// Paths (for example, /Channel/Application/Org1MSP/Admins) of policies that were added, removed or modified
+	PoliciesAdded    []string
+	PoliciesRemoved  []string
+	PoliciesModified []string
+}
+
+// IsEmpty returns true if there are no differences
+func (d *ConfigDiff) IsEmpty() bool {
+	return d.membersUnchanged() && d.settingsUnchanged() && d.policiesUnchanged()
+}
+
+// membersUnchanged returns true if the MSPs, anchor peers and orderers didn't change
+func (d *ConfigDiff) membersUnchanged() bool {
+	return len(d.MSPsAdded) == 0 && len(d.MSPsRemoved) == 0 && len(d.MSPsModified) == 0 &&
+		len(d.AnchorPeersAdded) == 0 && len(d.AnchorPeersRemoved) == 0 &&
+		len(d.OrderersAdded) == 0 && len(d.OrderersRemoved) == 0
+}
+
+// settingsUnchanged returns true if the capabilities and orderer settings didn't change
+func (d *ConfigDiff) settingsUnchanged() bool {
+	return len(d.CapabilitiesAdded) == 0 && len(d.CapabilitiesRemoved) == 0 &&
+		d.OrdererSettingsFrom == nil && d.OrdererSettingsTo == nil
+}
+
+// policiesUnchanged returns true if the ACLs and policies didn't change
+func (d *ConfigDiff) policiesUnchanged() bool {
+	return len(d.ACLsAdded) == 0 && len(d.ACLsRemoved) == 0 && len(d.ACLsModified) == 0 &&
+		len(d.PoliciesAdded) == 0 && len(d.PoliciesRemoved) == 0 && len(d.PoliciesModified) == 0
+}
+
+// Diff computes the differences between the 'from' and 'to' channel configurations
+func Diff(from, to *common.Config) (*ConfigDiff, error) {
+	fromCfg, err := newChannelCfg("", 0, from)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to load 'from' config")
+	}
+	toCfg, err := newChannelCfg("", 0, to)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to load 'to' config")
+	}
+
+	diff := &ConfigDiff{}
+
+	fromMSPs := make(map[string]*mb.MSPConfig)
+	if err := collectMSPs(fromMSPs, rootGroupPath, from.ChannelGroup); err != nil {
+		return nil, err
+	}
+	toMSPs := make(map[string]*mb.MSPConfig)
+	if err := collectMSPs(toMSPs, rootGroupPath, to.ChannelGroup); err != nil {
+		return nil, err
+	}
+
+	diffMSPs(diff, fromMSPs, toMSPs)
+
+	diff.AnchorPeersAdded, diff.AnchorPeersRemoved = diffAnchorPeers(fromCfg.anchorPeers, toCfg.anchorPeers)
+	diff.OrderersAdded, diff.OrderersRemoved = diffStrings(fromCfg.orderers, toCfg.orderers)
+
+	diffCapabilities(diff, fromCfg.capabilities, toCfg.capabilities)
+
+	diffACLs(diff, fromCfg.acls, toCfg.acls)
+
+	if err := diffOrdererSettings(diff, fromCfg, toCfg); err != nil {
+		return nil, err
+	}
+
+	fromPolicies := make(map[string]*common.Policy)
+	collectPolicies(fromPolicies, rootGroupPath, from.ChannelGroup)
+	toPolicies := make(map[string]*common.Policy)
+	collectPolicies(toPolicies, rootGroupPath, to.ChannelGroup)
+
+	diffPolicies(diff, fromPolicies, toPolicies)
+
+	return diff, nil
+}
+
+func diffMSPs(diff *ConfigDiff, fromMSPs, toMSPs map[string]*mb.MSPConfig) {
+	for key, toMSP := range toMSPs {
+		fromMSP, ok := fromMSPs[key]
+		if !ok {
+			diff.MSPsAdded = append(diff.MSPsAdded, key)
+		} else if !proto.Equal(fromMSP, toMSP) {
+			diff.MSPsModified = append(diff.MSPsModified, key)
+		}
+	}
+	for key := range fromMSPs {
+		if _, ok := toMSPs[key]; !ok {
+			diff.MSPsRemoved = append(diff.MSPsRemoved, key)
+		}
+	}
+
+	sort.Strings(diff.MSPsAdded)
+	sort.Strings(diff.MSPsRemoved)
+	sort.Strings(diff.MSPsModified)
+}
+
+// collectMSPs collects the MSPs defined in the group and its sub-groups, keyed by group path and MSP ID
+func collectMSPs(msps map[string]*mb.MSPConfig, path string, group *common.ConfigGroup) error {
+	if group == nil {
+		return nil
+	}
+	if value, ok := group.Values[channelConfig.MSPKey]; ok {
+		msp := &mb.MSPConfig{}
+		if err := proto.Unmarshal(value.Value, msp); err != nil {
+			return errors.Wrap(err, "unmarshal MSPConfig failed")
+		}
+		mspConfig := &mb.FabricMSPConfig{}
+		if err := proto.Unmarshal(msp.Config, mspConfig); err != nil {
+			return errors.Wrap(err, "unmarshal FabricMSPConfig failed")
+		}
+		msps[path+":"+mspConfig.Name] = msp
+	}
+	for name, subGroup := range group.Groups {
+		if err := collectMSPs(msps, path+"/"+name, subGroup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func diffAnchorPeers(from, to []*fab.OrgAnchorPeer) (added, removed []*fab.OrgAnchorPeer) {
+	key := func(ap *fab.OrgAnchorPeer) string {
+		return fmt.Sprintf("%s/%s:%d", ap.Org, ap.Host, ap.Port)
+	}
+
+	fromPeers := make(map[string]bool)
+	for _, ap := range from {
+		fromPeers[key(ap)] = true
+	}
+	toPeers := make(map[string]bool)
+	for _, ap := range to {
+		toPeers[key(ap)] = true
+		if !fromPeers[key(ap)] {
+			added = append(added, ap)
+		}
+	}
+	for _, ap := range from {
+		if !toPeers[key(ap)] {
+			removed = append(removed, ap)
+		}
+	}
+
+	return added, removed
+}
+
+func diffStrings(from, to []string) (added, removed []string) {
+	fromSet := make(map[string]bool)
+	for _, s := range from {
+		fromSet[s] = true
+	}
+	toSet := make(map[string]bool)
+	for _, s := range to {
+		toSet[s] = true
+		if !fromSet[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range from {
+		if !toSet[s] {
+			removed = append(removed, s)
+		}
+	}
+
+	return added, removed
+}
+
+func diffACLs(diff *ConfigDiff, from, to map[string]string) {
+	for resource, toPolicy := range to {
+		fromPolicy, ok := from[resource]
+		if !ok {
+			diff.ACLsAdded = append(diff.ACLsAdded, resource)
+		} else if fromPolicy != toPolicy {
+			diff.ACLsModified = append(diff.ACLsModified, resource)
+		}
+	}
+	for resource := range from {
+		if _, ok := to[resource]; !ok {
+			diff.ACLsRemoved = append(diff.ACLsRemoved, resource)
+		}
+	}
+
+	sort.Strings(diff.ACLsAdded)
+	sort.Strings(diff.ACLsRemoved)
+	sort.Strings(diff.ACLsModified)
+}
+
+// diffOrdererSettings sets the orderer settings of both configs if they differ. The settings are only validated
+// if they changed.
+func diffOrdererSettings(diff *ConfigDiff, fromCfg, toCfg *ChannelCfg) error {
+	if fromCfg.batchTimeout == toCfg.batchTimeout && reflect.DeepEqual(fromCfg.ordererSettings, toCfg.ordererSettings) {
+		return nil
+	}
+
+	var err error
+	diff.OrdererSettingsFrom, err = fromCfg.OrdererSettings()
+	if err != nil {
+		return errors.WithMessage(err, "invalid orderer settings in 'from' config")
+	}
+	diff.OrdererSettingsTo, err = toCfg.OrdererSettings()
+	if err != nil {
+		return errors.WithMessage(err, "invalid orderer settings in 'to' config")
+	}
+	return nil
+}
+
+func diffCapabilities(diff *ConfigDiff, from, to map[fab.ConfigGroupKey]map[string]bool) {
+	diff.CapabilitiesAdded = missingCapabilities(to, from)
+	diff.CapabilitiesRemoved = missingCapabilities(from, to)
+}
+
+// missingCapabilities returns the capabilities of each group that are in 'capabilities' but not in 'others'. Only
+// groups that have such capabilities are returned.
+func missingCapabilities(capabilities, others map[fab.ConfigGroupKey]map[string]bool) map[fab.ConfigGroupKey][]string {
+	missing := make(map[fab.ConfigGroupKey][]string)
+	for group, groupCapabilities := range capabilities {
+		for capability := range groupCapabilities {
+			if !others[group][capability] {
+				missing[group] = append(missing[group], capability)
+			}
+		}
+		sort.Strings(missing[group])
+	}
+	return missing
+}
+
+func collectPolicies(policies map[string]*common.Policy, path string, group *common.ConfigGroup) {
+	if group == nil {
+		return
+	}
+	for name, configPolicy := range group.Policies {
+		policies[path+"/"+name] = configPolicy.Policy
+	}
+	for name, subGroup := range group.Groups {
+		collectPolicies(policies, path+"/"+name, subGroup)
+	}
+}
+
+func diffPolicies(diff *ConfigDiff, from, to map[string]*common.Policy) {
+	for path, toPolicy := range to {
+		fromPolicy, ok := from[path]
+		if !ok {
+			diff.PoliciesAdded = append(diff.PoliciesAdded, path)
+		} else if !proto.Equal(fromPolicy, toPolicy) {
+			diff.PoliciesModified = append(diff.PoliciesModified, path)
+		}
+	}
+	for path := range from {
+		if _, ok := to[path]; !ok {
+			diff.PoliciesRemoved = append(diff.PoliciesRemoved, path)
+		}
+	}
+
+	sort.Strings(diff.PoliciesAdded)
+	sort.Strings(diff.PoliciesRemoved)
+	sort.Strings(diff.PoliciesModified)
+}
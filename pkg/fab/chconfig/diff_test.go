@@ -0,0 +1,209 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	ob "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	from := newMockConfig(t, &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:               "Admins",
+			MSPNames:                []string{"Org1MSP", "Org2MSP"},
+			OrdererAddress:          "localhost:7050",
+			RootCA:                  "root-ca",
+			ChannelCapabilities:     []string{fab.V1_1Capability},
+			ApplicationCapabilities: []string{fab.V1_1Capability},
+		},
+	})
+
+	to := newMockConfig(t, &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:               "Admins",
+			MSPNames:                []string{"Org1MSP", "Org3MSP"},
+			OrdererAddress:          "localhost:8050",
+			RootCA:                  "root-ca",
+			ChannelCapabilities:     []string{fab.V1_1Capability},
+			ApplicationCapabilities: []string{fab.V1_2Capability},
+		},
+	})
+
+	diff, err := Diff(from, to)
+	require.NoError(t, err)
+	assert.False(t, diff.IsEmpty())
+
+	assert.Equal(t, []string{"/Channel/Application/Org3MSP:Org3MSP"}, diff.MSPsAdded)
+	assert.Equal(t, []string{"/Channel/Application/Org2MSP:Org2MSP"}, diff.MSPsRemoved)
+	assert.Empty(t, diff.MSPsModified)
+
+	assert.Equal(t, []string{"localhost:8050"}, diff.OrderersAdded)
+	assert.Equal(t, []string{"localhost:7050"}, diff.OrderersRemoved)
+
+	assert.Equal(t, map[fab.ConfigGroupKey][]string{fab.ApplicationGroupKey: {fab.V1_2Capability}}, diff.CapabilitiesAdded)
+	assert.Equal(t, map[fab.ConfigGroupKey][]string{fab.ApplicationGroupKey: {fab.V1_1Capability}}, diff.CapabilitiesRemoved)
+
+	assert.Equal(t, []string{"/Channel/Application/Org3MSP/Admins", "/Channel/Application/Org3MSP/Readers", "/Channel/Application/Org3MSP/Writers"}, diff.PoliciesAdded)
+	assert.Equal(t, []string{"/Channel/Application/Org2MSP/Admins", "/Channel/Application/Org2MSP/Readers", "/Channel/Application/Org2MSP/Writers"}, diff.PoliciesRemoved)
+	assert.Empty(t, diff.PoliciesModified)
+
+	diff, err = Diff(from, from)
+	require.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+}
+
+func TestDiffModified(t *testing.T) {
+	from := newMockConfig(t, &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			MSPNames: []string{"Org1MSP"},
+			RootCA:   "root-ca",
+		},
+	})
+
+	to := newMockConfig(t, &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			MSPNames: []string{"Org1MSP"},
+			RootCA:   "new-root-ca",
+		},
+	})
+	to.ChannelGroup.Policies["Admins"].Policy = &common.Policy{Type: int32(common.Policy_IMPLICIT_META)}
+
+	diff, err := Diff(from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/Channel/Application/Org1MSP:Org1MSP", "/Channel/Orderer/OrdererMSP:OrdererMSP"}, diff.MSPsModified)
+	assert.Equal(t, []string{"/Channel/Admins"}, diff.PoliciesModified)
+	assert.Nil(t, diff.OrdererSettingsTo)
+
+	_, err = Diff(nil, to)
+	assert.Error(t, err)
+}
+
+func TestDiffOrdererSettings(t *testing.T) {
+	from := newMockConfig(t, &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			MSPNames: []string{"Org1MSP"},
+			RootCA:   "root-ca",
+		},
+	})
+	batchTimeout, err := proto.Marshal(&ob.BatchTimeout{Timeout: "2s"})
+	require.NoError(t, err)
+	from.ChannelGroup.Groups["Orderer"].Values["BatchTimeout"].Value = batchTimeout
+
+	to := proto.Clone(from).(*common.Config)
+	batchTimeout, err = proto.Marshal(&ob.BatchTimeout{Timeout: "500ms"})
+	require.NoError(t, err)
+	to.ChannelGroup.Groups["Orderer"].Values["BatchTimeout"].Value = batchTimeout
+
+	diff, err := Diff(from, to)
+	require.NoError(t, err)
+	require.NotNil(t, diff.OrdererSettingsFrom)
+	require.NotNil(t, diff.OrdererSettingsTo)
+	assert.Equal(t, 2*time.Second, diff.OrdererSettingsFrom.BatchTimeout)
+	assert.Equal(t, 500*time.Millisecond, diff.OrdererSettingsTo.BatchTimeout)
+
+	// The orderer settings are removed with the orderer group
+	delete(to.ChannelGroup.Groups, "Orderer")
+	diff, err = Diff(from, to)
+	require.NoError(t, err)
+	assert.NotNil(t, diff.OrdererSettingsFrom)
+	assert.Nil(t, diff.OrdererSettingsTo)
+	assert.False(t, diff.IsEmpty())
+
+	to = proto.Clone(from).(*common.Config)
+	batchTimeout, err = proto.Marshal(&ob.BatchTimeout{Timeout: "500"})
+	require.NoError(t, err)
+	to.ChannelGroup.Groups["Orderer"].Values["BatchTimeout"].Value = batchTimeout
+	_, err = Diff(from, to)
+	assert.Error(t, err, "expecting error for batch timeout without unit")
+}
+
+func TestDiffMSPInMultipleGroups(t *testing.T) {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			MSPNames: []string{"Org1MSP"},
+			RootCA:   "root-ca",
+		},
+	}
+
+	// Org1MSP is also an orderer organization
+	from := newMockConfig(t, builder)
+	from.ChannelGroup.Groups["Orderer"].Groups["Org1MSP"] = proto.Clone(from.ChannelGroup.Groups["Application"].Groups["Org1MSP"]).(*common.ConfigGroup)
+
+	// Only the definition of Org1MSP in the Orderer group changes
+	to := newMockConfig(t, builder)
+	to.ChannelGroup.Groups["Orderer"].Groups["Org1MSP"] = proto.Clone(newMockConfig(t, &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			MSPNames: []string{"Org1MSP"},
+			RootCA:   "new-root-ca",
+		},
+	}).ChannelGroup.Groups["Application"].Groups["Org1MSP"]).(*common.ConfigGroup)
+
+	diff, err := Diff(from, to)
+	require.NoError(t, err)
+	assert.Empty(t, diff.MSPsAdded)
+	assert.Empty(t, diff.MSPsRemoved)
+	assert.Equal(t, []string{"/Channel/Orderer/Org1MSP:Org1MSP"}, diff.MSPsModified)
+}
+
+func TestDiffACLs(t *testing.T) {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			MSPNames: []string{"Org1MSP"},
+			RootCA:   "root-ca",
+		},
+	}
+
+	from := newMockConfig(t, builder)
+	setACLs(t, from, map[string]string{
+		"peer/Propose":          "/Channel/Application/Writers",
+		"qscc/GetBlockByNumber": "/Channel/Application/Readers",
+		"cscc/GetConfigBlock":   "/Channel/Application/Readers",
+	})
+
+	to := newMockConfig(t, builder)
+	setACLs(t, to, map[string]string{
+		"peer/Propose":           "/Channel/Application/Writers",
+		"qscc/GetBlockByNumber":  "/Channel/Application/Admins",
+		"lscc/GetDeploymentSpec": "/Channel/Application/Readers",
+	})
+
+	diff, err := Diff(from, to)
+	require.NoError(t, err)
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, []string{"lscc/GetDeploymentSpec"}, diff.ACLsAdded)
+	assert.Equal(t, []string{"cscc/GetConfigBlock"}, diff.ACLsRemoved)
+	assert.Equal(t, []string{"qscc/GetBlockByNumber"}, diff.ACLsModified)
+}
+
+func setACLs(t *testing.T, config *common.Config, policies map[string]string) {
+	acls := &pb.ACLs{Acls: make(map[string]*pb.APIResource)}
+	for resource, policy := range policies {
+		acls.Acls[resource] = &pb.APIResource{PolicyRef: policy}
+	}
+	value, err := proto.Marshal(acls)
+	require.NoError(t, err)
+	config.ChannelGroup.Groups["Application"].Values["ACLs"] = &common.ConfigValue{ModPolicy: "Admins", Value: value}
+}
+
+func newMockConfig(t *testing.T, builder *mocks.MockConfigBlockBuilder) *common.Config {
+	block := builder.Build()
+	configEnvelope, err := resource.CreateConfigEnvelope(block.Data.Data[0])
+	require.NoError(t, err)
+	return configEnvelope.Config
+}
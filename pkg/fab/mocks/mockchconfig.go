@@ -16,14 +16,17 @@ import (
 
 // MockChannelCfg contains mock channel configuration
 type MockChannelCfg struct {
-	MockID           string
-	MockBlockNumber  uint64
-	MockMSPs         []*msp.MSPConfig
-	MockAnchorPeers  []*fab.OrgAnchorPeer
-	MockOrderers     []string
-	MockVersions     *fab.Versions
-	MockMembership   fab.ChannelMembership
-	MockCapabilities map[fab.ConfigGroupKey]map[string]bool
+	MockID              string
+	MockBlockNumber     uint64
+	MockMSPs            []*msp.MSPConfig
+	MockAnchorPeers     []*fab.OrgAnchorPeer
+	MockOrderers        []string
+	MockVersions        *fab.Versions
+	MockMembership      fab.ChannelMembership
+	MockCapabilities    map[fab.ConfigGroupKey]map[string]bool
+	MockOrdererSettings *fab.OrdererSettings
+	MockACLs            map[string]string
+	MockPolicies        *fab.PolicyGroup
 }
 
 // NewMockChannelCfg ...
@@ -78,6 +81,21 @@ func (cfg *MockChannelCfg) HasCapability(group fab.ConfigGroupKey, capability st
 	return capabilities[capability]
 }
 
+// OrdererSettings returns the orderer settings
+func (cfg *MockChannelCfg) OrdererSettings() (*fab.OrdererSettings, error) {
+	return cfg.MockOrdererSettings, nil
+}
+
+// ACLs returns the ACLs
+func (cfg *MockChannelCfg) ACLs() map[string]string {
+	return cfg.MockACLs
+}
+
+// Policies returns the policy tree
+func (cfg *MockChannelCfg) Policies() *fab.PolicyGroup {
+	return cfg.MockPolicies
+}
+
 // MockChannelConfig mockcore query channel configuration
 type MockChannelConfig struct {
 	channelID string
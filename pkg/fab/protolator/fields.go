@@ -0,0 +1,272 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protolator
+
+import (
+	"encoding/base64"
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+// configValues maps the names of config values to the type of message they contain
+var configValues = map[string]func() proto.Message{
+	// Channel group values
+	"HashingAlgorithm":          func() proto.Message { return &common.HashingAlgorithm{} },
+	"BlockDataHashingStructure": func() proto.Message { return &common.BlockDataHashingStructure{} },
+	"OrdererAddresses":          func() proto.Message { return &common.OrdererAddresses{} },
+	"Consortium":                func() proto.Message { return &common.Consortium{} },
+	"Capabilities":              func() proto.Message { return &common.Capabilities{} },
+
+	// Orderer group values
+	"ConsensusType":       func() proto.Message { return &ab.ConsensusType{} },
+	"BatchSize":           func() proto.Message { return &ab.BatchSize{} },
+	"BatchTimeout":        func() proto.Message { return &ab.BatchTimeout{} },
+	"KafkaBrokers":        func() proto.Message { return &ab.KafkaBrokers{} },
+	"ChannelRestrictions": func() proto.Message { return &ab.ChannelRestrictions{} },
+
+	// Application group values
+	"ACLs": func() proto.Message { return &pb.ACLs{} },
+
+	// Organization group values
+	"MSP":         func() proto.Message { return &mb.MSPConfig{} },
+	"AnchorPeers": func() proto.Message { return &pb.AnchorPeers{} },
+
+	// Consortium group values
+	"ChannelCreationPolicy": func() proto.Message { return &common.Policy{} },
+}
+
+// messageFields contains the fields that require translation for each of the supported message types
+var messageFields = map[reflect.Type]fieldsFunc{
+	reflect.TypeOf(&common.Block{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{nested("data", func() proto.Message { return &common.BlockData{} })}
+	},
+	reflect.TypeOf(&common.BlockData{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{repeated(opaque("data", func() proto.Message { return &common.Envelope{} }))}
+	},
+	reflect.TypeOf(&common.Envelope{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{opaque("payload", func() proto.Message { return &common.Payload{} })}
+	},
+	reflect.TypeOf(&common.Payload{}): payloadFields,
+	reflect.TypeOf(&common.Header{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{
+			opaque("channel_header", func() proto.Message { return &common.ChannelHeader{} }),
+			opaque("signature_header", func() proto.Message { return &common.SignatureHeader{} }),
+		}
+	},
+	reflect.TypeOf(&common.SignatureHeader{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{opaque("creator", func() proto.Message { return &mb.SerializedIdentity{} })}
+	},
+	reflect.TypeOf(&common.ConfigEnvelope{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{
+			nested("config", func() proto.Message { return &common.Config{} }),
+			nested("last_update", func() proto.Message { return &common.Envelope{} }),
+		}
+	},
+	reflect.TypeOf(&common.ConfigUpdateEnvelope{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{
+			opaque("config_update", func() proto.Message { return &common.ConfigUpdate{} }),
+			repeated(nested("signatures", func() proto.Message { return &common.ConfigSignature{} })),
+		}
+	},
+	reflect.TypeOf(&common.ConfigSignature{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{opaque("signature_header", func() proto.Message { return &common.SignatureHeader{} })}
+	},
+	reflect.TypeOf(&common.ConfigUpdate{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{
+			nested("read_set", func() proto.Message { return &common.ConfigGroup{} }),
+			nested("write_set", func() proto.Message { return &common.ConfigGroup{} }),
+		}
+	},
+	reflect.TypeOf(&common.Config{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{nested("channel_group", func() proto.Message { return &common.ConfigGroup{} })}
+	},
+	reflect.TypeOf(&common.ConfigGroup{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{
+			mapped(nested("groups", func() proto.Message { return &common.ConfigGroup{} })),
+			mapped(nested("values", func() proto.Message { return &common.ConfigValue{} })),
+			mapped(nested("policies", func() proto.Message { return &common.ConfigPolicy{} })),
+		}
+	},
+	reflect.TypeOf(&common.ConfigValue{}): func(obj map[string]interface{}, key string) []*field {
+		newMsg, ok := configValues[key]
+		if !ok {
+			return nil
+		}
+		return []*field{opaque("value", newMsg)}
+	},
+	reflect.TypeOf(&common.ConfigPolicy{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{nested("policy", func() proto.Message { return &common.Policy{} })}
+	},
+	reflect.TypeOf(&common.Policy{}): policyFields,
+	reflect.TypeOf(&common.SignaturePolicyEnvelope{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{repeated(nested("identities", func() proto.Message { return &mb.MSPPrincipal{} }))}
+	},
+	reflect.TypeOf(&common.ImplicitMetaPolicy{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{enum("rule", common.ImplicitMetaPolicy_Rule_name, common.ImplicitMetaPolicy_Rule_value)}
+	},
+	reflect.TypeOf(&mb.MSPPrincipal{}): principalFields,
+	reflect.TypeOf(&mb.MSPRole{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{enum("role", mb.MSPRole_MSPRoleType_name, mb.MSPRole_MSPRoleType_value)}
+	},
+	reflect.TypeOf(&mb.SerializedIdentity{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{pemBytes("id_bytes")}
+	},
+	reflect.TypeOf(&mb.MSPConfig{}): func(obj map[string]interface{}, key string) []*field {
+		// Only Fabric (type 0) MSP configurations are decoded
+		if enumValue(obj, "type", nil) != 0 {
+			return nil
+		}
+		return []*field{opaque("config", func() proto.Message { return &mb.FabricMSPConfig{} })}
+	},
+	reflect.TypeOf(&mb.FabricMSPConfig{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{
+			repeated(pemBytes("root_certs")),
+			repeated(pemBytes("intermediate_certs")),
+			repeated(pemBytes("admins")),
+			repeated(pemBytes("revocation_list")),
+			repeated(pemBytes("tls_root_certs")),
+			repeated(pemBytes("tls_intermediate_certs")),
+			nested("signing_identity", func() proto.Message { return &mb.SigningIdentityInfo{} }),
+			repeated(nested("organizational_unit_identifiers", func() proto.Message { return &mb.FabricOUIdentifier{} })),
+			nested("FabricNodeOUs", func() proto.Message { return &mb.FabricNodeOUs{} }),
+		}
+	},
+	reflect.TypeOf(&mb.SigningIdentityInfo{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{pemBytes("public_signer")}
+	},
+	reflect.TypeOf(&mb.FabricOUIdentifier{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{pemBytes("certificate")}
+	},
+	reflect.TypeOf(&mb.FabricNodeOUs{}): func(obj map[string]interface{}, key string) []*field {
+		return []*field{
+			nested("clientOUIdentifier", func() proto.Message { return &mb.FabricOUIdentifier{} }),
+			nested("peerOUIdentifier", func() proto.Message { return &mb.FabricOUIdentifier{} }),
+		}
+	},
+}
+
+func payloadFields(obj map[string]interface{}, key string) []*field {
+	switch common.HeaderType(payloadHeaderType(obj)) {
+	case common.HeaderType_CONFIG:
+		return []*field{
+			nested("header", func() proto.Message { return &common.Header{} }),
+			opaque("data", func() proto.Message { return &common.ConfigEnvelope{} }),
+		}
+	case common.HeaderType_CONFIG_UPDATE:
+		return []*field{
+			nested("header", func() proto.Message { return &common.Header{} }),
+			opaque("data", func() proto.Message { return &common.ConfigUpdateEnvelope{} }),
+		}
+	default:
+		return []*field{nested("header", func() proto.Message { return &common.Header{} })}
+	}
+}
+
+func policyFields(obj map[string]interface{}, key string) []*field {
+	switch common.Policy_PolicyType(enumValue(obj, "type", common.Policy_PolicyType_value)) {
+	case common.Policy_SIGNATURE:
+		return []*field{opaque("value", func() proto.Message { return &common.SignaturePolicyEnvelope{} })}
+	case common.Policy_IMPLICIT_META:
+		return []*field{opaque("value", func() proto.Message { return &common.ImplicitMetaPolicy{} })}
+	default:
+		return nil
+	}
+}
+
+func principalFields(obj map[string]interface{}, key string) []*field {
+	classification := enum("principal_classification", mb.MSPPrincipal_Classification_name, mb.MSPPrincipal_Classification_value)
+
+	switch mb.MSPPrincipal_Classification(enumValue(obj, classification.name, classification.enumValues)) {
+	case mb.MSPPrincipal_ROLE:
+		return []*field{classification, opaque("principal", func() proto.Message { return &mb.MSPRole{} })}
+	case mb.MSPPrincipal_ORGANIZATION_UNIT:
+		return []*field{classification, opaque("principal", func() proto.Message { return &mb.OrganizationUnit{} })}
+	case mb.MSPPrincipal_IDENTITY:
+		return []*field{classification, opaque("principal", func() proto.Message { return &mb.SerializedIdentity{} })}
+	default:
+		return []*field{classification}
+	}
+}
+
+// payloadHeaderType returns the type from the channel header of the given payload, which
+// may or may not have been translated yet. -1 is returned if the type can't be determined.
+func payloadHeaderType(obj map[string]interface{}) int32 {
+	header, ok := obj["header"].(map[string]interface{})
+	if !ok {
+		return -1
+	}
+
+	switch channelHeader := header["channel_header"].(type) {
+	case string:
+		b, err := base64.StdEncoding.DecodeString(channelHeader)
+		if err != nil {
+			return -1
+		}
+		chdr := &common.ChannelHeader{}
+		if err := proto.Unmarshal(b, chdr); err != nil {
+			return -1
+		}
+		return chdr.Type
+	case map[string]interface{}:
+		return enumValue(channelHeader, "type", common.HeaderType_value)
+	default:
+		return -1
+	}
+}
+
+// enumValue returns the value of the given numeric or enum field, which may be rendered either as a
+// number or as the name of the value. Fields that are not present have the default value 0.
+func enumValue(obj map[string]interface{}, name string, values map[string]int32) int32 {
+	value, ok := obj[name]
+	if !ok || value == nil {
+		return 0
+	}
+
+	if s, ok := value.(string); ok {
+		if n, ok := values[s]; ok {
+			return n
+		}
+	}
+
+	n, err := toInt32(value)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+func nested(name string, newMsg func() proto.Message) *field {
+	return &field{name: name, kind: nestedField, newMsg: newMsg}
+}
+
+func opaque(name string, newMsg func() proto.Message) *field {
+	return &field{name: name, kind: opaqueField, newMsg: newMsg}
+}
+
+func pemBytes(name string) *field {
+	return &field{name: name, kind: pemField}
+}
+
+func enum(name string, names map[int32]string, values map[string]int32) *field {
+	return &field{name: name, kind: enumField, enumNames: names, enumValues: values}
+}
+
+func repeated(f *field) *field {
+	f.shape = repeatedValue
+	return f
+}
+
+func mapped(f *field) *field {
+	f.shape = mapValue
+	return f
+}
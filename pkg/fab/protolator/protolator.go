@@ -0,0 +1,349 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package protolator translates channel configuration protos (config blocks, envelopes, config updates
+// and configs) to and from human readable JSON.
+//
+// Unlike a plain JSON encoding of the protos, the embedded (marshalled) protos such as MSP configurations,
+// policies, anchor peers and orderer batch sizes are decoded in place, and certificates are rendered as PEM
+// text. The JSON produced by DeepMarshalJSON may be edited and converted back with DeepUnmarshalJSON.
+package protolator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+type fieldKind int
+
+const (
+	// nestedField is a message field
+	nestedField fieldKind = iota
+	// opaqueField is a bytes field that contains a marshalled message
+	opaqueField
+	// pemField is a bytes field that contains PEM encoded data (usually a certificate)
+	pemField
+	// enumField is an enum field (rendered using the name of the value)
+	enumField
+)
+
+type fieldShape int
+
+const (
+	singleValue fieldShape = iota
+	repeatedValue
+	mapValue
+)
+
+// field describes a field of a message which requires translation
+type field struct {
+	name  string
+	kind  fieldKind
+	shape fieldShape
+	// newMsg returns a new instance of the nested or embedded message
+	newMsg func() proto.Message
+	// enumNames and enumValues map enum values to names and vice versa
+	enumNames  map[int32]string
+	enumValues map[string]int32
+}
+
+// fieldsFunc returns the fields of a message which require translation. obj is the JSON representation
+// of the message and key is the map key under which the message appears (if any).
+type fieldsFunc func(obj map[string]interface{}, key string) []*field
+
+// DeepMarshalJSON marshals the given message to JSON, decoding embedded messages in place.
+func DeepMarshalJSON(w io.Writer, msg proto.Message) error {
+	obj, err := messageToMap(msg)
+	if err != nil {
+		return err
+	}
+
+	if err := (&translator{decode: true}).translate(obj, msg, ""); err != nil {
+		return err
+	}
+
+	jsonBytes, err := json.MarshalIndent(obj, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "marshal JSON failed")
+	}
+
+	_, err = w.Write(jsonBytes)
+	return errors.Wrap(err, "write JSON failed")
+}
+
+// DeepUnmarshalJSON unmarshals JSON produced by DeepMarshalJSON into the given message,
+// re-encoding the embedded messages.
+func DeepUnmarshalJSON(r io.Reader, msg proto.Message) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	obj := make(map[string]interface{})
+	if err := decoder.Decode(&obj); err != nil {
+		return errors.Wrap(err, "unmarshal JSON failed")
+	}
+
+	if err := (&translator{}).translate(obj, msg, ""); err != nil {
+		return err
+	}
+
+	return mapToMessage(obj, msg)
+}
+
+type translator struct {
+	// decode is true when translating from proto JSON to deep JSON
+	decode bool
+}
+
+func (t *translator) translate(obj map[string]interface{}, msg proto.Message, key string) error {
+	fieldsFn, ok := messageFields[reflect.TypeOf(msg)]
+	if !ok {
+		return nil
+	}
+
+	for _, f := range fieldsFn(obj, key) {
+		value, ok := obj[f.name]
+		if !ok || value == nil {
+			continue
+		}
+
+		if err := t.translateField(obj, f, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// translateField translates the value of the field, which may be a single value, an array or a map of values
+func (t *translator) translateField(obj map[string]interface{}, f *field, value interface{}) error {
+	switch f.shape {
+	case repeatedValue:
+		return t.translateRepeated(f, value)
+	case mapValue:
+		return t.translateMap(f, value)
+	default:
+		var err error
+		if obj[f.name], err = t.translateValue(f, value, ""); err != nil {
+			return errors.WithMessage(err, f.name)
+		}
+		return nil
+	}
+}
+
+func (t *translator) translateRepeated(f *field, value interface{}) error {
+	values, ok := value.([]interface{})
+	if !ok {
+		return errors.Errorf("field %s must be an array", f.name)
+	}
+
+	var err error
+	for i, v := range values {
+		if values[i], err = t.translateValue(f, v, ""); err != nil {
+			return errors.WithMessage(err, f.name)
+		}
+	}
+	return nil
+}
+
+func (t *translator) translateMap(f *field, value interface{}) error {
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("field %s must be an object", f.name)
+	}
+
+	var err error
+	for k, v := range values {
+		if values[k], err = t.translateValue(f, v, k); err != nil {
+			return errors.WithMessage(err, f.name+"."+k)
+		}
+	}
+	return nil
+}
+
+func (t *translator) translateValue(f *field, value interface{}, key string) (interface{}, error) {
+	switch f.kind {
+	case nestedField:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("value must be an object")
+		}
+		return obj, t.translate(obj, f.newMsg(), key)
+	case opaqueField:
+		if t.decode {
+			return t.decodeOpaque(f.newMsg(), value, key)
+		}
+		return t.encodeOpaque(f.newMsg(), value, key)
+	case pemField:
+		if t.decode {
+			return decodePEM(value)
+		}
+		return encodePEM(value)
+	case enumField:
+		if t.decode {
+			return decodeEnum(f, value)
+		}
+		return encodeEnum(f, value)
+	default:
+		return nil, errors.Errorf("unsupported field kind %d", f.kind)
+	}
+}
+
+// decodeOpaque converts a base64 encoded marshalled message into its (deep) JSON representation
+func (t *translator) decodeOpaque(msg proto.Message, value interface{}, key string) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("value must be a base64 encoded string")
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "base64 decode failed")
+	}
+	if err := proto.Unmarshal(b, msg); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal %T failed", msg)
+	}
+
+	obj, err := messageToMap(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj, t.translate(obj, msg, key)
+}
+
+// encodeOpaque converts the (deep) JSON representation of a message into the base64 encoded marshalled message
+func (t *translator) encodeOpaque(msg proto.Message, value interface{}, key string) (interface{}, error) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("value must be an object")
+	}
+	if err := t.translate(obj, msg, key); err != nil {
+		return nil, err
+	}
+	if err := mapToMessage(obj, msg); err != nil {
+		return nil, err
+	}
+
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshal %T failed", msg)
+	}
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// decodePEM renders PEM encoded bytes as text. Values that are not valid PEM are left base64 encoded.
+func decodePEM(value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("value must be a base64 encoded string")
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "base64 decode failed")
+	}
+	if block, _ := pem.Decode(b); block == nil || !utf8.Valid(b) {
+		return s, nil
+	}
+	return string(b), nil
+}
+
+func encodePEM(value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("value must be a string")
+	}
+	if !strings.HasPrefix(strings.TrimSpace(s), "-----BEGIN") {
+		// Not PEM text, so the value was left base64 encoded
+		return s, nil
+	}
+	return base64.StdEncoding.EncodeToString([]byte(s)), nil
+}
+
+func decodeEnum(f *field, value interface{}) (interface{}, error) {
+	n, err := toInt32(value)
+	if err != nil {
+		return nil, err
+	}
+	name, ok := f.enumNames[n]
+	if !ok {
+		return value, nil
+	}
+	return name, nil
+}
+
+func encodeEnum(f *field, value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		// Numeric value
+		return value, nil
+	}
+	n, ok := f.enumValues[s]
+	if !ok {
+		return nil, errors.Errorf("unknown enum value %s", s)
+	}
+	return json.Number(strconv.Itoa(int(n))), nil
+}
+
+func toInt32(value interface{}) (int32, error) {
+	switch v := value.(type) {
+	case json.Number:
+		n, err := v.Int64()
+		return int32(n), errors.Wrap(err, "invalid number")
+	case float64:
+		return int32(v), nil
+	case string:
+		var n int32
+		err := json.Unmarshal([]byte(v), &n)
+		return n, errors.Wrap(err, "invalid number")
+	default:
+		return 0, errors.Errorf("unexpected value type %T", value)
+	}
+}
+
+// messageToMap converts the given message to a generic JSON object
+func messageToMap(msg proto.Message) (map[string]interface{}, error) {
+	marshaler := &jsonpb.Marshaler{OrigName: true, EnumsAsInts: true}
+
+	var buf bytes.Buffer
+	if err := marshaler.Marshal(&buf, msg); err != nil {
+		return nil, errors.Wrapf(err, "JSON marshal %T failed", msg)
+	}
+
+	decoder := json.NewDecoder(&buf)
+	decoder.UseNumber()
+
+	obj := make(map[string]interface{})
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, errors.Wrap(err, "unmarshal JSON failed")
+	}
+
+	return obj, nil
+}
+
+// mapToMessage populates the given message from a generic JSON object
+func mapToMessage(obj map[string]interface{}, msg proto.Message) error {
+	jsonBytes, err := json.Marshal(obj)
+	if err != nil {
+		return errors.Wrap(err, "marshal JSON failed")
+	}
+
+	if err := jsonpb.Unmarshal(bytes.NewReader(jsonBytes), msg); err != nil {
+		return errors.Wrapf(err, "JSON unmarshal %T failed", msg)
+	}
+
+	return nil
+}
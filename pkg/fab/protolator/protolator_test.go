@@ -0,0 +1,274 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protolator
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	cert := newCertPEM(t)
+	config := newTestConfig(t, cert)
+
+	var buf bytes.Buffer
+	require.NoError(t, DeepMarshalJSON(&buf, config))
+
+	obj := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+
+	org := path(t, obj, "channel_group", "groups", "Application", "groups", "Org1MSP")
+
+	// Embedded MSP config is decoded and certificates are rendered as PEM
+	mspConfig := path(t, org, "values", "MSP", "value", "config")
+	assert.Equal(t, "Org1MSP", mspConfig["name"])
+	assert.Equal(t, []interface{}{string(cert)}, mspConfig["root_certs"])
+
+	anchorPeers := path(t, org, "values", "AnchorPeers", "value")
+	assert.Equal(t, "peer0.org1.example.com", anchorPeers["anchor_peers"].([]interface{})[0].(map[string]interface{})["host"])
+
+	// Policies are decoded, including enums
+	policy := path(t, org, "policies", "Admins", "policy", "value")
+	identities := policy["identities"].([]interface{})
+	require.Len(t, identities, 2)
+	role := identities[0].(map[string]interface{})
+	assert.Equal(t, "ADMIN", role["principal"].(map[string]interface{})["role"])
+	assert.Equal(t, "Org1MSP", role["principal"].(map[string]interface{})["msp_identifier"])
+	identity := identities[1].(map[string]interface{})
+	assert.Equal(t, "IDENTITY", identity["principal_classification"])
+	assert.Equal(t, string(cert), identity["principal"].(map[string]interface{})["id_bytes"])
+
+	implicitMeta := path(t, obj, "channel_group", "policies", "Admins", "policy", "value")
+	assert.Equal(t, "MAJORITY", implicitMeta["rule"])
+
+	batchSize := path(t, obj, "channel_group", "groups", "Orderer", "values", "BatchSize", "value")
+	assert.Equal(t, float64(10), batchSize["max_message_count"])
+
+	// Unknown values are left as is
+	assert.IsType(t, "", path(t, obj, "channel_group", "values", "Unknown")["value"])
+
+	// Convert back
+	unmarshalled := &common.Config{}
+	require.NoError(t, DeepUnmarshalJSON(bytes.NewReader(buf.Bytes()), unmarshalled))
+	assertEquivalent(t, config, unmarshalled)
+
+	// Modify the JSON and convert back
+	mspConfig["name"] = "ModifiedMSP"
+	modifiedJSON, err := json.Marshal(obj)
+	require.NoError(t, err)
+
+	modified := &common.Config{}
+	require.NoError(t, DeepUnmarshalJSON(bytes.NewReader(modifiedJSON), modified))
+
+	mspValue := &mb.MSPConfig{}
+	require.NoError(t, proto.Unmarshal(modified.ChannelGroup.Groups["Application"].Groups["Org1MSP"].Values["MSP"].Value, mspValue))
+	fabricMSPConfig := &mb.FabricMSPConfig{}
+	require.NoError(t, proto.Unmarshal(mspValue.Config, fabricMSPConfig))
+	assert.Equal(t, "ModifiedMSP", fabricMSPConfig.Name)
+	assert.Equal(t, [][]byte{cert}, fabricMSPConfig.RootCerts)
+}
+
+func TestBlockRoundTrip(t *testing.T) {
+	config := newTestConfig(t, newCertPEM(t))
+
+	configEnvelope := &common.ConfigEnvelope{Config: config}
+	block := &common.Block{
+		Header: &common.BlockHeader{Number: 1},
+		Data: &common.BlockData{Data: [][]byte{marshal(t, &common.Envelope{
+			Payload: marshal(t, &common.Payload{
+				Header: &common.Header{
+					ChannelHeader:   marshal(t, &common.ChannelHeader{Type: int32(common.HeaderType_CONFIG), ChannelId: "mychannel"}),
+					SignatureHeader: marshal(t, &common.SignatureHeader{Creator: marshal(t, &mb.SerializedIdentity{Mspid: "OrdererMSP"})}),
+				},
+				Data: marshal(t, configEnvelope),
+			}),
+		})}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, DeepMarshalJSON(&buf, block))
+
+	obj := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+
+	envelope := obj["data"].(map[string]interface{})["data"].([]interface{})[0].(map[string]interface{})
+	payload := path(t, envelope, "payload")
+	assert.Equal(t, "mychannel", path(t, payload, "header", "channel_header")["channel_id"])
+	assert.Equal(t, "OrdererMSP", path(t, payload, "header", "signature_header", "creator")["mspid"])
+	assert.Contains(t, path(t, payload, "data", "config", "channel_group", "groups"), "Orderer")
+
+	unmarshalled := &common.Block{}
+	require.NoError(t, DeepUnmarshalJSON(bytes.NewReader(buf.Bytes()), unmarshalled))
+	assertEquivalent(t, block, unmarshalled)
+}
+
+func TestConfigUpdateEnvelopeRoundTrip(t *testing.T) {
+	configUpdate := &common.ConfigUpdate{
+		ChannelId: "mychannel",
+		ReadSet:   &common.ConfigGroup{Version: 1},
+		WriteSet: &common.ConfigGroup{
+			Version: 2,
+			Values: map[string]*common.ConfigValue{
+				"BatchTimeout": {Value: marshal(t, &ab.BatchTimeout{Timeout: "5s"}), ModPolicy: "Admins"},
+			},
+		},
+	}
+	envelope := &common.ConfigUpdateEnvelope{
+		ConfigUpdate: marshal(t, configUpdate),
+		Signatures: []*common.ConfigSignature{{
+			SignatureHeader: marshal(t, &common.SignatureHeader{Creator: marshal(t, &mb.SerializedIdentity{Mspid: "Org1MSP"})}),
+			Signature:       []byte("signature"),
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, DeepMarshalJSON(&buf, envelope))
+	assert.Contains(t, buf.String(), `"timeout": "5s"`)
+	assert.Contains(t, buf.String(), `"mspid": "Org1MSP"`)
+
+	unmarshalled := &common.ConfigUpdateEnvelope{}
+	require.NoError(t, DeepUnmarshalJSON(bytes.NewReader(buf.Bytes()), unmarshalled))
+	assertEquivalent(t, envelope, unmarshalled)
+}
+
+func TestInvalidJSON(t *testing.T) {
+	err := DeepUnmarshalJSON(strings.NewReader("{"), &common.Config{})
+	assert.Error(t, err)
+
+	err = DeepUnmarshalJSON(strings.NewReader(`{"channel_group": {"values": {"BatchSize": {"value": "not an object"}}}}`), &common.Config{})
+	assert.Error(t, err)
+
+	err = DeepUnmarshalJSON(strings.NewReader(`{"channel_group": {"unknown_field": 1}}`), &common.Config{})
+	assert.Error(t, err)
+
+	err = DeepMarshalJSON(&bytes.Buffer{}, &common.Config{ChannelGroup: &common.ConfigGroup{
+		Values: map[string]*common.ConfigValue{"BatchSize": {Value: []byte("invalid")}},
+	}})
+	assert.Error(t, err)
+}
+
+// assertEquivalent asserts that the deep JSON representations of the messages are equal, since the
+// marshalled bytes of embedded messages with map fields are not deterministic
+func assertEquivalent(t *testing.T, expected, actual proto.Message) {
+	var expectedJSON, actualJSON bytes.Buffer
+	require.NoError(t, DeepMarshalJSON(&expectedJSON, expected))
+	require.NoError(t, DeepMarshalJSON(&actualJSON, actual))
+	assert.JSONEq(t, expectedJSON.String(), actualJSON.String())
+}
+
+func path(t *testing.T, obj map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, key := range keys {
+		next, ok := obj[key].(map[string]interface{})
+		require.True(t, ok, "expecting object at %s", key)
+		obj = next
+	}
+	return obj
+}
+
+func newTestConfig(t *testing.T, cert []byte) *common.Config {
+	mspConfig := &mb.MSPConfig{
+		Config: marshal(t, &mb.FabricMSPConfig{Name: "Org1MSP", RootCerts: [][]byte{cert}}),
+	}
+
+	// Note that ROLE is the default principal classification
+	adminsPolicy := &common.Policy{
+		Type: int32(common.Policy_SIGNATURE),
+		Value: marshal(t, &common.SignaturePolicyEnvelope{
+			Rule: &common.SignaturePolicy{Type: &common.SignaturePolicy_SignedBy{SignedBy: 0}},
+			Identities: []*mb.MSPPrincipal{{
+				PrincipalClassification: mb.MSPPrincipal_ROLE,
+				Principal:               marshal(t, &mb.MSPRole{MspIdentifier: "Org1MSP", Role: mb.MSPRole_ADMIN}),
+			}, {
+				PrincipalClassification: mb.MSPPrincipal_IDENTITY,
+				Principal:               marshal(t, &mb.SerializedIdentity{Mspid: "Org1MSP", IdBytes: cert}),
+			}},
+		}),
+	}
+
+	return &common.Config{
+		Sequence: 3,
+		ChannelGroup: &common.ConfigGroup{
+			ModPolicy: "Admins",
+			Values: map[string]*common.ConfigValue{
+				"HashingAlgorithm": {Value: marshal(t, &common.HashingAlgorithm{Name: "SHA256"}), ModPolicy: "Admins"},
+				"OrdererAddresses": {Value: marshal(t, &common.OrdererAddresses{Addresses: []string{"orderer.example.com:7050"}}), ModPolicy: "/Channel/Orderer/Admins"},
+				"Unknown":          {Value: []byte("unknown")},
+			},
+			Policies: map[string]*common.ConfigPolicy{
+				"Admins": {
+					ModPolicy: "Admins",
+					Policy: &common.Policy{
+						Type:  int32(common.Policy_IMPLICIT_META),
+						Value: marshal(t, &common.ImplicitMetaPolicy{SubPolicy: "Admins", Rule: common.ImplicitMetaPolicy_MAJORITY}),
+					},
+				},
+			},
+			Groups: map[string]*common.ConfigGroup{
+				"Orderer": {
+					Version: 1,
+					Values: map[string]*common.ConfigValue{
+						"BatchSize": {Value: marshal(t, &ab.BatchSize{MaxMessageCount: 10, AbsoluteMaxBytes: 1024}), ModPolicy: "Admins"},
+					},
+				},
+				"Application": {
+					Version: 1,
+					Groups: map[string]*common.ConfigGroup{
+						"Org1MSP": {
+							Values: map[string]*common.ConfigValue{
+								"MSP":         {Value: marshal(t, mspConfig), ModPolicy: "Admins"},
+								"AnchorPeers": {Value: marshal(t, &pb.AnchorPeers{AnchorPeers: []*pb.AnchorPeer{{Host: "peer0.org1.example.com", Port: 7051}}}), ModPolicy: "Admins"},
+							},
+							Policies: map[string]*common.ConfigPolicy{
+								"Admins": {Policy: adminsPolicy, ModPolicy: "Admins"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newCertPEM(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ca.org1.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+}
+
+func marshal(t *testing.T, msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	return b
+}
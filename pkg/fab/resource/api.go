@@ -42,6 +42,9 @@ type InstallChaincodeRequest struct {
 	Version string
 	// required - package (chaincode package type and bytes)
 	Package *CCPackage
+	// optional - a pre-built chaincode deployment package (see CCDeploymentPackage), which is installed as is
+	// instead of Package. The name, path and version default to those of the deployment package.
+	DeploymentPackage []byte
 }
 
 // JoinChannelRequest allows a set of peers to transact on a channel on the network
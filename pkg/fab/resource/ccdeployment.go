@@ -0,0 +1,373 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resource
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	fcutils "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	common "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// CCDeploymentPackage is a chaincode deployment package, as installed on the peers. A deployment package is either
+// a ChaincodeDeploymentSpec or a signed deployment package: an envelope that contains a SignedChaincodeDeploymentSpec,
+// i.e. the deployment spec along with an instantiation policy and the endorsements of the chaincode owners.
+type CCDeploymentPackage struct {
+	DeploymentSpec *pb.ChaincodeDeploymentSpec
+	// nil if the deployment package isn't signed
+	InstantiationPolicy *common.SignaturePolicyEnvelope
+	OwnerEndorsements   []*pb.Endorsement
+
+	instantiationPolicyBytes []byte
+}
+
+// Signed returns true if the deployment package is a signed deployment package
+func (p *CCDeploymentPackage) Signed() bool {
+	return p.InstantiationPolicy != nil
+}
+
+// ChaincodeID returns the ID (name, path and version) of the chaincode in the deployment package
+func (p *CCDeploymentPackage) ChaincodeID() *pb.ChaincodeID {
+	return p.DeploymentSpec.ChaincodeSpec.ChaincodeId
+}
+
+// Fingerprint returns the fingerprint of the chaincode in the deployment package, which is computed in the same way
+// as the ID of an installed chaincode reported by the peer (see ChaincodeInfo). The fingerprint depends on the code
+// package, the chaincode name and version and, for signed deployment packages, on the instantiation policy and
+// the chaincode owners.
+func (p *CCDeploymentPackage) Fingerprint() []byte {
+	ccID := p.ChaincodeID()
+
+	codeHash := sha256.Sum256(p.DeploymentSpec.CodePackage)
+	metadataHash := sha256.Sum256([]byte(ccID.Name + ccID.Version))
+	hashes := [][]byte{codeHash[:], metadataHash[:]}
+
+	if p.Signed() {
+		signers := [][]byte{p.instantiationPolicyBytes}
+		for _, endorsement := range p.OwnerEndorsements {
+			signers = append(signers, endorsement.Endorser)
+		}
+		signatureHash := sha256.Sum256(fcutils.ConcatenateBytes(signers...))
+		hashes = append(hashes, signatureHash[:])
+	}
+
+	fingerprint := sha256.Sum256(fcutils.ConcatenateBytes(hashes...))
+	return fingerprint[:]
+}
+
+// CreateChaincodeDeploymentSpec creates the deployment spec of a chaincode package.
+func CreateChaincodeDeploymentSpec(name string, path string, version string, ccPackage *CCPackage) (*pb.ChaincodeDeploymentSpec, error) {
+	if name == "" || path == "" || version == "" {
+		return nil, errors.New("chaincode name, path and version are required")
+	}
+	if ccPackage == nil {
+		return nil, errors.New("chaincode package is required")
+	}
+
+	return &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        ccPackage.Type,
+			ChaincodeId: &pb.ChaincodeID{Name: name, Path: path, Version: version},
+		},
+		CodePackage: ccPackage.Code,
+	}, nil
+}
+
+// CreateSignedCCDeploymentPackage creates a signed chaincode deployment package, which is endorsed by the user
+// of the given context as an owner of the chaincode. Other owners may add their endorsements with
+// SignCCDeploymentPackage; the packages endorsed by each owner may also be merged with MergeCCDeploymentPackages.
+//  Parameters:
+//  ctx is the context of the chaincode owner
+//  cds is the chaincode deployment spec
+//  instantiationPolicy is the policy that must be satisfied by the signers of instantiate and upgrade proposals
+//
+//  Returns:
+//  the signed deployment package
+func CreateSignedCCDeploymentPackage(ctx context.Client, cds *pb.ChaincodeDeploymentSpec, instantiationPolicy *common.SignaturePolicyEnvelope) ([]byte, error) {
+	if cds == nil || cds.ChaincodeSpec == nil || cds.ChaincodeSpec.ChaincodeId == nil {
+		return nil, errors.New("chaincode deployment spec is required")
+	}
+	if instantiationPolicy == nil {
+		return nil, errors.New("instantiation policy is required")
+	}
+
+	cdsBytes, err := proto.Marshal(cds)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of chaincode deployment spec failed")
+	}
+	policyBytes, err := proto.Marshal(instantiationPolicy)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of instantiation policy failed")
+	}
+
+	signedCDS := &pb.SignedChaincodeDeploymentSpec{
+		ChaincodeDeploymentSpec: cdsBytes,
+		InstantiationPolicy:     policyBytes,
+	}
+
+	return signCCDeploymentPackage(ctx, signedCDS)
+}
+
+// SignCCDeploymentPackage adds the endorsement of the user of the given context, as an owner of the chaincode,
+// to a signed chaincode deployment package.
+//  Parameters:
+//  ctx is the context of the chaincode owner
+//  deploymentPackage is the signed deployment package
+//
+//  Returns:
+//  the deployment package with the owner's endorsement
+func SignCCDeploymentPackage(ctx context.Client, deploymentPackage []byte) ([]byte, error) {
+	_, signedCDS, err := unmarshalSignedCCDeploymentPackage(deploymentPackage)
+	if err != nil {
+		return nil, err
+	}
+
+	return signCCDeploymentPackage(ctx, signedCDS)
+}
+
+// MergeCCDeploymentPackages merges the endorsements of signed chaincode deployment packages that were endorsed
+// by different owners, which must contain the same deployment spec and instantiation policy.
+//  Parameters:
+//  deploymentPackages are the signed deployment packages
+//
+//  Returns:
+//  the deployment package with the endorsements of all owners
+func MergeCCDeploymentPackages(deploymentPackages ...[]byte) ([]byte, error) {
+	if len(deploymentPackages) == 0 {
+		return nil, errors.New("deployment packages are required")
+	}
+
+	header, merged, err := unmarshalSignedCCDeploymentPackage(deploymentPackages[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deploymentPackage := range deploymentPackages[1:] {
+		_, signedCDS, err := unmarshalSignedCCDeploymentPackage(deploymentPackage)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(signedCDS.ChaincodeDeploymentSpec, merged.ChaincodeDeploymentSpec) {
+			return nil, errors.New("deployment packages have different chaincode deployment specs")
+		}
+		if !bytes.Equal(signedCDS.InstantiationPolicy, merged.InstantiationPolicy) {
+			return nil, errors.New("deployment packages have different instantiation policies")
+		}
+		for _, endorsement := range signedCDS.OwnerEndorsements {
+			if !hasEndorser(merged.OwnerEndorsements, endorsement.Endorser) {
+				merged.OwnerEndorsements = append(merged.OwnerEndorsements, endorsement)
+			}
+		}
+	}
+
+	return marshalCCDeploymentPackage(header, merged, nil)
+}
+
+// UnmarshalCCDeploymentPackage unmarshals a chaincode deployment package (either a ChaincodeDeploymentSpec or
+// a signed deployment package).
+func UnmarshalCCDeploymentPackage(deploymentPackage []byte) (*CCDeploymentPackage, error) {
+	if _, signedCDS, err := unmarshalSignedCCDeploymentPackage(deploymentPackage); err == nil {
+		cds, err := unmarshalChaincodeDeploymentSpec(signedCDS.ChaincodeDeploymentSpec)
+		if err != nil {
+			return nil, err
+		}
+		policy := &common.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(signedCDS.InstantiationPolicy, policy); err != nil {
+			return nil, errors.Wrap(err, "unmarshal of instantiation policy failed")
+		}
+		return &CCDeploymentPackage{
+			DeploymentSpec:           cds,
+			InstantiationPolicy:      policy,
+			OwnerEndorsements:        signedCDS.OwnerEndorsements,
+			instantiationPolicyBytes: signedCDS.InstantiationPolicy,
+		}, nil
+	}
+
+	cds, err := unmarshalChaincodeDeploymentSpec(deploymentPackage)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid chaincode deployment package")
+	}
+	return &CCDeploymentPackage{DeploymentSpec: cds}, nil
+}
+
+// ReadCCDeploymentPackage reads a chaincode deployment package from the given file, for example a package created
+// with the 'peer chaincode package' command.
+func ReadCCDeploymentPackage(fileName string) ([]byte, error) {
+	deploymentPackage, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading chaincode deployment package failed")
+	}
+	if _, err := UnmarshalCCDeploymentPackage(deploymentPackage); err != nil {
+		return nil, err
+	}
+	return deploymentPackage, nil
+}
+
+// WriteCCDeploymentPackage writes a chaincode deployment package to the given file.
+func WriteCCDeploymentPackage(fileName string, deploymentPackage []byte) error {
+	if _, err := UnmarshalCCDeploymentPackage(deploymentPackage); err != nil {
+		return err
+	}
+	return errors.Wrap(ioutil.WriteFile(fileName, deploymentPackage, 0644), "writing chaincode deployment package failed")
+}
+
+// ResolveDeploymentPackage returns the install request with the chaincode name, path and version of the request's
+// deployment package. The name, path and version of the request, if provided, must match those of the deployment package.
+func ResolveDeploymentPackage(req InstallChaincodeRequest) (InstallChaincodeRequest, error) {
+	if req.Package != nil {
+		return req, errors.New("chaincode package and deployment package are mutually exclusive")
+	}
+
+	deploymentPackage, err := UnmarshalCCDeploymentPackage(req.DeploymentPackage)
+	if err != nil {
+		return req, err
+	}
+	ccID := deploymentPackage.ChaincodeID()
+
+	resolved := req
+	for _, v := range []struct {
+		field, value string
+		resolved     *string
+	}{
+		{"name", ccID.Name, &resolved.Name},
+		{"path", ccID.Path, &resolved.Path},
+		{"version", ccID.Version, &resolved.Version},
+	} {
+		if *v.resolved != "" && *v.resolved != v.value {
+			return req, errors.Errorf("chaincode %s [%s] doesn't match the deployment package's chaincode %s [%s]", v.field, *v.resolved, v.field, v.value)
+		}
+		*v.resolved = v.value
+	}
+
+	return resolved, nil
+}
+
+// signCCDeploymentPackage adds the endorsement of the user of the given context to the signed deployment spec
+// and wraps it in an envelope signed by the user
+func signCCDeploymentPackage(ctx context.Client, signedCDS *pb.SignedChaincodeDeploymentSpec) ([]byte, error) {
+	owner, err := ctx.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get user context's identity")
+	}
+
+	// the owner signs the deployment spec and instantiation policy along with its identity
+	signingBytes := fcutils.ConcatenateBytes(signedCDS.ChaincodeDeploymentSpec, signedCDS.InstantiationPolicy, owner)
+	signature, err := ctx.SigningManager().Sign(signingBytes, ctx.PrivateKey())
+	if err != nil {
+		return nil, errors.WithMessage(err, "signing of chaincode deployment package failed")
+	}
+
+	endorsed := &pb.SignedChaincodeDeploymentSpec{
+		ChaincodeDeploymentSpec: signedCDS.ChaincodeDeploymentSpec,
+		InstantiationPolicy:     signedCDS.InstantiationPolicy,
+	}
+	for _, endorsement := range signedCDS.OwnerEndorsements {
+		if !bytes.Equal(endorsement.Endorser, owner) {
+			endorsed.OwnerEndorsements = append(endorsed.OwnerEndorsements, endorsement)
+		}
+	}
+	endorsed.OwnerEndorsements = append(endorsed.OwnerEndorsements, &pb.Endorsement{Endorser: owner, Signature: signature})
+
+	txh, err := txn.NewHeader(ctx, "")
+	if err != nil {
+		return nil, errors.WithMessage(err, "create transaction ID failed")
+	}
+	channelHeader, err := txn.CreateChannelHeader(common.HeaderType_CHAINCODE_PACKAGE, txn.ChannelHeaderOpts{TxnHeader: txh, Timestamp: time.Now()})
+	if err != nil {
+		return nil, errors.WithMessage(err, "create channel header failed")
+	}
+	payload, err := txn.CreatePayload(txh, channelHeader, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "create payload failed")
+	}
+
+	return marshalCCDeploymentPackage(payload.Header, endorsed, ctx)
+}
+
+// marshalCCDeploymentPackage marshals an envelope with the given header and signed deployment spec. The envelope
+// is signed by the user of the given context, if provided.
+func marshalCCDeploymentPackage(header *common.Header, signedCDS *pb.SignedChaincodeDeploymentSpec, ctx context.Client) ([]byte, error) {
+	data, err := proto.Marshal(signedCDS)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of signed chaincode deployment spec failed")
+	}
+	payloadBytes, err := proto.Marshal(&common.Payload{Header: header, Data: data})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of payload failed")
+	}
+
+	envelope := &common.Envelope{Payload: payloadBytes}
+	if ctx != nil {
+		envelope.Signature, err = ctx.SigningManager().Sign(payloadBytes, ctx.PrivateKey())
+		if err != nil {
+			return nil, errors.WithMessage(err, "signing of payload failed")
+		}
+	}
+
+	envelopeBytes, err := proto.Marshal(envelope)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of envelope failed")
+	}
+	return envelopeBytes, nil
+}
+
+// unmarshalSignedCCDeploymentPackage unmarshals a signed deployment package and returns the header of its envelope
+// and the signed deployment spec
+func unmarshalSignedCCDeploymentPackage(deploymentPackage []byte) (*common.Header, *pb.SignedChaincodeDeploymentSpec, error) {
+	envelope := &common.Envelope{}
+	if err := proto.Unmarshal(deploymentPackage, envelope); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal of envelope failed")
+	}
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal of payload failed")
+	}
+	if payload.Header == nil {
+		return nil, nil, errors.New("signed chaincode deployment package must have a header")
+	}
+	channelHeader := &common.ChannelHeader{}
+	if err := proto.Unmarshal(payload.Header.ChannelHeader, channelHeader); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal of channel header failed")
+	}
+	if channelHeader.Type != int32(common.HeaderType_CHAINCODE_PACKAGE) {
+		return nil, nil, errors.Errorf("invalid header type for signed chaincode deployment package: %d", channelHeader.Type)
+	}
+
+	signedCDS := &pb.SignedChaincodeDeploymentSpec{}
+	if err := proto.Unmarshal(payload.Data, signedCDS); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal of signed chaincode deployment spec failed")
+	}
+	return payload.Header, signedCDS, nil
+}
+
+func unmarshalChaincodeDeploymentSpec(cdsBytes []byte) (*pb.ChaincodeDeploymentSpec, error) {
+	cds := &pb.ChaincodeDeploymentSpec{}
+	if err := proto.Unmarshal(cdsBytes, cds); err != nil {
+		return nil, errors.Wrap(err, "unmarshal of chaincode deployment spec failed")
+	}
+	if cds.ChaincodeSpec == nil || cds.ChaincodeSpec.ChaincodeId == nil || cds.ChaincodeSpec.ChaincodeId.Name == "" {
+		return nil, errors.New("chaincode deployment spec must include a chaincode ID")
+	}
+	return cds, nil
+}
+
+func hasEndorser(endorsements []*pb.Endorsement, endorser []byte) bool {
+	for _, endorsement := range endorsements {
+		if bytes.Equal(endorsement.Endorser, endorser) {
+			return true
+		}
+	}
+	return false
+}
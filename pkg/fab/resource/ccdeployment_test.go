@@ -0,0 +1,258 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resource
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ownerIdentity is a signing identity with a distinct serialized identity
+type ownerIdentity struct {
+	*mspmocks.MockSigningIdentity
+	serialized []byte
+}
+
+func (o *ownerIdentity) Serialize() ([]byte, error) {
+	return o.serialized, nil
+}
+
+func setupOwnerContext(owner string) context.Client {
+	ctx := setupContext()
+	return &contextImpl.Client{
+		SigningIdentity: &ownerIdentity{MockSigningIdentity: mspmocks.NewMockSigningIdentity(owner, owner), serialized: []byte(owner)},
+		Providers:       ctx,
+	}
+}
+
+func newTestDeploymentSpec(t *testing.T) *pb.ChaincodeDeploymentSpec {
+	cds, err := CreateChaincodeDeploymentSpec("examplecc", "github.com/examplecc", "v1", &CCPackage{Type: pb.ChaincodeSpec_GOLANG, Code: []byte("code")})
+	require.NoError(t, err)
+	return cds
+}
+
+func TestCreateChaincodeDeploymentSpec(t *testing.T) {
+	cds := newTestDeploymentSpec(t)
+	assert.Equal(t, pb.ChaincodeSpec_GOLANG, cds.ChaincodeSpec.Type)
+	assert.Equal(t, &pb.ChaincodeID{Name: "examplecc", Path: "github.com/examplecc", Version: "v1"}, cds.ChaincodeSpec.ChaincodeId)
+	assert.Equal(t, []byte("code"), cds.CodePackage)
+
+	_, err := CreateChaincodeDeploymentSpec("examplecc", "", "v1", &CCPackage{})
+	assert.Error(t, err, "expecting error for missing path")
+
+	_, err = CreateChaincodeDeploymentSpec("examplecc", "github.com/examplecc", "v1", nil)
+	assert.Error(t, err, "expecting error for missing package")
+}
+
+func TestUnmarshalCCDeploymentPackage(t *testing.T) {
+	cds := newTestDeploymentSpec(t)
+	cdsBytes, err := proto.Marshal(cds)
+	require.NoError(t, err)
+
+	deploymentPackage, err := UnmarshalCCDeploymentPackage(cdsBytes)
+	require.NoError(t, err)
+	assert.False(t, deploymentPackage.Signed())
+	assert.True(t, proto.Equal(cds, deploymentPackage.DeploymentSpec))
+	assert.Equal(t, "examplecc", deploymentPackage.ChaincodeID().Name)
+
+	_, err = UnmarshalCCDeploymentPackage([]byte("invalid"))
+	assert.Error(t, err)
+
+	_, err = UnmarshalCCDeploymentPackage(nil)
+	assert.Error(t, err, "expecting error for missing chaincode ID")
+}
+
+func TestSignedCCDeploymentPackage(t *testing.T) {
+	cds := newTestDeploymentSpec(t)
+	policy := cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP"})
+
+	org1Package, err := CreateSignedCCDeploymentPackage(setupOwnerContext("Org1MSP"), cds, policy)
+	require.NoError(t, err)
+
+	deploymentPackage, err := UnmarshalCCDeploymentPackage(org1Package)
+	require.NoError(t, err)
+	assert.True(t, deploymentPackage.Signed())
+	assert.True(t, proto.Equal(cds, deploymentPackage.DeploymentSpec))
+	assert.True(t, proto.Equal(policy, deploymentPackage.InstantiationPolicy))
+	require.Len(t, deploymentPackage.OwnerEndorsements, 1)
+	assert.Equal(t, []byte("Org1MSP"), deploymentPackage.OwnerEndorsements[0].Endorser)
+	assert.NotEmpty(t, deploymentPackage.OwnerEndorsements[0].Signature)
+
+	// Org2 signs the package endorsed by Org1
+	signedPackage, err := SignCCDeploymentPackage(setupOwnerContext("Org2MSP"), org1Package)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Org1MSP", "Org2MSP"}, endorsers(t, signedPackage))
+
+	// Signing again doesn't duplicate the endorsement
+	signedPackage, err = SignCCDeploymentPackage(setupOwnerContext("Org2MSP"), signedPackage)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Org1MSP", "Org2MSP"}, endorsers(t, signedPackage))
+
+	// Org2 and Org3 endorse their own packages which are then merged
+	org2Package, err := CreateSignedCCDeploymentPackage(setupOwnerContext("Org2MSP"), cds, policy)
+	require.NoError(t, err)
+	org3Package, err := CreateSignedCCDeploymentPackage(setupOwnerContext("Org3MSP"), cds, policy)
+	require.NoError(t, err)
+
+	mergedPackage, err := MergeCCDeploymentPackages(org1Package, org2Package, org3Package, org1Package)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Org1MSP", "Org2MSP", "Org3MSP"}, endorsers(t, mergedPackage))
+}
+
+func TestSignedCCDeploymentPackageError(t *testing.T) {
+	ctx := setupOwnerContext("Org1MSP")
+	cds := newTestDeploymentSpec(t)
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+
+	_, err := CreateSignedCCDeploymentPackage(ctx, nil, policy)
+	assert.Error(t, err, "expecting error for missing deployment spec")
+
+	_, err = CreateSignedCCDeploymentPackage(ctx, cds, nil)
+	assert.Error(t, err, "expecting error for missing instantiation policy")
+
+	// A deployment spec isn't a signed deployment package
+	cdsBytes, err := proto.Marshal(cds)
+	require.NoError(t, err)
+	_, err = SignCCDeploymentPackage(ctx, cdsBytes)
+	assert.Error(t, err, "expecting error for unsigned deployment package")
+
+	_, err = MergeCCDeploymentPackages()
+	assert.Error(t, err, "expecting error for missing deployment packages")
+
+	signedPackage, err := CreateSignedCCDeploymentPackage(ctx, cds, policy)
+	require.NoError(t, err)
+
+	otherPolicyPackage, err := CreateSignedCCDeploymentPackage(ctx, cds, cauthdsl.SignedByMspMember("Org2MSP"))
+	require.NoError(t, err)
+	_, err = MergeCCDeploymentPackages(signedPackage, otherPolicyPackage)
+	assert.Error(t, err, "expecting error for different instantiation policies")
+
+	otherCDS := newTestDeploymentSpec(t)
+	otherCDS.ChaincodeSpec.ChaincodeId.Version = "v2"
+	otherCDSPackage, err := CreateSignedCCDeploymentPackage(ctx, otherCDS, policy)
+	require.NoError(t, err)
+	_, err = MergeCCDeploymentPackages(signedPackage, otherCDSPackage)
+	assert.Error(t, err, "expecting error for different deployment specs")
+}
+
+func TestFingerprint(t *testing.T) {
+	cds := newTestDeploymentSpec(t)
+
+	// Fingerprint of the chaincode as computed by the peer: hash(hash(code) + hash(name + version))
+	codeHash := sha256.Sum256([]byte("code"))
+	metadataHash := sha256.Sum256([]byte("examplecc" + "v1"))
+	expected := sha256.Sum256(append(codeHash[:], metadataHash[:]...))
+
+	deploymentPackage := &CCDeploymentPackage{DeploymentSpec: cds}
+	assert.Equal(t, expected[:], deploymentPackage.Fingerprint())
+
+	// The chaincode path isn't part of the fingerprint
+	otherPath := newTestDeploymentSpec(t)
+	otherPath.ChaincodeSpec.ChaincodeId.Path = "github.com/other"
+	assert.Equal(t, expected[:], (&CCDeploymentPackage{DeploymentSpec: otherPath}).Fingerprint())
+
+	otherCode := newTestDeploymentSpec(t)
+	otherCode.CodePackage = []byte("other code")
+	assert.NotEqual(t, expected[:], (&CCDeploymentPackage{DeploymentSpec: otherCode}).Fingerprint())
+
+	// The fingerprint of a signed deployment package also depends on the instantiation policy and owners
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+	signedPackage, err := CreateSignedCCDeploymentPackage(setupOwnerContext("Org1MSP"), cds, policy)
+	require.NoError(t, err)
+	signed, err := UnmarshalCCDeploymentPackage(signedPackage)
+	require.NoError(t, err)
+
+	policyBytes, err := proto.Marshal(policy)
+	require.NoError(t, err)
+	signatureHash := sha256.Sum256(append(policyBytes, []byte("Org1MSP")...))
+	expected = sha256.Sum256(append(append(codeHash[:], metadataHash[:]...), signatureHash[:]...))
+	assert.Equal(t, expected[:], signed.Fingerprint())
+
+	signedPackage, err = SignCCDeploymentPackage(setupOwnerContext("Org2MSP"), signedPackage)
+	require.NoError(t, err)
+	signedByBoth, err := UnmarshalCCDeploymentPackage(signedPackage)
+	require.NoError(t, err)
+	assert.NotEqual(t, signed.Fingerprint(), signedByBoth.Fingerprint())
+}
+
+func TestReadWriteCCDeploymentPackage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ccdeployment")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	signedPackage, err := CreateSignedCCDeploymentPackage(setupOwnerContext("Org1MSP"), newTestDeploymentSpec(t), cauthdsl.SignedByMspMember("Org1MSP"))
+	require.NoError(t, err)
+
+	fileName := filepath.Join(dir, "examplecc.pak")
+	require.NoError(t, WriteCCDeploymentPackage(fileName, signedPackage))
+
+	deploymentPackage, err := ReadCCDeploymentPackage(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, signedPackage, deploymentPackage, "deployment package must be read bit-for-bit")
+
+	assert.Error(t, WriteCCDeploymentPackage(fileName, []byte("invalid")), "expecting error for invalid deployment package")
+
+	require.NoError(t, ioutil.WriteFile(fileName, []byte("invalid"), 0644))
+	_, err = ReadCCDeploymentPackage(fileName)
+	assert.Error(t, err, "expecting error for invalid deployment package")
+
+	_, err = ReadCCDeploymentPackage(filepath.Join(dir, "missing.pak"))
+	assert.Error(t, err, "expecting error for missing file")
+}
+
+func TestResolveDeploymentPackage(t *testing.T) {
+	cdsBytes, err := proto.Marshal(newTestDeploymentSpec(t))
+	require.NoError(t, err)
+
+	req, err := ResolveDeploymentPackage(InstallChaincodeRequest{DeploymentPackage: cdsBytes})
+	require.NoError(t, err)
+	assert.Equal(t, "examplecc", req.Name)
+	assert.Equal(t, "github.com/examplecc", req.Path)
+	assert.Equal(t, "v1", req.Version)
+
+	_, err = ResolveDeploymentPackage(InstallChaincodeRequest{Name: "examplecc", Version: "v1", DeploymentPackage: cdsBytes})
+	assert.NoError(t, err)
+
+	_, err = ResolveDeploymentPackage(InstallChaincodeRequest{Version: "v2", DeploymentPackage: cdsBytes})
+	assert.Error(t, err, "expecting error for version mismatch")
+
+	_, err = ResolveDeploymentPackage(InstallChaincodeRequest{Package: &CCPackage{}, DeploymentPackage: cdsBytes})
+	assert.Error(t, err, "expecting error for package and deployment package")
+}
+
+func TestCreateInstallInvokeRequestWithDeploymentPackage(t *testing.T) {
+	signedPackage, err := CreateSignedCCDeploymentPackage(setupOwnerContext("Org1MSP"), newTestDeploymentSpec(t), cauthdsl.SignedByMspMember("Org1MSP"))
+	require.NoError(t, err)
+
+	cir, err := createInstallInvokeRequest(ChaincodeInstallRequest{Name: "examplecc", Path: "github.com/examplecc", Version: "v1", DeploymentPackage: signedPackage})
+	require.NoError(t, err)
+	require.Len(t, cir.Args, 1)
+	assert.Equal(t, signedPackage, cir.Args[0], "deployment package must be installed as is")
+}
+
+func endorsers(t *testing.T, signedPackage []byte) []string {
+	deploymentPackage, err := UnmarshalCCDeploymentPackage(signedPackage)
+	require.NoError(t, err)
+
+	var endorsers []string
+	for _, endorsement := range deploymentPackage.OwnerEndorsements {
+		endorsers = append(endorsers, string(endorsement.Endorser))
+	}
+	return endorsers
+}
@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeConfigUpdateNoChanges(t *testing.T) {
+	original := newTestConfig()
+
+	_, err := ComputeConfigUpdate("mychannel", original, proto.Clone(original).(*common.Config))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no differences detected")
+
+	_, err = ComputeConfigUpdate("mychannel", &common.Config{}, original)
+	assert.Error(t, err)
+	_, err = ComputeConfigUpdate("mychannel", original, nil)
+	assert.Error(t, err)
+}
+
+func TestComputeConfigUpdateModifiedValue(t *testing.T) {
+	original := newTestConfig()
+	updated := proto.Clone(original).(*common.Config)
+	updated.ChannelGroup.Groups["Orderer"].Values["BatchSize"].Value = []byte("new-batch-size")
+
+	configUpdate, err := ComputeConfigUpdate("mychannel", original, updated)
+	require.NoError(t, err)
+	assert.Equal(t, "mychannel", configUpdate.ChannelId)
+
+	// The orderer group membership is unchanged, so only the modified value is written
+	readSet := configUpdate.ReadSet.Groups["Orderer"]
+	require.NotNil(t, readSet)
+	assert.Equal(t, uint64(1), readSet.Version)
+	assert.Empty(t, readSet.Values)
+
+	writeSet := configUpdate.WriteSet.Groups["Orderer"]
+	require.NotNil(t, writeSet)
+	assert.Equal(t, uint64(1), writeSet.Version)
+	require.Len(t, writeSet.Values, 1)
+	assert.Equal(t, uint64(3), writeSet.Values["BatchSize"].Version)
+	assert.Equal(t, []byte("new-batch-size"), writeSet.Values["BatchSize"].Value)
+
+	assert.NotContains(t, configUpdate.WriteSet.Groups, "Application")
+	assert.Equal(t, uint64(0), configUpdate.WriteSet.Version)
+}
+
+func TestComputeConfigUpdateAddedGroup(t *testing.T) {
+	original := newTestConfig()
+	updated := proto.Clone(original).(*common.Config)
+	updated.ChannelGroup.Groups["Application"].Groups["Org2MSP"] = &common.ConfigGroup{
+		ModPolicy: "Admins",
+		Values:    map[string]*common.ConfigValue{"MSP": {Value: []byte("org2-msp"), ModPolicy: "Admins"}},
+		Policies:  map[string]*common.ConfigPolicy{"Admins": {Policy: &common.Policy{Type: 1}, ModPolicy: "Admins"}},
+	}
+
+	configUpdate, err := ComputeConfigUpdate("mychannel", original, updated)
+	require.NoError(t, err)
+
+	readSet := configUpdate.ReadSet.Groups["Application"]
+	require.NotNil(t, readSet)
+	assert.Equal(t, uint64(2), readSet.Version)
+	assert.Contains(t, readSet.Groups, "Org1MSP")
+
+	// Group membership changed: the version is bumped and unmodified members are included
+	writeSet := configUpdate.WriteSet.Groups["Application"]
+	require.NotNil(t, writeSet)
+	assert.Equal(t, uint64(3), writeSet.Version)
+	assert.Equal(t, "Admins", writeSet.ModPolicy)
+	assert.Equal(t, uint64(1), writeSet.Groups["Org1MSP"].Version)
+	assert.Empty(t, writeSet.Groups["Org1MSP"].Values)
+
+	org2 := writeSet.Groups["Org2MSP"]
+	require.NotNil(t, org2)
+	assert.Equal(t, uint64(0), org2.Version)
+	assert.Equal(t, uint64(0), org2.Values["MSP"].Version)
+	assert.Equal(t, []byte("org2-msp"), org2.Values["MSP"].Value)
+	assert.Equal(t, uint64(0), org2.Policies["Admins"].Version)
+}
+
+func TestComputeConfigUpdateRemovedPolicy(t *testing.T) {
+	original := newTestConfig()
+	updated := proto.Clone(original).(*common.Config)
+	delete(updated.ChannelGroup.Groups["Application"].Groups["Org1MSP"].Policies, "Readers")
+
+	configUpdate, err := ComputeConfigUpdate("mychannel", original, updated)
+	require.NoError(t, err)
+
+	writeSet := configUpdate.WriteSet.Groups["Application"].Groups["Org1MSP"]
+	assert.Equal(t, uint64(2), writeSet.Version)
+	assert.Contains(t, writeSet.Policies, "Admins")
+	assert.NotContains(t, writeSet.Policies, "Readers")
+}
+
+func TestCreateConfigUpdateEnvelope(t *testing.T) {
+	original := newTestConfig()
+	updated := proto.Clone(original).(*common.Config)
+	updated.ChannelGroup.Values["Consortium"] = &common.ConfigValue{Value: []byte("consortium"), ModPolicy: "Admins"}
+
+	configUpdate, err := ComputeConfigUpdate("mychannel", original, updated)
+	require.NoError(t, err)
+
+	envelope, err := CreateConfigUpdateEnvelope(configUpdate)
+	require.NoError(t, err)
+
+	configUpdateBytes, err := ExtractChannelConfig(envelope)
+	require.NoError(t, err)
+
+	extracted := &common.ConfigUpdate{}
+	require.NoError(t, proto.Unmarshal(configUpdateBytes, extracted))
+	assert.True(t, proto.Equal(configUpdate, extracted))
+}
+
+func TestExtractConfigFromBlock(t *testing.T) {
+	_, err := ExtractConfigFromBlock(nil)
+	assert.Error(t, err)
+
+	_, err = ExtractConfigFromBlock(&common.Block{Data: &common.BlockData{Data: [][]byte{[]byte("invalid")}}})
+	assert.Error(t, err)
+}
+
+func newTestConfig() *common.Config {
+	return &common.Config{
+		Sequence: 5,
+		ChannelGroup: &common.ConfigGroup{
+			ModPolicy: "Admins",
+			Values: map[string]*common.ConfigValue{
+				"HashingAlgorithm": {Version: 0, Value: []byte("SHA256"), ModPolicy: "Admins"},
+			},
+			Policies: map[string]*common.ConfigPolicy{
+				"Admins": {Version: 0, Policy: &common.Policy{Type: 3}, ModPolicy: "Admins"},
+			},
+			Groups: map[string]*common.ConfigGroup{
+				"Orderer": {
+					Version:   1,
+					ModPolicy: "Admins",
+					Values: map[string]*common.ConfigValue{
+						"BatchSize":    {Version: 2, Value: []byte("batch-size"), ModPolicy: "Admins"},
+						"BatchTimeout": {Version: 0, Value: []byte("2s"), ModPolicy: "Admins"},
+					},
+				},
+				"Application": {
+					Version:   2,
+					ModPolicy: "Admins",
+					Groups: map[string]*common.ConfigGroup{
+						"Org1MSP": {
+							Version:   1,
+							ModPolicy: "Admins",
+							Values: map[string]*common.ConfigValue{
+								"MSP": {Version: 0, Value: []byte("org1-msp"), ModPolicy: "Admins"},
+							},
+							Policies: map[string]*common.ConfigPolicy{
+								"Admins":  {Version: 0, Policy: &common.Policy{Type: 1}, ModPolicy: "Admins"},
+								"Readers": {Version: 0, Policy: &common.Policy{Type: 1}, ModPolicy: "Admins"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -26,6 +26,9 @@ type ChaincodeInstallRequest struct {
 	Path    string
 	Version string
 	Package *ChaincodePackage
+	// DeploymentPackage is a chaincode deployment package which, if provided, is installed as is (instead of
+	// a deployment spec created from the package)
+	DeploymentPackage []byte
 }
 
 // ChaincodePackage contains package type and bytes required to create CDS
@@ -48,6 +51,11 @@ func createInstallInvokeRequest(request ChaincodeInstallRequest) (fab.ChaincodeI
 	// Generate arguments for install
 	args := [][]byte{}
 
+	if request.DeploymentPackage != nil {
+		args = append(args, request.DeploymentPackage)
+		return fab.ChaincodeInvokeRequest{ChaincodeID: lscc, Fcn: lsccInstall, Args: args}, nil
+	}
+
 	ccds := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{
 		Type: request.Package.Type, ChaincodeId: &pb.ChaincodeID{Name: request.Name, Path: request.Path, Version: request.Version}},
 		CodePackage: request.Package.Code}
@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resource
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+
+	imsp "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/msp"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+)
+
+const (
+	mspCACertsDir              = "cacerts"
+	mspAdminCertsDir           = "admincerts"
+	mspIntermediateCertsDir    = "intermediatecerts"
+	mspCRLsDir                 = "crls"
+	mspTLSCACertsDir           = "tlscacerts"
+	mspTLSIntermediateCertsDir = "tlsintermediatecerts"
+	mspConfigFile              = "config.yaml"
+)
+
+// GenerateMSPConfig generates the (verifying) MSP configuration of an organization from a local MSP
+// directory, as laid out by cryptogen or the fabric-ca client. The resulting configuration contains
+// no signing identity and may be included in a channel configuration.
+//  Parameters:
+//  mspDir is the path of the MSP directory
+//  mspID is the MSP ID of the organization
+//
+//  Returns:
+//  the MSP configuration
+func GenerateMSPConfig(mspDir, mspID string) (*mb.MSPConfig, error) {
+	if mspID == "" {
+		return nil, errors.New("MSP ID is required")
+	}
+
+	rootCerts, err := readPEMDir(filepath.Join(mspDir, mspCACertsDir))
+	if err != nil {
+		return nil, err
+	}
+	if len(rootCerts) == 0 {
+		return nil, errors.Errorf("no root CA certificates found in %s", filepath.Join(mspDir, mspCACertsDir))
+	}
+
+	fabricMSPConfig := &mb.FabricMSPConfig{
+		Name:      mspID,
+		RootCerts: rootCerts,
+		CryptoConfig: &mb.FabricCryptoConfig{
+			SignatureHashFamily:            "SHA2",
+			IdentityIdentifierHashFunction: "SHA256",
+		},
+	}
+
+	dirs := []struct {
+		name  string
+		certs *[][]byte
+	}{
+		{mspAdminCertsDir, &fabricMSPConfig.Admins},
+		{mspIntermediateCertsDir, &fabricMSPConfig.IntermediateCerts},
+		{mspCRLsDir, &fabricMSPConfig.RevocationList},
+		{mspTLSCACertsDir, &fabricMSPConfig.TlsRootCerts},
+		{mspTLSIntermediateCertsDir, &fabricMSPConfig.TlsIntermediateCerts},
+	}
+	for _, dir := range dirs {
+		if *dir.certs, err = readPEMDir(filepath.Join(mspDir, dir.name)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := loadMSPConfigFile(mspDir, fabricMSPConfig); err != nil {
+		return nil, err
+	}
+
+	fabricMSPConfigBytes, err := proto.Marshal(fabricMSPConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal Fabric MSP config failed")
+	}
+
+	return &mb.MSPConfig{Type: 0, Config: fabricMSPConfigBytes}, nil
+}
+
+// loadMSPConfigFile loads the organizational unit identifiers and node OUs from the optional config.yaml
+func loadMSPConfigFile(mspDir string, fabricMSPConfig *mb.FabricMSPConfig) error {
+	configBytes, err := ioutil.ReadFile(filepath.Join(mspDir, mspConfigFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "reading %s failed", mspConfigFile)
+	}
+
+	configuration := &imsp.Configuration{}
+	if err := yaml.Unmarshal(configBytes, configuration); err != nil {
+		return errors.Wrapf(err, "unmarshal %s failed", mspConfigFile)
+	}
+
+	for _, ouID := range configuration.OrganizationalUnitIdentifiers {
+		identifier, err := newOUIdentifier(mspDir, ouID)
+		if err != nil {
+			return err
+		}
+		fabricMSPConfig.OrganizationalUnitIdentifiers = append(fabricMSPConfig.OrganizationalUnitIdentifiers, identifier)
+	}
+
+	if configuration.NodeOUs != nil && configuration.NodeOUs.Enable {
+		fabricMSPConfig.FabricNodeOUs = &mb.FabricNodeOUs{Enable: true}
+		if fabricMSPConfig.FabricNodeOUs.ClientOUIdentifier, err = newOUIdentifier(mspDir, configuration.NodeOUs.ClientOUIdentifier); err != nil {
+			return err
+		}
+		if fabricMSPConfig.FabricNodeOUs.PeerOUIdentifier, err = newOUIdentifier(mspDir, configuration.NodeOUs.PeerOUIdentifier); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newOUIdentifier(mspDir string, ouID *imsp.OrganizationalUnitIdentifiersConfiguration) (*mb.FabricOUIdentifier, error) {
+	if ouID == nil {
+		return nil, nil
+	}
+
+	identifier := &mb.FabricOUIdentifier{OrganizationalUnitIdentifier: ouID.OrganizationalUnitIdentifier}
+	if ouID.Certificate != "" {
+		cert, err := ioutil.ReadFile(filepath.Join(mspDir, ouID.Certificate))
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading OU certificate %s failed", ouID.Certificate)
+		}
+		identifier.Certificate = cert
+	}
+
+	return identifier, nil
+}
+
+// readPEMDir returns the contents of the PEM files in the given directory. A missing directory is not an error.
+func readPEMDir(dir string) ([][]byte, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "reading directory %s failed", dir)
+	}
+
+	var contents [][]byte
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		b, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading file %s failed", f.Name())
+		}
+		if block, _ := pem.Decode(b); block == nil {
+			return nil, errors.Errorf("file %s in %s is not PEM encoded", f.Name(), dir)
+		}
+		contents = append(contents, b)
+	}
+
+	return contents, nil
+}
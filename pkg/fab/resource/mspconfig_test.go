@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resource
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const org1MSPDir = "../../../test/fixtures/fabric/v1/crypto-config/peerOrganizations/org1.example.com/msp"
+
+func TestGenerateMSPConfig(t *testing.T) {
+	mspConfig, err := GenerateMSPConfig(org1MSPDir, "Org1MSP")
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), mspConfig.Type)
+
+	fabricMSPConfig := &mb.FabricMSPConfig{}
+	require.NoError(t, proto.Unmarshal(mspConfig.Config, fabricMSPConfig))
+	assert.Equal(t, "Org1MSP", fabricMSPConfig.Name)
+	assert.Len(t, fabricMSPConfig.RootCerts, 1)
+	assert.Len(t, fabricMSPConfig.Admins, 1)
+	assert.Len(t, fabricMSPConfig.TlsRootCerts, 1)
+	assert.Len(t, fabricMSPConfig.RevocationList, 1)
+	assert.Empty(t, fabricMSPConfig.IntermediateCerts)
+	assert.Nil(t, fabricMSPConfig.SigningIdentity)
+	assert.Equal(t, "SHA2", fabricMSPConfig.CryptoConfig.SignatureHashFamily)
+	assert.Nil(t, fabricMSPConfig.FabricNodeOUs)
+
+	_, err = GenerateMSPConfig(org1MSPDir, "")
+	assert.Error(t, err, "expecting error for missing MSP ID")
+
+	_, err = GenerateMSPConfig("invalid-dir", "Org1MSP")
+	assert.Error(t, err, "expecting error since there are no CA certificates")
+}
+
+func TestGenerateMSPConfigWithNodeOUs(t *testing.T) {
+	mspDir, err := ioutil.TempDir("", "msp")
+	require.NoError(t, err)
+	defer os.RemoveAll(mspDir)
+
+	caCert, err := ioutil.ReadFile(filepath.Join(org1MSPDir, "cacerts", "ca.org1.example.com-cert.pem"))
+	require.NoError(t, err)
+
+	require.NoError(t, os.Mkdir(filepath.Join(mspDir, "cacerts"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(mspDir, "cacerts", "ca.pem"), caCert, 0644))
+
+	config := `NodeOUs:
+  Enable: true
+  ClientOUIdentifier:
+    Certificate: cacerts/ca.pem
+    OrganizationalUnitIdentifier: client
+  PeerOUIdentifier:
+    Certificate: cacerts/ca.pem
+    OrganizationalUnitIdentifier: peer
+`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(mspDir, "config.yaml"), []byte(config), 0644))
+
+	mspConfig, err := GenerateMSPConfig(mspDir, "Org1MSP")
+	require.NoError(t, err)
+
+	fabricMSPConfig := &mb.FabricMSPConfig{}
+	require.NoError(t, proto.Unmarshal(mspConfig.Config, fabricMSPConfig))
+	require.NotNil(t, fabricMSPConfig.FabricNodeOUs)
+	assert.True(t, fabricMSPConfig.FabricNodeOUs.Enable)
+	assert.Equal(t, "client", fabricMSPConfig.FabricNodeOUs.ClientOUIdentifier.OrganizationalUnitIdentifier)
+	assert.Equal(t, caCert, fabricMSPConfig.FabricNodeOUs.ClientOUIdentifier.Certificate)
+	assert.Equal(t, "peer", fabricMSPConfig.FabricNodeOUs.PeerOUIdentifier.OrganizationalUnitIdentifier)
+
+	// Files that are not PEM encoded are rejected
+	require.NoError(t, ioutil.WriteFile(filepath.Join(mspDir, "cacerts", "invalid.pem"), []byte("invalid"), 0644))
+	_, err = GenerateMSPConfig(mspDir, "Org1MSP")
+	assert.Error(t, err)
+}
@@ -312,27 +312,9 @@ func QueryInstalledChaincodes(reqCtx reqContext.Context, peer fab.ProposalProces
 // InstallChaincode sends an install proposal to one or more endorsing peers.
 func InstallChaincode(reqCtx reqContext.Context, req InstallChaincodeRequest, targets []fab.ProposalProcessor, opts ...Opt) ([]*fab.TransactionProposalResponse, fab.TransactionID, error) {
 
-	if req.Name == "" {
-		return nil, fab.EmptyTransactionID, errors.New("chaincode name required")
-	}
-	if req.Path == "" {
-		return nil, fab.EmptyTransactionID, errors.New("chaincode path required")
-	}
-	if req.Version == "" {
-		return nil, fab.EmptyTransactionID, errors.New("chaincode version required")
-	}
-	if req.Package == nil {
-		return nil, fab.EmptyTransactionID, errors.New("chaincode package is required")
-	}
-
-	propReq := ChaincodeInstallRequest{
-		Name:    req.Name,
-		Path:    req.Path,
-		Version: req.Version,
-		Package: &ChaincodePackage{
-			Type: req.Package.Type,
-			Code: req.Package.Code,
-		},
+	propReq, err := newChaincodeInstallRequest(req)
+	if err != nil {
+		return nil, fab.EmptyTransactionID, err
 	}
 
 	ctx, ok := contextImpl.RequestClientContext(reqCtx)
@@ -364,6 +346,46 @@ func InstallChaincode(reqCtx reqContext.Context, req InstallChaincodeRequest, ta
 	return resp.([]*fab.TransactionProposalResponse), prop.TxnID, err
 }
 
+// newChaincodeInstallRequest validates the install request, resolving the chaincode name, path and version of a
+// deployment package, and returns the corresponding install proposal request
+func newChaincodeInstallRequest(req InstallChaincodeRequest) (ChaincodeInstallRequest, error) {
+	if req.DeploymentPackage != nil {
+		var err error
+		req, err = ResolveDeploymentPackage(req)
+		if err != nil {
+			return ChaincodeInstallRequest{}, err
+		}
+	}
+
+	if req.Name == "" {
+		return ChaincodeInstallRequest{}, errors.New("chaincode name required")
+	}
+	if req.Path == "" {
+		return ChaincodeInstallRequest{}, errors.New("chaincode path required")
+	}
+	if req.Version == "" {
+		return ChaincodeInstallRequest{}, errors.New("chaincode version required")
+	}
+	if req.Package == nil && req.DeploymentPackage == nil {
+		return ChaincodeInstallRequest{}, errors.New("chaincode package is required")
+	}
+
+	propReq := ChaincodeInstallRequest{
+		Name:              req.Name,
+		Path:              req.Path,
+		Version:           req.Version,
+		DeploymentPackage: req.DeploymentPackage,
+	}
+	if req.Package != nil {
+		propReq.Package = &ChaincodePackage{
+			Type: req.Package.Type,
+			Code: req.Package.Code,
+		}
+	}
+
+	return propReq, nil
+}
+
 func queryChaincodeWithTarget(reqCtx reqContext.Context, request fab.ChaincodeInvokeRequest, target fab.ProposalProcessor, opts options) ([]byte, error) {
 
 	targets := []fab.ProposalProcessor{target}
@@ -100,8 +100,11 @@ declare -a FILES=(
     "common/util/utils.go"
     "common/attrmgr/attrmgr.go"
 
+    "common/channelconfig/application.go"
     "common/channelconfig/applicationorg.go"
     "common/channelconfig/channel.go"
+    "common/channelconfig/consortium.go"
+    "common/channelconfig/consortiums.go"
     "common/channelconfig/util.go"
     "common/channelconfig/orderer.go"
 
@@ -182,6 +185,10 @@ gofilter
 sed -i'' -e 's/&bccsp.SHA256Opts{}/factory.GetSHA256Opts()/g' "${TMP_PROJECT_PATH}/${FILTER_FILENAME}"
 sed -i'' -e 's/"github.com\/hyperledger\/fabric\/bccsp\/factory"/factory "github.com\/hyperledger\/fabric-sdk-go\/internal\/github.com\/hyperledger\/fabric\/sdkpatch\/cryptosuitebridge"/g' "${TMP_PROJECT_PATH}/${FILTER_FILENAME}"
 
+FILTER_FILENAME="common/channelconfig/application.go"
+FILTER_FN=
+gofilter
+
 FILTER_FILENAME="common/channelconfig/applicationorg.go"
 FILTER_FN=
 gofilter
@@ -190,6 +197,14 @@ FILTER_FILENAME="common/channelconfig/channel.go"
 FILTER_FN=
 gofilter
 
+FILTER_FILENAME="common/channelconfig/consortium.go"
+FILTER_FN=
+gofilter
+
+FILTER_FILENAME="common/channelconfig/consortiums.go"
+FILTER_FN=
+gofilter
+
 FILTER_FILENAME="common/channelconfig/util.go"
 FILTER_FN=
 gofilter
@@ -330,12 +345,21 @@ FILTER_TYPE="IMPORT,CONST"
 # Allow no declarations
 FILTER_GEN=
 
+FILTER_FILENAME="common/channelconfig/application.go"
+gofilter
+
 FILTER_FILENAME="common/channelconfig/applicationorg.go"
 gofilter
 
 FILTER_FILENAME="common/channelconfig/channel.go"
 gofilter
 
+FILTER_FILENAME="common/channelconfig/consortium.go"
+gofilter
+
+FILTER_FILENAME="common/channelconfig/consortiums.go"
+gofilter
+
 FILTER_FILENAME="common/channelconfig/util.go"
 gofilter
 
@@ -19,6 +19,7 @@ NAMESPACE_PREFIX="sdk."
 declare -a PKGS=(
     "protos/common"
     "protos/peer"
+    "protos/peer/lifecycle"
 
     "protos/msp"
 
@@ -40,11 +41,13 @@ declare -a FILES=(
     "protos/peer/configuration.pb.go"
     "protos/peer/events.pb.go"
     "protos/peer/peer.pb.go"
+    "protos/peer/policy.pb.go"
     "protos/peer/proposal.pb.go"
     "protos/peer/proposal_response.pb.go"
     "protos/peer/query.pb.go"
     "protos/peer/transaction.pb.go"
     "protos/peer/signed_cc_dep_spec.pb.go"
+    "protos/peer/lifecycle/lifecycle.pb.go"
 
     "protos/msp/identities.pb.go"
     "protos/msp/msp_config.pb.go"
@@ -100,6 +103,10 @@ do
     sed -i'' -e "/proto.RegisterType/s/protos/${NAMESPACE_PREFIX}protos/g" "${TMP_PROJECT_PATH}/${i}"
     sed -i'' -e "/proto.RegisterEnum/s/protos/${NAMESPACE_PREFIX}protos/g" "${TMP_PROJECT_PATH}/${i}"
   fi
+  if [[ ${i} == "protos/peer/lifecycle"* ]]; then
+    sed -i'' -e "/proto.RegisterType/s/lifecycle/${NAMESPACE_PREFIX}lifecycle/g" "${TMP_PROJECT_PATH}/${i}"
+    sed -i'' -e "/proto.RegisterEnum/s/lifecycle/${NAMESPACE_PREFIX}lifecycle/g" "${TMP_PROJECT_PATH}/${i}"
+  fi
 done
 
 # Copy patched project into internal paths
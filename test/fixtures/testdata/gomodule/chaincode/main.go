@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/example/modcc/lib"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// DoubleChaincode doubles the value of a key
+type DoubleChaincode struct {
+}
+
+// Init ...
+func (t *DoubleChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+// Invoke doubles the value of the key given as argument
+func (t *DoubleChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	_, args := stub.GetFunctionAndParameters()
+	if len(args) != 1 {
+		return shim.Error("Incorrect number of arguments. Expecting 1")
+	}
+
+	value, err := stub.GetState(args[0])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	doubled, err := lib.DoubleString(string(value))
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	if err := stub.PutState(args[0], []byte(doubled)); err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success([]byte(doubled))
+}
+
+func main() {
+	if err := shim.Start(new(DoubleChaincode)); err != nil {
+		fmt.Printf("Error starting DoubleChaincode: %s", err)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/example/modcc
+
+require github.com/example/dep v1.0.0
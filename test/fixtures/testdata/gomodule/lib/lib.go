@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lib
+
+import (
+	"strconv"
+
+	"github.com/example/dep"
+)
+
+// DoubleString doubles the integer in the given string
+func DoubleString(value string) (string, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(dep.Double(i)), nil
+}
@@ -0,0 +1,12 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package unused
+
+import "github.com/example/missing"
+
+// Unused isn't imported by the chaincode, so its imports don't have to be resolvable
+var Unused = missing.Value
@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package org.hyperledger.fabric.example;
+
+import java.util.List;
+
+import org.hyperledger.fabric.shim.ChaincodeBase;
+import org.hyperledger.fabric.shim.ChaincodeStub;
+
+/**
+ * Java version of the example_cc chaincode: moves an amount between the asset holdings of two entities.
+ */
+public class SimpleChaincode extends ChaincodeBase {
+
+    @Override
+    public Response init(ChaincodeStub stub) {
+        List<String> args = stub.getParameters();
+        if (args.size() != 4) {
+            return newErrorResponse("Incorrect number of arguments. Expecting 4");
+        }
+
+        try {
+            int aval = Integer.parseInt(args.get(1));
+            int bval = Integer.parseInt(args.get(3));
+            stub.putStringState(args.get(0), Integer.toString(aval));
+            stub.putStringState(args.get(2), Integer.toString(bval));
+        } catch (NumberFormatException e) {
+            return newErrorResponse("Expecting integer value for asset holding");
+        }
+        return newSuccessResponse();
+    }
+
+    @Override
+    public Response invoke(ChaincodeStub stub) {
+        List<String> params = stub.getParameters();
+        if (!"invoke".equals(stub.getFunction()) || params.size() < 2) {
+            return newErrorResponse("Unknown function call");
+        }
+
+        List<String> args = params.subList(1, params.size());
+        switch (params.get(0)) {
+            case "move":
+                return move(stub, args);
+            case "query":
+                return query(stub, args);
+            default:
+                return newErrorResponse("Unknown action, check the first argument, must be one of 'move' or 'query'");
+        }
+    }
+
+    private Response move(ChaincodeStub stub, List<String> args) {
+        if (args.size() != 3) {
+            return newErrorResponse("Incorrect number of arguments. Expecting 3");
+        }
+
+        int amount = Integer.parseInt(args.get(2));
+        int aval = Integer.parseInt(stub.getStringState(args.get(0))) - amount;
+        int bval = Integer.parseInt(stub.getStringState(args.get(1))) + amount;
+        stub.putStringState(args.get(0), Integer.toString(aval));
+        stub.putStringState(args.get(1), Integer.toString(bval));
+        return newSuccessResponse();
+    }
+
+    private Response query(ChaincodeStub stub, List<String> args) {
+        if (args.size() != 1) {
+            return newErrorResponse("Incorrect number of arguments. Expecting name of the person to query");
+        }
+
+        String val = stub.getStringState(args.get(0));
+        if (val == null || val.isEmpty()) {
+            return newErrorResponse("Nil amount for " + args.get(0));
+        }
+        return newSuccessResponse(val.getBytes());
+    }
+
+    public static void main(String[] args) {
+        new SimpleChaincode().start(args);
+    }
+}
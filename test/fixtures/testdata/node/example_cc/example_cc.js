@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+'use strict';
+
+const shim = require('fabric-shim');
+
+// Node.js version of example_cc: moves an amount between the asset holdings of two entities
+const Chaincode = class {
+
+	async Init(stub) {
+		const args = stub.getFunctionAndParameters().params;
+		if (args.length !== 4) {
+			return shim.error('Incorrect number of arguments. Expecting 4');
+		}
+
+		const aval = parseInt(args[1]);
+		const bval = parseInt(args[3]);
+		if (isNaN(aval) || isNaN(bval)) {
+			return shim.error('Expecting integer value for asset holding');
+		}
+
+		try {
+			await stub.putState(args[0], Buffer.from(aval.toString()));
+			await stub.putState(args[2], Buffer.from(bval.toString()));
+			return shim.success();
+		} catch (err) {
+			return shim.error(err);
+		}
+	}
+
+	async Invoke(stub) {
+		const ret = stub.getFunctionAndParameters();
+		if (ret.fcn !== 'invoke' || ret.params.length < 2) {
+			return shim.error('Unknown function call');
+		}
+
+		const args = ret.params.slice(1);
+		switch (ret.params[0]) {
+		case 'move':
+			return this.move(stub, args);
+		case 'query':
+			return this.query(stub, args);
+		default:
+			return shim.error('Unknown action, check the first argument, must be one of \'move\' or \'query\'');
+		}
+	}
+
+	async move(stub, args) {
+		if (args.length !== 3) {
+			return shim.error('Incorrect number of arguments. Expecting 3');
+		}
+
+		try {
+			const aval = parseInt((await stub.getState(args[0])).toString()) - parseInt(args[2]);
+			const bval = parseInt((await stub.getState(args[1])).toString()) + parseInt(args[2]);
+			await stub.putState(args[0], Buffer.from(aval.toString()));
+			await stub.putState(args[1], Buffer.from(bval.toString()));
+			return shim.success();
+		} catch (err) {
+			return shim.error(err);
+		}
+	}
+
+	async query(stub, args) {
+		if (args.length !== 1) {
+			return shim.error('Incorrect number of arguments. Expecting name of the person to query');
+		}
+
+		const val = await stub.getState(args[0]);
+		if (!val || val.length === 0) {
+			return shim.error('Nil amount for ' + args[0]);
+		}
+		return shim.success(val);
+	}
+};
+
+shim.start(new Chaincode());
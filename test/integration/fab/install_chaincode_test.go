@@ -8,7 +8,11 @@ package fab
 
 import (
 	reqContext "context"
+	"io/ioutil"
 	"math/rand"
+	"os"
+	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -17,9 +21,12 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context"
 	packager "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/gopackager"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/javapackager"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/nodepackager"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
 	"github.com/hyperledger/fabric-sdk-go/test/integration"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
@@ -57,6 +64,14 @@ func TestChaincodeInstal(t *testing.T) {
 	testChaincodeInstallUsingChaincodePath(t, sdk, testSetup)
 
 	testChaincodeInstallUsingChaincodePackage(t, sdk, testSetup)
+
+	testNodeChaincodeInstall(t, sdk, testSetup)
+
+	testJavaChaincodeInstall(t, sdk, testSetup)
+
+	testModuleChaincodeInstall(t, sdk, testSetup)
+
+	testSignedDeploymentPackageInstall(t, sdk, testSetup)
 }
 
 // Test chaincode install using chaincodePath to create chaincodePackage
@@ -149,6 +164,111 @@ func testChaincodeInstallUsingChaincodePackage(t *testing.T, sdk *fabsdk.FabricS
 	}
 }
 
+// Test install of a Node.js chaincode package
+func testNodeChaincodeInstall(t *testing.T, sdk *fabsdk.FabricSDK, testSetup *integration.BaseSetupImpl) {
+
+	chainCodeVersion := getRandomCCVersion()
+	nodeChainCodePath := path.Join(integration.GetDeployPath(), "node", "example_cc")
+
+	ccPkg, err := nodepackager.NewCCPackage(nodeChainCodePath)
+	require.NoError(t, err, "Failed to package Node.js chaincode")
+
+	reqCtx, cancel, err := getContext(sdk, "Admin", orgName)
+	require.NoError(t, err, "Failed to get resource")
+	defer cancel()
+
+	peers, err := getProposalProcessors(sdk, "Admin", testSetup.OrgID, testSetup.Targets)
+	require.Nil(t, err, "creating peers failed")
+
+	err = installCC(t, reqCtx, "install_node", nodeChainCodePath, chainCodeVersion, ccPkg, peers)
+	require.NoError(t, err, "installCC return error")
+}
+
+// Test install of a Java chaincode package
+func testJavaChaincodeInstall(t *testing.T, sdk *fabsdk.FabricSDK, testSetup *integration.BaseSetupImpl) {
+
+	chainCodeVersion := getRandomCCVersion()
+	javaChainCodePath := path.Join(integration.GetDeployPath(), "java", "example_cc")
+
+	ccPkg, err := javapackager.NewCCPackage(javaChainCodePath)
+	require.NoError(t, err, "Failed to package Java chaincode")
+
+	reqCtx, cancel, err := getContext(sdk, "Admin", orgName)
+	require.NoError(t, err, "Failed to get resource")
+	defer cancel()
+
+	peers, err := getProposalProcessors(sdk, "Admin", testSetup.OrgID, testSetup.Targets)
+	require.Nil(t, err, "creating peers failed")
+
+	err = installCC(t, reqCtx, "install_java", javaChainCodePath, chainCodeVersion, ccPkg, peers)
+	require.NoError(t, err, "installCC return error")
+}
+
+// Test install of a Go chaincode that is part of a Go module outside of the GOPATH
+func testModuleChaincodeInstall(t *testing.T, sdk *fabsdk.FabricSDK, testSetup *integration.BaseSetupImpl) {
+
+	chainCodeVersion := getRandomCCVersion()
+
+	ccPkg, ccPath, err := packager.NewModuleCCPackage(path.Join(integration.GetDeployPath(), "gomodule", "chaincode"))
+	require.NoError(t, err, "Failed to package Go module chaincode")
+
+	reqCtx, cancel, err := getContext(sdk, "Admin", orgName)
+	require.NoError(t, err, "Failed to get resource")
+	defer cancel()
+
+	peers, err := getProposalProcessors(sdk, "Admin", testSetup.OrgID, testSetup.Targets)
+	require.Nil(t, err, "creating peers failed")
+
+	err = installCC(t, reqCtx, "install_module", ccPath, chainCodeVersion, ccPkg, peers)
+	require.NoError(t, err, "installCC return error")
+}
+
+// Test install of a signed deployment package endorsed by the owners of both orgs
+func testSignedDeploymentPackageInstall(t *testing.T, sdk *fabsdk.FabricSDK, testSetup *integration.BaseSetupImpl) {
+
+	chainCodeVersion := getRandomCCVersion()
+
+	ccPkg, err := packager.NewCCPackage(chainCodePath, integration.GetDeployPath())
+	require.NoError(t, err, "Failed to package chaincode")
+
+	cds, err := resource.CreateChaincodeDeploymentSpec("install_signed", chainCodePath, chainCodeVersion, ccPkg)
+	require.NoError(t, err, "Failed to create deployment spec")
+
+	org1Owner, err := sdk.Context(fabsdk.WithUser("Admin"), fabsdk.WithOrg(org1Name))()
+	require.NoError(t, err, "Failed to get org1 context")
+	org2Owner, err := sdk.Context(fabsdk.WithUser("Admin"), fabsdk.WithOrg("Org2"))()
+	require.NoError(t, err, "Failed to get org2 context")
+
+	policy := cauthdsl.SignedByAnyAdmin([]string{"Org1MSP", "Org2MSP"})
+	signedPkg, err := resource.CreateSignedCCDeploymentPackage(org1Owner, cds, policy)
+	require.NoError(t, err, "Failed to create signed deployment package")
+	signedPkg, err = resource.SignCCDeploymentPackage(org2Owner, signedPkg)
+	require.NoError(t, err, "Failed to sign deployment package")
+
+	dir, err := ioutil.TempDir("", "ccdeployment")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "install_signed.pak")
+	require.NoError(t, resource.WriteCCDeploymentPackage(fileName, signedPkg), "Failed to write deployment package")
+	deploymentPkg, err := resource.ReadCCDeploymentPackage(fileName)
+	require.NoError(t, err, "Failed to read deployment package")
+
+	reqCtx, cancel, err := getContext(sdk, "Admin", orgName)
+	require.NoError(t, err, "Failed to get resource")
+	defer cancel()
+
+	peers, err := getProposalProcessors(sdk, "Admin", testSetup.OrgID, testSetup.Targets)
+	require.Nil(t, err, "creating peers failed")
+
+	icr := resource.InstallChaincodeRequest{DeploymentPackage: deploymentPkg}
+	r, _, err := resource.InstallChaincode(reqCtx, icr, peers, resource.WithRetry(retry.DefaultResMgmtOpts))
+	require.NoError(t, err, "InstallChaincode failed")
+	for _, response := range r {
+		require.Equal(t, int32(common.Status_SUCCESS), response.Status, "InstallChaincode returned response status: [%d], message: [%s]", response.Status, response.GetResponse().Message)
+	}
+}
+
 // installCC use low level client to install chaincode
 func installCC(t *testing.T, reqCtx reqContext.Context, name string, path string, version string, ccPackage *resource.CCPackage, targets []fab.ProposalProcessor) error {
 
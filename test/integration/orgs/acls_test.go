@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orgs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/stretchr/testify/require"
+)
+
+// TestACLsUpdate sets the ACL of a resource on the channel (signed by the admins of both organizations),
+// verifies the update using the channel configuration from the orderer and then resets it again
+func TestACLsUpdate(t *testing.T) {
+
+	mc := multiorgContext{
+		ordererClientContext:   sdk.Context(fabsdk.WithUser(ordererAdminUser), fabsdk.WithOrg(ordererOrgName)),
+		org1AdminClientContext: sdk.Context(fabsdk.WithUser(org1AdminUser), fabsdk.WithOrg(org1)),
+		org2AdminClientContext: sdk.Context(fabsdk.WithUser(org2AdminUser), fabsdk.WithOrg(org2)),
+		ccName:                 exampleCC,
+	}
+	setupClientContextsAndChannel(t, sdk, &mc)
+
+	org1Admin, err := org1MspClient.GetSigningIdentity(org1AdminUser)
+	require.NoError(t, err, "failed to get org1 admin")
+	org2Admin, err := org2MspClient.GetSigningIdentity(org2AdminUser)
+	require.NoError(t, err, "failed to get org2 admin")
+	signers := []msp.SigningIdentity{org1Admin, org2Admin}
+
+	cfg, err := mc.org1ResMgmt.QueryConfigFromOrderer(channelID, resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "QueryConfigFromOrderer failed")
+
+	const resource = "qscc/GetChainInfo"
+	original, exists := cfg.ACLs()[resource]
+	policy := "/Channel/Application/Readers"
+	if original == policy {
+		policy = "/Channel/Application/Writers"
+	}
+
+	_, err = mc.org1ResMgmt.SetACLs(resmgmt.SetACLsRequest{ChannelID: channelID, ACLs: map[string]string{resource: policy}, SigningIdentities: signers}, resmgmt.WithRetry(retry.DefaultResMgmtOpts), resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "SetACLs failed")
+	require.True(t, waitForACL(t, mc.org1ResMgmt, resource, policy), "ACL was not set")
+
+	if exists {
+		_, err = mc.org1ResMgmt.SetACLs(resmgmt.SetACLsRequest{ChannelID: channelID, ACLs: map[string]string{resource: original}, SigningIdentities: signers}, resmgmt.WithRetry(retry.DefaultResMgmtOpts), resmgmt.WithOrdererEndpoint("orderer.example.com"))
+		require.NoError(t, err, "SetACLs failed")
+	} else {
+		_, err = mc.org1ResMgmt.ResetACLs(resmgmt.ResetACLsRequest{ChannelID: channelID, Resources: []string{resource}, SigningIdentities: signers}, resmgmt.WithRetry(retry.DefaultResMgmtOpts), resmgmt.WithOrdererEndpoint("orderer.example.com"))
+		require.NoError(t, err, "ResetACLs failed")
+	}
+	require.True(t, waitForACL(t, mc.org1ResMgmt, resource, original), "ACL was not restored")
+}
+
+// waitForACL polls the channel configuration until the ACL of the resource has the expected policy
+// (an empty policy means that the resource has no ACL)
+func waitForACL(t *testing.T, rc *resmgmt.Client, resource, expected string) bool {
+	for i := 0; i < pollRetries; i++ {
+		cfg, err := rc.QueryConfigFromOrderer(channelID, resmgmt.WithOrdererEndpoint("orderer.example.com"))
+		require.NoError(t, err, "QueryConfigFromOrderer failed")
+		if cfg.ACLs()[resource] == expected {
+			return true
+		}
+		time.Sleep(time.Second)
+	}
+	return false
+}
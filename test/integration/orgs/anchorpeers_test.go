@@ -0,0 +1,59 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orgs
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAnchorPeersUpdate adds an anchor peer to Org1 on the channel and then removes it again. Each update is
+// verified by the client (using the channel configuration of the peers) before it returns, and again using the
+// anchor peers of the channel configuration of the orderer.
+func TestAnchorPeersUpdate(t *testing.T) {
+
+	mc := multiorgContext{
+		ordererClientContext:   sdk.Context(fabsdk.WithUser(ordererAdminUser), fabsdk.WithOrg(ordererOrgName)),
+		org1AdminClientContext: sdk.Context(fabsdk.WithUser(org1AdminUser), fabsdk.WithOrg(org1)),
+		org2AdminClientContext: sdk.Context(fabsdk.WithUser(org2AdminUser), fabsdk.WithOrg(org2)),
+		ccName:                 exampleCC,
+	}
+	setupClientContextsAndChannel(t, sdk, &mc)
+
+	anchorPeer := resmgmt.AnchorPeer{Host: "peer1.org1.example.com", Port: 7151}
+	req := resmgmt.AnchorPeersRequest{ChannelID: channelID, AnchorPeers: []resmgmt.AnchorPeer{anchorPeer}, Verify: true}
+
+	_, err := mc.org1ResMgmt.AddAnchorPeers(req, resmgmt.WithRetry(retry.DefaultResMgmtOpts), resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "AddAnchorPeers failed")
+	require.True(t, hasAnchorPeer(t, mc.org1ResMgmt, anchorPeer), "anchor peer was not added")
+
+	_, err = mc.org1ResMgmt.RemoveAnchorPeers(req, resmgmt.WithRetry(retry.DefaultResMgmtOpts), resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "RemoveAnchorPeers failed")
+	require.False(t, hasAnchorPeer(t, mc.org1ResMgmt, anchorPeer), "anchor peer was not removed")
+
+	// Removing an anchor peer that isn't defined doesn't submit a config update
+	resp, err := mc.org1ResMgmt.RemoveAnchorPeers(req, resmgmt.WithRetry(retry.DefaultResMgmtOpts), resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "RemoveAnchorPeers of missing anchor peer failed")
+	require.Empty(t, resp.TransactionID, "no config update expected")
+}
+
+// hasAnchorPeer returns true if the anchor peer is included in the channel configuration
+func hasAnchorPeer(t *testing.T, rc *resmgmt.Client, anchorPeer resmgmt.AnchorPeer) bool {
+	cfg, err := rc.QueryConfigFromOrderer(channelID, resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "QueryConfigFromOrderer failed")
+
+	for _, ap := range cfg.AnchorPeers() {
+		if ap.Host == anchorPeer.Host && ap.Port == anchorPeer.Port {
+			return true
+		}
+	}
+	return false
+}
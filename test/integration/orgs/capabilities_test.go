@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orgs
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUpgradeCapabilities requests capabilities that are already enabled on the channel,
+// which must not result in any config update
+func TestUpgradeCapabilities(t *testing.T) {
+
+	mc := multiorgContext{
+		ordererClientContext:   sdk.Context(fabsdk.WithUser(ordererAdminUser), fabsdk.WithOrg(ordererOrgName)),
+		org1AdminClientContext: sdk.Context(fabsdk.WithUser(org1AdminUser), fabsdk.WithOrg(org1)),
+		org2AdminClientContext: sdk.Context(fabsdk.WithUser(org2AdminUser), fabsdk.WithOrg(org2)),
+		ccName:                 exampleCC,
+	}
+	setupClientContextsAndChannel(t, sdk, &mc)
+
+	cfg, err := mc.org1ResMgmt.QueryConfigFromOrderer(channelID, resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "QueryConfigFromOrderer failed")
+
+	req := resmgmt.CapabilitiesRequest{ChannelID: channelID}
+	if cfg.HasCapability(fab.ChannelGroupKey, fab.V1_1Capability) {
+		req.ChannelCapabilities = []string{fab.V1_1Capability}
+	}
+	if cfg.HasCapability(fab.OrdererGroupKey, fab.V1_1Capability) {
+		req.OrdererCapabilities = []string{fab.V1_1Capability}
+	}
+	if cfg.HasCapability(fab.ApplicationGroupKey, fab.V1_1Capability) {
+		req.ApplicationCapabilities = []string{fab.V1_1Capability}
+	}
+	if len(req.ChannelCapabilities) == 0 && len(req.OrdererCapabilities) == 0 && len(req.ApplicationCapabilities) == 0 {
+		t.Skip("no capabilities are enabled on the channel")
+	}
+
+	resp, err := mc.org1ResMgmt.UpgradeCapabilities(req, resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "UpgradeCapabilities failed")
+	assert.Empty(t, resp.Updates, "no config update expected since the capabilities are already enabled")
+}
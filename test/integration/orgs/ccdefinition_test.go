@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orgs
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	packager "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/gopackager"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/hyperledger/fabric-sdk-go/test/integration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQueryChaincodeDefinition instantiates a chaincode on the channel and verifies its definition,
+// its collections config and that the instantiated chaincode is the one installed on the Org1 peers.
+func TestQueryChaincodeDefinition(t *testing.T) {
+
+	mc := multiorgContext{
+		ordererClientContext:   sdk.Context(fabsdk.WithUser(ordererAdminUser), fabsdk.WithOrg(ordererOrgName)),
+		org1AdminClientContext: sdk.Context(fabsdk.WithUser(org1AdminUser), fabsdk.WithOrg(org1)),
+		org2AdminClientContext: sdk.Context(fabsdk.WithUser(org2AdminUser), fabsdk.WithOrg(org2)),
+		ccName:                 "definition_cc_" + integration.GenerateRandomID(),
+	}
+	setupClientContextsAndChannel(t, sdk, &mc)
+
+	ccPkg, err := packager.NewCCPackage("github.com/example_cc", "../../fixtures/testdata")
+	require.NoError(t, err, "failed to package chaincode")
+
+	createCC(t, mc.org1ResMgmt, mc.org2ResMgmt, ccPkg, mc.ccName)
+
+	definition, err := mc.org1ResMgmt.QueryChaincodeDefinition(channelID, mc.ccName, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.Nil(t, err, "error should be nil for QueryChaincodeDefinition")
+	assert.Equal(t, mc.ccName, definition.Name)
+	assert.Equal(t, "0", definition.Version)
+	assert.Equal(t, "OutOf(1, 'Org1MSP.member', 'Org2MSP.member')", definition.PolicyExpression)
+	assert.Equal(t, "escc", definition.Escc)
+	assert.Equal(t, "vscc", definition.Vscc)
+
+	installCCReq := resmgmt.InstallCCRequest{Name: mc.ccName, Path: "github.com/example_cc", Version: "0", Package: ccPkg}
+	responses, err := mc.org1ResMgmt.VerifyInstalledCC(installCCReq, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.Nil(t, err, "error should be nil for VerifyInstalledCC")
+	require.NotEmpty(t, responses, "verification responses should be populated")
+	for _, response := range responses {
+		assert.Equal(t, definition.Fingerprint, response.Fingerprint, "instantiated chaincode should have been installed on %s", response.Target)
+	}
+
+	collConfig, err := mc.org1ResMgmt.QueryCollectionsConfig(channelID, mc.ccName, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.Nil(t, err, "error should be nil for QueryCollectionsConfig")
+	assert.Empty(t, collConfig.Config, "chaincode doesn't have collections")
+}
@@ -0,0 +1,29 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orgs
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQueryConsortiums queries the consortiums of the orderer system channel, which is only readable by the orderer admin
+func TestQueryConsortiums(t *testing.T) {
+
+	ordererResMgmt, err := resmgmt.New(sdk.Context(fabsdk.WithUser(ordererAdminUser), fabsdk.WithOrg(ordererOrgName)))
+	require.NoError(t, err, "failed to create orderer resource management client")
+
+	consortiums, err := ordererResMgmt.QueryConsortiums(resmgmt.DefaultSystemChannelID, resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "QueryConsortiums failed")
+	require.Len(t, consortiums, 1)
+	assert.Equal(t, "SampleConsortium", consortiums[0].Name)
+	assert.Equal(t, []string{"Org1MSP", "Org2MSP"}, consortiums[0].MSPIDs)
+}
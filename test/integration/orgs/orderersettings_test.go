@@ -0,0 +1,59 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orgs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrdererSettingsUpdate changes the batch timeout of the channel using the orderer admin, verifies the update
+// using the channel configuration from the orderer and then restores the original value
+func TestOrdererSettingsUpdate(t *testing.T) {
+
+	mc := multiorgContext{
+		ordererClientContext:   sdk.Context(fabsdk.WithUser(ordererAdminUser), fabsdk.WithOrg(ordererOrgName)),
+		org1AdminClientContext: sdk.Context(fabsdk.WithUser(org1AdminUser), fabsdk.WithOrg(org1)),
+		org2AdminClientContext: sdk.Context(fabsdk.WithUser(org2AdminUser), fabsdk.WithOrg(org2)),
+		ccName:                 exampleCC,
+	}
+	setupClientContextsAndChannel(t, sdk, &mc)
+
+	ordererResMgmt, err := resmgmt.New(mc.ordererClientContext)
+	require.NoError(t, err, "failed to create orderer resmgmt client")
+
+	settings, err := ordererResMgmt.QueryOrdererSettings(channelID, resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "QueryOrdererSettings failed")
+	original := settings.BatchTimeout
+
+	batchTimeout := original + time.Second
+	_, err = ordererResMgmt.UpdateOrdererSettings(resmgmt.OrdererSettingsRequest{ChannelID: channelID, BatchTimeout: batchTimeout}, resmgmt.WithRetry(retry.DefaultResMgmtOpts), resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "UpdateOrdererSettings failed")
+	require.True(t, waitForBatchTimeout(t, ordererResMgmt, batchTimeout), "batch timeout was not updated")
+
+	_, err = ordererResMgmt.UpdateOrdererSettings(resmgmt.OrdererSettingsRequest{ChannelID: channelID, BatchTimeout: original}, resmgmt.WithRetry(retry.DefaultResMgmtOpts), resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.NoError(t, err, "UpdateOrdererSettings failed")
+	require.True(t, waitForBatchTimeout(t, ordererResMgmt, original), "batch timeout was not restored")
+}
+
+// waitForBatchTimeout polls the orderer settings until the batch timeout has the expected value
+func waitForBatchTimeout(t *testing.T, rc *resmgmt.Client, expected time.Duration) bool {
+	for i := 0; i < pollRetries; i++ {
+		settings, err := rc.QueryOrdererSettings(channelID, resmgmt.WithOrdererEndpoint("orderer.example.com"))
+		require.NoError(t, err, "QueryOrdererSettings failed")
+		if settings.BatchTimeout == expected {
+			return true
+		}
+		time.Sleep(time.Second)
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orgs
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	packager "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/gopackager"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/hyperledger/fabric-sdk-go/test/integration"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChaincodeRollout rolls out a new chaincode and then upgrades it across both organizations. Repeating the
+// upgrade rollout resumes it, which skips all the stages since they have been completed.
+func TestChaincodeRollout(t *testing.T) {
+
+	mc := multiorgContext{
+		ordererClientContext:   sdk.Context(fabsdk.WithUser(ordererAdminUser), fabsdk.WithOrg(ordererOrgName)),
+		org1AdminClientContext: sdk.Context(fabsdk.WithUser(org1AdminUser), fabsdk.WithOrg(org1)),
+		org2AdminClientContext: sdk.Context(fabsdk.WithUser(org2AdminUser), fabsdk.WithOrg(org2)),
+		ccName:                 "rollout_cc_" + integration.GenerateRandomID(),
+	}
+	setupClientContextsAndChannel(t, sdk, &mc)
+
+	ccPkg, err := packager.NewCCPackage("github.com/example_cc", "../../fixtures/testdata")
+	require.NoError(t, err, "failed to package chaincode")
+
+	var progress []resmgmt.RolloutProgress
+	req := resmgmt.RolloutCCRequest{
+		Orgs: []resmgmt.RolloutOrg{
+			{Name: "Org1MSP", Client: mc.org1ResMgmt},
+			{Name: "Org2MSP", Client: mc.org2ResMgmt},
+		},
+		Install:  resmgmt.InstallCCRequest{Name: mc.ccName, Path: "github.com/example_cc", Version: "0", Package: ccPkg},
+		Args:     integration.ExampleCCInitArgs(),
+		Policy:   cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP"}),
+		Progress: func(p resmgmt.RolloutProgress) { progress = append(progress, p) },
+	}
+
+	resp, err := mc.org1ResMgmt.RolloutCC(channelID, req, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.NoError(t, err, "rollout of version 0 failed")
+	assert.False(t, resp.Upgraded)
+	assert.NotEmpty(t, resp.TransactionID)
+	assert.Len(t, resp.Installed, 2)
+
+	req.Install.Version = "1"
+	req.Args = integration.ExampleCCUpgradeArgs()
+	resp, err = mc.org1ResMgmt.RolloutCC(channelID, req, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.NoError(t, err, "rollout of version 1 failed")
+	assert.True(t, resp.Upgraded)
+	assert.NotEmpty(t, resp.TransactionID)
+
+	progress = nil
+	resp, err = mc.org1ResMgmt.RolloutCC(channelID, req, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.NoError(t, err, "resumed rollout of version 1 failed")
+	assert.Empty(t, resp.TransactionID)
+	assert.Empty(t, resp.Installed)
+	require.Len(t, progress, 5)
+	for _, p := range progress[:3] {
+		assert.True(t, p.Skipped, "install and deploy stages should be skipped")
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orgs
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	packager "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/gopackager"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/hyperledger/fabric-sdk-go/test/integration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInstantiateCCWithSignaturePolicy instantiates a chaincode with an endorsement policy created from
+// a policy expression that is verified against the channel configuration.
+func TestInstantiateCCWithSignaturePolicy(t *testing.T) {
+
+	mc := multiorgContext{
+		ordererClientContext:   sdk.Context(fabsdk.WithUser(ordererAdminUser), fabsdk.WithOrg(ordererOrgName)),
+		org1AdminClientContext: sdk.Context(fabsdk.WithUser(org1AdminUser), fabsdk.WithOrg(org1)),
+		org2AdminClientContext: sdk.Context(fabsdk.WithUser(org2AdminUser), fabsdk.WithOrg(org2)),
+		ccName:                 "policy_cc_" + integration.GenerateRandomID(),
+	}
+	setupClientContextsAndChannel(t, sdk, &mc)
+
+	chConfig, err := mc.org1ResMgmt.QueryConfigFromOrderer(channelID, resmgmt.WithOrdererEndpoint("orderer.example.com"))
+	require.Nil(t, err, "error should be nil for QueryConfigFromOrderer")
+
+	// Org3MSP is not a member of the channel
+	_, err = resmgmt.NewSignaturePolicy("AND ('Org1MSP.member','Org3MSP.member')", chConfig)
+	assert.Error(t, err, "policy referencing an MSP which is not a member of the channel should be rejected")
+
+	org1Andorg2Policy, err := resmgmt.NewSignaturePolicy("AND ('Org1MSP.member','Org2MSP.member')", chConfig)
+	require.Nil(t, err, "error should be nil for getting cc policy with both orgs to approve")
+
+	ccPkg, err := packager.NewCCPackage("github.com/example_cc", "../../fixtures/testdata")
+	require.NoError(t, err, "failed to package chaincode")
+
+	installCCReq := resmgmt.InstallCCRequest{Name: mc.ccName, Path: "github.com/example_cc", Version: "0", Package: ccPkg}
+	_, err = mc.org1ResMgmt.InstallCC(installCCReq, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.NoError(t, err, "InstallCC for Org1 failed")
+	_, err = mc.org2ResMgmt.InstallCC(installCCReq, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.NoError(t, err, "InstallCC for Org2 failed")
+
+	instantiateResp, err := mc.org1ResMgmt.InstantiateCC(
+		channelID,
+		resmgmt.InstantiateCCRequest{
+			Name:    mc.ccName,
+			Path:    "github.com/example_cc",
+			Version: "0",
+			Args:    integration.ExampleCCInitArgs(),
+			Policy:  org1Andorg2Policy,
+		},
+		resmgmt.WithRetry(retry.DefaultResMgmtOpts),
+		resmgmt.WithTargetEndpoints("peer0.org1.example.com"),
+	)
+	require.Nil(t, err, "error should be nil for InstantiateCC")
+	require.NotEmpty(t, instantiateResp, "transaction response should be populated for InstantiateCC")
+
+	definition, err := mc.org1ResMgmt.QueryChaincodeDefinition(channelID, mc.ccName, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.Nil(t, err, "error should be nil for QueryChaincodeDefinition")
+	assert.Equal(t, "OutOf(2, 'Org1MSP.member', 'Org2MSP.member')", definition.PolicyExpression)
+}
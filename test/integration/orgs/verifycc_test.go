@@ -0,0 +1,61 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orgs
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	packager "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/gopackager"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/hyperledger/fabric-sdk-go/test/integration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerifyInstalledCC installs a chaincode on the Org1 peers and verifies that the installed chaincode matches
+// the chaincode package, and that a package with the same name and version built from different code is flagged.
+func TestVerifyInstalledCC(t *testing.T) {
+
+	mc := multiorgContext{
+		ordererClientContext:   sdk.Context(fabsdk.WithUser(ordererAdminUser), fabsdk.WithOrg(ordererOrgName)),
+		org1AdminClientContext: sdk.Context(fabsdk.WithUser(org1AdminUser), fabsdk.WithOrg(org1)),
+		org2AdminClientContext: sdk.Context(fabsdk.WithUser(org2AdminUser), fabsdk.WithOrg(org2)),
+		ccName:                 "verify_cc_" + integration.GenerateRandomID(),
+	}
+	setupClientContextsAndChannel(t, sdk, &mc)
+
+	ccPkg, err := packager.NewCCPackage("github.com/example_cc", "../../fixtures/testdata")
+	require.NoError(t, err, "failed to package chaincode")
+
+	installCCReq := resmgmt.InstallCCRequest{Name: mc.ccName, Path: "github.com/example_cc", Version: "0", Package: ccPkg}
+	_, err = mc.org1ResMgmt.InstallCC(installCCReq, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.NoError(t, err, "InstallCC for Org1 failed")
+
+	verifyInstalledCC(t, mc.org1ResMgmt, installCCReq)
+}
+
+func verifyInstalledCC(t *testing.T, orgResMgmt *resmgmt.Client, installCCReq resmgmt.InstallCCRequest) {
+	responses, err := orgResMgmt.VerifyInstalledCC(installCCReq, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.Nil(t, err, "error should be nil for VerifyInstalledCC")
+	require.NotEmpty(t, responses, "verification responses should be populated")
+	for _, response := range responses {
+		assert.True(t, response.Installed, "chaincode should be installed on %s", response.Target)
+		assert.False(t, response.Mismatch, "chaincode installed on %s should match the package", response.Target)
+	}
+
+	// The same name and version built from different code must be flagged
+	modifiedReq := installCCReq
+	modifiedReq.Package = &resource.CCPackage{Type: installCCReq.Package.Type, Code: append([]byte("modified"), installCCReq.Package.Code...)}
+	responses, err = orgResMgmt.VerifyInstalledCC(modifiedReq, resmgmt.WithRetry(retry.DefaultResMgmtOpts))
+	require.Nil(t, err, "error should be nil for VerifyInstalledCC")
+	for _, response := range responses {
+		assert.True(t, response.Mismatch, "chaincode installed on %s should not match the modified package", response.Target)
+	}
+}
@@ -0,0 +1,1079 @@
+/*
+Notice: This file has been modified for Hyperledger Fabric SDK Go usage.
+Please review third_party pinning scripts and patches for more details.
+*/
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer/lifecycle/lifecycle.proto
+
+/*
+Package lifecycle is a generated protocol buffer package.
+
+It is generated from these files:
+	peer/lifecycle/lifecycle.proto
+
+It has these top-level messages:
+	InstallChaincodeArgs
+	InstallChaincodeResult
+	QueryInstalledChaincodeArgs
+	QueryInstalledChaincodeResult
+	GetInstalledChaincodePackageArgs
+	GetInstalledChaincodePackageResult
+	QueryInstalledChaincodesArgs
+	QueryInstalledChaincodesResult
+	ApproveChaincodeDefinitionForMyOrgArgs
+	ChaincodeSource
+	ApproveChaincodeDefinitionForMyOrgResult
+	CommitChaincodeDefinitionArgs
+	CommitChaincodeDefinitionResult
+	CheckCommitReadinessArgs
+	CheckCommitReadinessResult
+	QueryChaincodeDefinitionArgs
+	QueryChaincodeDefinitionResult
+	QueryChaincodeDefinitionsArgs
+	QueryChaincodeDefinitionsResult
+*/
+package lifecycle
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common2 "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// InstallChaincodeArgs is the message used as the argument to
+// '_lifecycle.InstallChaincode'.
+type InstallChaincodeArgs struct {
+	ChaincodeInstallPackage []byte `protobuf:"bytes,1,opt,name=chaincode_install_package,json=chaincodeInstallPackage,proto3" json:"chaincode_install_package,omitempty"`
+}
+
+func (m *InstallChaincodeArgs) Reset()                    { *m = InstallChaincodeArgs{} }
+func (m *InstallChaincodeArgs) String() string            { return proto.CompactTextString(m) }
+func (*InstallChaincodeArgs) ProtoMessage()               {}
+func (*InstallChaincodeArgs) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *InstallChaincodeArgs) GetChaincodeInstallPackage() []byte {
+	if m != nil {
+		return m.ChaincodeInstallPackage
+	}
+	return nil
+}
+
+// InstallChaincodeArgs is the message returned by
+// '_lifecycle.InstallChaincode'.
+type InstallChaincodeResult struct {
+	PackageId string `protobuf:"bytes,1,opt,name=package_id,json=packageId" json:"package_id,omitempty"`
+	Label     string `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+}
+
+func (m *InstallChaincodeResult) Reset()                    { *m = InstallChaincodeResult{} }
+func (m *InstallChaincodeResult) String() string            { return proto.CompactTextString(m) }
+func (*InstallChaincodeResult) ProtoMessage()               {}
+func (*InstallChaincodeResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *InstallChaincodeResult) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+func (m *InstallChaincodeResult) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+// QueryInstalledChaincodeArgs is the message used as arguments
+// '_lifecycle.QueryInstalledChaincode'
+type QueryInstalledChaincodeArgs struct {
+	PackageId string `protobuf:"bytes,1,opt,name=package_id,json=packageId" json:"package_id,omitempty"`
+}
+
+func (m *QueryInstalledChaincodeArgs) Reset()                    { *m = QueryInstalledChaincodeArgs{} }
+func (m *QueryInstalledChaincodeArgs) String() string            { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodeArgs) ProtoMessage()               {}
+func (*QueryInstalledChaincodeArgs) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *QueryInstalledChaincodeArgs) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+// QueryInstalledChaincodeResult is the message returned by
+// '_lifecycle.QueryInstalledChaincode'
+type QueryInstalledChaincodeResult struct {
+	PackageId  string                                               `protobuf:"bytes,1,opt,name=package_id,json=packageId" json:"package_id,omitempty"`
+	Label      string                                               `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+	References map[string]*QueryInstalledChaincodeResult_References `protobuf:"bytes,3,rep,name=references" json:"references,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *QueryInstalledChaincodeResult) Reset()                    { *m = QueryInstalledChaincodeResult{} }
+func (m *QueryInstalledChaincodeResult) String() string            { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodeResult) ProtoMessage()               {}
+func (*QueryInstalledChaincodeResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *QueryInstalledChaincodeResult) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodeResult) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodeResult) GetReferences() map[string]*QueryInstalledChaincodeResult_References {
+	if m != nil {
+		return m.References
+	}
+	return nil
+}
+
+type QueryInstalledChaincodeResult_References struct {
+	Chaincodes []*QueryInstalledChaincodeResult_Chaincode `protobuf:"bytes,1,rep,name=chaincodes" json:"chaincodes,omitempty"`
+}
+
+func (m *QueryInstalledChaincodeResult_References) Reset() {
+	*m = QueryInstalledChaincodeResult_References{}
+}
+func (m *QueryInstalledChaincodeResult_References) String() string { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodeResult_References) ProtoMessage()    {}
+func (*QueryInstalledChaincodeResult_References) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{3, 1}
+}
+
+func (m *QueryInstalledChaincodeResult_References) GetChaincodes() []*QueryInstalledChaincodeResult_Chaincode {
+	if m != nil {
+		return m.Chaincodes
+	}
+	return nil
+}
+
+type QueryInstalledChaincodeResult_Chaincode struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *QueryInstalledChaincodeResult_Chaincode) Reset() {
+	*m = QueryInstalledChaincodeResult_Chaincode{}
+}
+func (m *QueryInstalledChaincodeResult_Chaincode) String() string { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodeResult_Chaincode) ProtoMessage()    {}
+func (*QueryInstalledChaincodeResult_Chaincode) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{3, 2}
+}
+
+func (m *QueryInstalledChaincodeResult_Chaincode) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodeResult_Chaincode) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+// GetInstalledChaincodePackageArgs is the message used as the argument to
+// '_lifecycle.GetInstalledChaincodePackage'.
+type GetInstalledChaincodePackageArgs struct {
+	PackageId string `protobuf:"bytes,1,opt,name=package_id,json=packageId" json:"package_id,omitempty"`
+}
+
+func (m *GetInstalledChaincodePackageArgs) Reset()         { *m = GetInstalledChaincodePackageArgs{} }
+func (m *GetInstalledChaincodePackageArgs) String() string { return proto.CompactTextString(m) }
+func (*GetInstalledChaincodePackageArgs) ProtoMessage()    {}
+func (*GetInstalledChaincodePackageArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{4}
+}
+
+func (m *GetInstalledChaincodePackageArgs) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+// GetInstalledChaincodePackageResult is the message returned by
+// '_lifecycle.GetInstalledChaincodePackage'.
+type GetInstalledChaincodePackageResult struct {
+	ChaincodeInstallPackage []byte `protobuf:"bytes,1,opt,name=chaincode_install_package,json=chaincodeInstallPackage,proto3" json:"chaincode_install_package,omitempty"`
+}
+
+func (m *GetInstalledChaincodePackageResult) Reset()         { *m = GetInstalledChaincodePackageResult{} }
+func (m *GetInstalledChaincodePackageResult) String() string { return proto.CompactTextString(m) }
+func (*GetInstalledChaincodePackageResult) ProtoMessage()    {}
+func (*GetInstalledChaincodePackageResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{5}
+}
+
+func (m *GetInstalledChaincodePackageResult) GetChaincodeInstallPackage() []byte {
+	if m != nil {
+		return m.ChaincodeInstallPackage
+	}
+	return nil
+}
+
+// QueryInstalledChaincodesArgs currently is an empty argument to
+// '_lifecycle.QueryInstalledChaincodes'.   In the future, it may be
+// extended to have parameters.
+type QueryInstalledChaincodesArgs struct {
+}
+
+func (m *QueryInstalledChaincodesArgs) Reset()                    { *m = QueryInstalledChaincodesArgs{} }
+func (m *QueryInstalledChaincodesArgs) String() string            { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodesArgs) ProtoMessage()               {}
+func (*QueryInstalledChaincodesArgs) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+// QueryInstalledChaincodesResult is the message returned by
+// '_lifecycle.QueryInstalledChaincodes'.  It returns a list of installed
+// chaincodes, including a map of channel name to chaincode name and version
+// pairs of chaincode definitions that reference this chaincode package.
+type QueryInstalledChaincodesResult struct {
+	InstalledChaincodes []*QueryInstalledChaincodesResult_InstalledChaincode `protobuf:"bytes,1,rep,name=installed_chaincodes,json=installedChaincodes" json:"installed_chaincodes,omitempty"`
+}
+
+func (m *QueryInstalledChaincodesResult) Reset()                    { *m = QueryInstalledChaincodesResult{} }
+func (m *QueryInstalledChaincodesResult) String() string            { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodesResult) ProtoMessage()               {}
+func (*QueryInstalledChaincodesResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *QueryInstalledChaincodesResult) GetInstalledChaincodes() []*QueryInstalledChaincodesResult_InstalledChaincode {
+	if m != nil {
+		return m.InstalledChaincodes
+	}
+	return nil
+}
+
+type QueryInstalledChaincodesResult_InstalledChaincode struct {
+	PackageId  string                                                `protobuf:"bytes,1,opt,name=package_id,json=packageId" json:"package_id,omitempty"`
+	Label      string                                                `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+	References map[string]*QueryInstalledChaincodesResult_References `protobuf:"bytes,3,rep,name=references" json:"references,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) Reset() {
+	*m = QueryInstalledChaincodesResult_InstalledChaincode{}
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) String() string {
+	return proto.CompactTextString(m)
+}
+func (*QueryInstalledChaincodesResult_InstalledChaincode) ProtoMessage() {}
+func (*QueryInstalledChaincodesResult_InstalledChaincode) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{7, 0}
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetReferences() map[string]*QueryInstalledChaincodesResult_References {
+	if m != nil {
+		return m.References
+	}
+	return nil
+}
+
+type QueryInstalledChaincodesResult_References struct {
+	Chaincodes []*QueryInstalledChaincodesResult_Chaincode `protobuf:"bytes,1,rep,name=chaincodes" json:"chaincodes,omitempty"`
+}
+
+func (m *QueryInstalledChaincodesResult_References) Reset() {
+	*m = QueryInstalledChaincodesResult_References{}
+}
+func (m *QueryInstalledChaincodesResult_References) String() string {
+	return proto.CompactTextString(m)
+}
+func (*QueryInstalledChaincodesResult_References) ProtoMessage() {}
+func (*QueryInstalledChaincodesResult_References) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{7, 1}
+}
+
+func (m *QueryInstalledChaincodesResult_References) GetChaincodes() []*QueryInstalledChaincodesResult_Chaincode {
+	if m != nil {
+		return m.Chaincodes
+	}
+	return nil
+}
+
+type QueryInstalledChaincodesResult_Chaincode struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *QueryInstalledChaincodesResult_Chaincode) Reset() {
+	*m = QueryInstalledChaincodesResult_Chaincode{}
+}
+func (m *QueryInstalledChaincodesResult_Chaincode) String() string { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodesResult_Chaincode) ProtoMessage()    {}
+func (*QueryInstalledChaincodesResult_Chaincode) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{7, 2}
+}
+
+func (m *QueryInstalledChaincodesResult_Chaincode) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodesResult_Chaincode) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+// ApproveChaincodeDefinitionForMyOrgArgs is the message used as arguments to
+// `_lifecycle.ApproveChaincodeDefinitionForMyOrg`.
+type ApproveChaincodeDefinitionForMyOrgArgs struct {
+	Sequence            int64                            `protobuf:"varint,1,opt,name=sequence" json:"sequence,omitempty"`
+	Name                string                           `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Version             string                           `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
+	EndorsementPlugin   string                           `protobuf:"bytes,4,opt,name=endorsement_plugin,json=endorsementPlugin" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin    string                           `protobuf:"bytes,5,opt,name=validation_plugin,json=validationPlugin" json:"validation_plugin,omitempty"`
+	ValidationParameter []byte                           `protobuf:"bytes,6,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections         *common2.CollectionConfigPackage `protobuf:"bytes,7,opt,name=collections" json:"collections,omitempty"`
+	InitRequired        bool                             `protobuf:"varint,8,opt,name=init_required,json=initRequired" json:"init_required,omitempty"`
+	Source              *ChaincodeSource                 `protobuf:"bytes,9,opt,name=source" json:"source,omitempty"`
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) Reset() {
+	*m = ApproveChaincodeDefinitionForMyOrgArgs{}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) String() string { return proto.CompactTextString(m) }
+func (*ApproveChaincodeDefinitionForMyOrgArgs) ProtoMessage()    {}
+func (*ApproveChaincodeDefinitionForMyOrgArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{8}
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetCollections() *common2.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetSource() *ChaincodeSource {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+type ChaincodeSource struct {
+	// Types that are valid to be assigned to Type:
+	//	*ChaincodeSource_Unavailable_
+	//	*ChaincodeSource_LocalPackage
+	Type isChaincodeSource_Type `protobuf_oneof:"Type"`
+}
+
+func (m *ChaincodeSource) Reset()                    { *m = ChaincodeSource{} }
+func (m *ChaincodeSource) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeSource) ProtoMessage()               {}
+func (*ChaincodeSource) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+type isChaincodeSource_Type interface{ isChaincodeSource_Type() }
+
+type ChaincodeSource_Unavailable_ struct {
+	Unavailable *ChaincodeSource_Unavailable `protobuf:"bytes,1,opt,name=unavailable,oneof"`
+}
+type ChaincodeSource_LocalPackage struct {
+	LocalPackage *ChaincodeSource_Local `protobuf:"bytes,2,opt,name=local_package,json=localPackage,oneof"`
+}
+
+func (*ChaincodeSource_Unavailable_) isChaincodeSource_Type() {}
+func (*ChaincodeSource_LocalPackage) isChaincodeSource_Type() {}
+
+func (m *ChaincodeSource) GetType() isChaincodeSource_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *ChaincodeSource) GetUnavailable() *ChaincodeSource_Unavailable {
+	if x, ok := m.GetType().(*ChaincodeSource_Unavailable_); ok {
+		return x.Unavailable
+	}
+	return nil
+}
+
+func (m *ChaincodeSource) GetLocalPackage() *ChaincodeSource_Local {
+	if x, ok := m.GetType().(*ChaincodeSource_LocalPackage); ok {
+		return x.LocalPackage
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ChaincodeSource) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ChaincodeSource_OneofMarshaler, _ChaincodeSource_OneofUnmarshaler, _ChaincodeSource_OneofSizer, []interface{}{
+		(*ChaincodeSource_Unavailable_)(nil),
+		(*ChaincodeSource_LocalPackage)(nil),
+	}
+}
+
+func _ChaincodeSource_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ChaincodeSource)
+	// Type
+	switch x := m.Type.(type) {
+	case *ChaincodeSource_Unavailable_:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Unavailable); err != nil {
+			return err
+		}
+	case *ChaincodeSource_LocalPackage:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LocalPackage); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ChaincodeSource.Type has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ChaincodeSource_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ChaincodeSource)
+	switch tag {
+	case 1: // Type.unavailable
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChaincodeSource_Unavailable)
+		err := b.DecodeMessage(msg)
+		m.Type = &ChaincodeSource_Unavailable_{msg}
+		return true, err
+	case 2: // Type.local_package
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChaincodeSource_Local)
+		err := b.DecodeMessage(msg)
+		m.Type = &ChaincodeSource_LocalPackage{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ChaincodeSource_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ChaincodeSource)
+	// Type
+	switch x := m.Type.(type) {
+	case *ChaincodeSource_Unavailable_:
+		s := proto.Size(x.Unavailable)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ChaincodeSource_LocalPackage:
+		s := proto.Size(x.LocalPackage)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type ChaincodeSource_Unavailable struct {
+}
+
+func (m *ChaincodeSource_Unavailable) Reset()                    { *m = ChaincodeSource_Unavailable{} }
+func (m *ChaincodeSource_Unavailable) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeSource_Unavailable) ProtoMessage()               {}
+func (*ChaincodeSource_Unavailable) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9, 0} }
+
+type ChaincodeSource_Local struct {
+	PackageId string `protobuf:"bytes,1,opt,name=package_id,json=packageId" json:"package_id,omitempty"`
+}
+
+func (m *ChaincodeSource_Local) Reset()                    { *m = ChaincodeSource_Local{} }
+func (m *ChaincodeSource_Local) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeSource_Local) ProtoMessage()               {}
+func (*ChaincodeSource_Local) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9, 1} }
+
+func (m *ChaincodeSource_Local) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+// ApproveChaincodeDefinitionForMyOrgResult is the message returned by
+// `_lifecycle.ApproveChaincodeDefinitionForMyOrg`. Currently it returns
+// nothing, but may be extended in the future.
+type ApproveChaincodeDefinitionForMyOrgResult struct {
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgResult) Reset() {
+	*m = ApproveChaincodeDefinitionForMyOrgResult{}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) String() string { return proto.CompactTextString(m) }
+func (*ApproveChaincodeDefinitionForMyOrgResult) ProtoMessage()    {}
+func (*ApproveChaincodeDefinitionForMyOrgResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{10}
+}
+
+// CommitChaincodeDefinitionArgs is the message used as arguments to
+// `_lifecycle.CommitChaincodeDefinition`.
+type CommitChaincodeDefinitionArgs struct {
+	Sequence            int64                            `protobuf:"varint,1,opt,name=sequence" json:"sequence,omitempty"`
+	Name                string                           `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Version             string                           `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
+	EndorsementPlugin   string                           `protobuf:"bytes,4,opt,name=endorsement_plugin,json=endorsementPlugin" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin    string                           `protobuf:"bytes,5,opt,name=validation_plugin,json=validationPlugin" json:"validation_plugin,omitempty"`
+	ValidationParameter []byte                           `protobuf:"bytes,6,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections         *common2.CollectionConfigPackage `protobuf:"bytes,7,opt,name=collections" json:"collections,omitempty"`
+	InitRequired        bool                             `protobuf:"varint,8,opt,name=init_required,json=initRequired" json:"init_required,omitempty"`
+}
+
+func (m *CommitChaincodeDefinitionArgs) Reset()                    { *m = CommitChaincodeDefinitionArgs{} }
+func (m *CommitChaincodeDefinitionArgs) String() string            { return proto.CompactTextString(m) }
+func (*CommitChaincodeDefinitionArgs) ProtoMessage()               {}
+func (*CommitChaincodeDefinitionArgs) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *CommitChaincodeDefinitionArgs) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetCollections() *common2.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+// CommitChaincodeDefinitionResult is the message returned by
+// `_lifecycle.CommitChaincodeDefinition`. Currently it returns
+// nothing, but may be extended in the future.
+type CommitChaincodeDefinitionResult struct {
+}
+
+func (m *CommitChaincodeDefinitionResult) Reset()         { *m = CommitChaincodeDefinitionResult{} }
+func (m *CommitChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*CommitChaincodeDefinitionResult) ProtoMessage()    {}
+func (*CommitChaincodeDefinitionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{12}
+}
+
+// CheckCommitReadinessArgs is the message used as arguments to
+// `_lifecycle.CheckCommitReadiness`.
+type CheckCommitReadinessArgs struct {
+	Sequence            int64                            `protobuf:"varint,1,opt,name=sequence" json:"sequence,omitempty"`
+	Name                string                           `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Version             string                           `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
+	EndorsementPlugin   string                           `protobuf:"bytes,4,opt,name=endorsement_plugin,json=endorsementPlugin" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin    string                           `protobuf:"bytes,5,opt,name=validation_plugin,json=validationPlugin" json:"validation_plugin,omitempty"`
+	ValidationParameter []byte                           `protobuf:"bytes,6,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections         *common2.CollectionConfigPackage `protobuf:"bytes,7,opt,name=collections" json:"collections,omitempty"`
+	InitRequired        bool                             `protobuf:"varint,8,opt,name=init_required,json=initRequired" json:"init_required,omitempty"`
+}
+
+func (m *CheckCommitReadinessArgs) Reset()                    { *m = CheckCommitReadinessArgs{} }
+func (m *CheckCommitReadinessArgs) String() string            { return proto.CompactTextString(m) }
+func (*CheckCommitReadinessArgs) ProtoMessage()               {}
+func (*CheckCommitReadinessArgs) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *CheckCommitReadinessArgs) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *CheckCommitReadinessArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CheckCommitReadinessArgs) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CheckCommitReadinessArgs) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *CheckCommitReadinessArgs) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *CheckCommitReadinessArgs) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *CheckCommitReadinessArgs) GetCollections() *common2.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *CheckCommitReadinessArgs) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+// CheckCommitReadinessResult is the message returned by
+// `_lifecycle.CheckCommitReadiness`. It returns a map of
+// orgs to their approval (true/false) for the definition
+// supplied as args.
+type CheckCommitReadinessResult struct {
+	Approvals map[string]bool `protobuf:"bytes,1,rep,name=approvals" json:"approvals,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+func (m *CheckCommitReadinessResult) Reset()                    { *m = CheckCommitReadinessResult{} }
+func (m *CheckCommitReadinessResult) String() string            { return proto.CompactTextString(m) }
+func (*CheckCommitReadinessResult) ProtoMessage()               {}
+func (*CheckCommitReadinessResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *CheckCommitReadinessResult) GetApprovals() map[string]bool {
+	if m != nil {
+		return m.Approvals
+	}
+	return nil
+}
+
+// QueryChaincodeDefinitionArgs is the message used as arguments to
+// `_lifecycle.QueryChaincodeDefinition`.
+type QueryChaincodeDefinitionArgs struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *QueryChaincodeDefinitionArgs) Reset()                    { *m = QueryChaincodeDefinitionArgs{} }
+func (m *QueryChaincodeDefinitionArgs) String() string            { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionArgs) ProtoMessage()               {}
+func (*QueryChaincodeDefinitionArgs) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *QueryChaincodeDefinitionArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// QueryChaincodeDefinitionResult is the message returned by
+// `_lifecycle.QueryChaincodeDefinition`.
+type QueryChaincodeDefinitionResult struct {
+	Sequence            int64                            `protobuf:"varint,1,opt,name=sequence" json:"sequence,omitempty"`
+	Version             string                           `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+	EndorsementPlugin   string                           `protobuf:"bytes,3,opt,name=endorsement_plugin,json=endorsementPlugin" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin    string                           `protobuf:"bytes,4,opt,name=validation_plugin,json=validationPlugin" json:"validation_plugin,omitempty"`
+	ValidationParameter []byte                           `protobuf:"bytes,5,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections         *common2.CollectionConfigPackage `protobuf:"bytes,6,opt,name=collections" json:"collections,omitempty"`
+	InitRequired        bool                             `protobuf:"varint,7,opt,name=init_required,json=initRequired" json:"init_required,omitempty"`
+	Approvals           map[string]bool                  `protobuf:"bytes,8,rep,name=approvals" json:"approvals,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+func (m *QueryChaincodeDefinitionResult) Reset()         { *m = QueryChaincodeDefinitionResult{} }
+func (m *QueryChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionResult) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{16}
+}
+
+func (m *QueryChaincodeDefinitionResult) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *QueryChaincodeDefinitionResult) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionResult) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionResult) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionResult) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionResult) GetCollections() *common2.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionResult) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+func (m *QueryChaincodeDefinitionResult) GetApprovals() map[string]bool {
+	if m != nil {
+		return m.Approvals
+	}
+	return nil
+}
+
+// QueryChaincodeDefinitionsArgs is the message used as arguments to
+// `_lifecycle.QueryChaincodeDefinitions`.
+type QueryChaincodeDefinitionsArgs struct {
+}
+
+func (m *QueryChaincodeDefinitionsArgs) Reset()                    { *m = QueryChaincodeDefinitionsArgs{} }
+func (m *QueryChaincodeDefinitionsArgs) String() string            { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionsArgs) ProtoMessage()               {}
+func (*QueryChaincodeDefinitionsArgs) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+// QueryChaincodeDefinitionsResult is the message returned by
+// `_lifecycle.QueryChaincodeDefinitions`.
+type QueryChaincodeDefinitionsResult struct {
+	ChaincodeDefinitions []*QueryChaincodeDefinitionsResult_ChaincodeDefinition `protobuf:"bytes,1,rep,name=chaincode_definitions,json=chaincodeDefinitions" json:"chaincode_definitions,omitempty"`
+}
+
+func (m *QueryChaincodeDefinitionsResult) Reset()         { *m = QueryChaincodeDefinitionsResult{} }
+func (m *QueryChaincodeDefinitionsResult) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionsResult) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionsResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{18}
+}
+
+func (m *QueryChaincodeDefinitionsResult) GetChaincodeDefinitions() []*QueryChaincodeDefinitionsResult_ChaincodeDefinition {
+	if m != nil {
+		return m.ChaincodeDefinitions
+	}
+	return nil
+}
+
+type QueryChaincodeDefinitionsResult_ChaincodeDefinition struct {
+	Name                string                           `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Sequence            int64                            `protobuf:"varint,2,opt,name=sequence" json:"sequence,omitempty"`
+	Version             string                           `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
+	EndorsementPlugin   string                           `protobuf:"bytes,4,opt,name=endorsement_plugin,json=endorsementPlugin" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin    string                           `protobuf:"bytes,5,opt,name=validation_plugin,json=validationPlugin" json:"validation_plugin,omitempty"`
+	ValidationParameter []byte                           `protobuf:"bytes,6,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections         *common2.CollectionConfigPackage `protobuf:"bytes,7,opt,name=collections" json:"collections,omitempty"`
+	InitRequired        bool                             `protobuf:"varint,8,opt,name=init_required,json=initRequired" json:"init_required,omitempty"`
+}
+
+func (m *QueryChaincodeDefinitionsResult_ChaincodeDefinition) Reset() {
+	*m = QueryChaincodeDefinitionsResult_ChaincodeDefinition{}
+}
+func (m *QueryChaincodeDefinitionsResult_ChaincodeDefinition) String() string {
+	return proto.CompactTextString(m)
+}
+func (*QueryChaincodeDefinitionsResult_ChaincodeDefinition) ProtoMessage() {}
+func (*QueryChaincodeDefinitionsResult_ChaincodeDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{18, 0}
+}
+
+func (m *QueryChaincodeDefinitionsResult_ChaincodeDefinition) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionsResult_ChaincodeDefinition) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *QueryChaincodeDefinitionsResult_ChaincodeDefinition) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionsResult_ChaincodeDefinition) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionsResult_ChaincodeDefinition) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionsResult_ChaincodeDefinition) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionsResult_ChaincodeDefinition) GetCollections() *common2.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionsResult_ChaincodeDefinition) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*InstallChaincodeArgs)(nil), "sdk.lifecycle.InstallChaincodeArgs")
+	proto.RegisterType((*InstallChaincodeResult)(nil), "sdk.lifecycle.InstallChaincodeResult")
+	proto.RegisterType((*QueryInstalledChaincodeArgs)(nil), "sdk.lifecycle.QueryInstalledChaincodeArgs")
+	proto.RegisterType((*QueryInstalledChaincodeResult)(nil), "sdk.lifecycle.QueryInstalledChaincodeResult")
+	proto.RegisterType((*QueryInstalledChaincodeResult_References)(nil), "sdk.lifecycle.QueryInstalledChaincodeResult.References")
+	proto.RegisterType((*QueryInstalledChaincodeResult_Chaincode)(nil), "sdk.lifecycle.QueryInstalledChaincodeResult.Chaincode")
+	proto.RegisterType((*GetInstalledChaincodePackageArgs)(nil), "sdk.lifecycle.GetInstalledChaincodePackageArgs")
+	proto.RegisterType((*GetInstalledChaincodePackageResult)(nil), "sdk.lifecycle.GetInstalledChaincodePackageResult")
+	proto.RegisterType((*QueryInstalledChaincodesArgs)(nil), "sdk.lifecycle.QueryInstalledChaincodesArgs")
+	proto.RegisterType((*QueryInstalledChaincodesResult)(nil), "sdk.lifecycle.QueryInstalledChaincodesResult")
+	proto.RegisterType((*QueryInstalledChaincodesResult_InstalledChaincode)(nil), "sdk.lifecycle.QueryInstalledChaincodesResult.InstalledChaincode")
+	proto.RegisterType((*QueryInstalledChaincodesResult_References)(nil), "sdk.lifecycle.QueryInstalledChaincodesResult.References")
+	proto.RegisterType((*QueryInstalledChaincodesResult_Chaincode)(nil), "sdk.lifecycle.QueryInstalledChaincodesResult.Chaincode")
+	proto.RegisterType((*ApproveChaincodeDefinitionForMyOrgArgs)(nil), "sdk.lifecycle.ApproveChaincodeDefinitionForMyOrgArgs")
+	proto.RegisterType((*ChaincodeSource)(nil), "sdk.lifecycle.ChaincodeSource")
+	proto.RegisterType((*ChaincodeSource_Unavailable)(nil), "sdk.lifecycle.ChaincodeSource.Unavailable")
+	proto.RegisterType((*ChaincodeSource_Local)(nil), "sdk.lifecycle.ChaincodeSource.Local")
+	proto.RegisterType((*ApproveChaincodeDefinitionForMyOrgResult)(nil), "sdk.lifecycle.ApproveChaincodeDefinitionForMyOrgResult")
+	proto.RegisterType((*CommitChaincodeDefinitionArgs)(nil), "sdk.lifecycle.CommitChaincodeDefinitionArgs")
+	proto.RegisterType((*CommitChaincodeDefinitionResult)(nil), "sdk.lifecycle.CommitChaincodeDefinitionResult")
+	proto.RegisterType((*CheckCommitReadinessArgs)(nil), "sdk.lifecycle.CheckCommitReadinessArgs")
+	proto.RegisterType((*CheckCommitReadinessResult)(nil), "sdk.lifecycle.CheckCommitReadinessResult")
+	proto.RegisterType((*QueryChaincodeDefinitionArgs)(nil), "sdk.lifecycle.QueryChaincodeDefinitionArgs")
+	proto.RegisterType((*QueryChaincodeDefinitionResult)(nil), "sdk.lifecycle.QueryChaincodeDefinitionResult")
+	proto.RegisterType((*QueryChaincodeDefinitionsArgs)(nil), "sdk.lifecycle.QueryChaincodeDefinitionsArgs")
+	proto.RegisterType((*QueryChaincodeDefinitionsResult)(nil), "sdk.lifecycle.QueryChaincodeDefinitionsResult")
+	proto.RegisterType((*QueryChaincodeDefinitionsResult_ChaincodeDefinition)(nil), "sdk.lifecycle.QueryChaincodeDefinitionsResult.ChaincodeDefinition")
+}
+
+func init() { proto.RegisterFile("peer/lifecycle/lifecycle.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 985 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0x6e, 0xe2, 0x24, 0x4d, 0x4e, 0x76, 0x69, 0x3b, 0x1b, 0xa8, 0x31, 0xec, 0x6e, 0x30, 0xd2,
+	0x6a, 0xc5, 0x8f, 0x23, 0xb2, 0xbd, 0x28, 0xd5, 0x0a, 0x29, 0x0d, 0xd0, 0x6e, 0xd5, 0x8a, 0xe2,
+	0x02, 0x42, 0xdc, 0xa4, 0x13, 0xfb, 0x24, 0x3b, 0xda, 0x89, 0x9d, 0x8e, 0x9d, 0x48, 0x79, 0x18,
+	0xde, 0x00, 0xf1, 0x0a, 0xbc, 0x05, 0x37, 0x48, 0x08, 0x09, 0x71, 0xcd, 0x2b, 0xa0, 0x8c, 0x27,
+	0xb6, 0xb3, 0xb1, 0xb3, 0x69, 0x77, 0xb9, 0xdb, 0x3b, 0x7b, 0xce, 0x77, 0x7e, 0x66, 0xce, 0xf7,
+	0xf9, 0x4c, 0x02, 0x7b, 0x63, 0x44, 0xd1, 0xe2, 0x6c, 0x80, 0xce, 0xcc, 0xe1, 0x98, 0x3c, 0x59,
+	0x63, 0xe1, 0x87, 0x3e, 0xa9, 0xc5, 0x0b, 0xc6, 0x5d, 0xc7, 0x1f, 0x8d, 0x7c, 0xaf, 0xe5, 0xf8,
+	0x9c, 0xa3, 0x13, 0x32, 0xdf, 0x8b, 0x30, 0xa6, 0x0d, 0x8d, 0x13, 0x2f, 0x08, 0x29, 0xe7, 0xdd,
+	0x53, 0xca, 0x3c, 0xc7, 0x77, 0xb1, 0x23, 0x86, 0x01, 0x79, 0x00, 0xef, 0x3a, 0x8b, 0x85, 0x1e,
+	0x8b, 0x10, 0xbd, 0x31, 0x75, 0xce, 0xe8, 0x10, 0xf5, 0x42, 0xb3, 0x70, 0xb8, 0x65, 0xdf, 0x8d,
+	0x01, 0x2a, 0xc2, 0xf3, 0xc8, 0x6c, 0x3e, 0x83, 0x77, 0xce, 0xc7, 0xb4, 0x31, 0x98, 0xf0, 0x90,
+	0xec, 0x02, 0xa8, 0x18, 0x3d, 0xe6, 0xca, 0x30, 0x35, 0xbb, 0xa6, 0x56, 0x4e, 0x5c, 0xd2, 0x80,
+	0x32, 0xa7, 0x7d, 0xe4, 0x7a, 0x51, 0x5a, 0xa2, 0x17, 0xf3, 0x18, 0xde, 0xfb, 0x76, 0x82, 0x62,
+	0xa6, 0x62, 0xa2, 0xbb, 0x5c, 0xe9, 0xfa, 0x98, 0xe6, 0x6f, 0x1a, 0xec, 0xe6, 0xb8, 0x5f, 0xa2,
+	0x28, 0xf2, 0x23, 0x80, 0xc0, 0x01, 0x0a, 0xf4, 0x1c, 0x0c, 0x74, 0xad, 0xa9, 0x1d, 0xd6, 0xdb,
+	0xf7, 0xad, 0xa4, 0x03, 0x6b, 0x53, 0x5a, 0x76, 0xec, 0xfa, 0x95, 0x17, 0x8a, 0x99, 0x9d, 0x8a,
+	0x65, 0x08, 0xb8, 0x75, 0xce, 0x4c, 0x6e, 0x83, 0x76, 0x86, 0x33, 0x55, 0xda, 0xfc, 0x91, 0x9c,
+	0x40, 0x79, 0x4a, 0xf9, 0x04, 0x65, 0x51, 0xf5, 0xf6, 0xd1, 0x1b, 0x64, 0xb6, 0xa3, 0x08, 0x0f,
+	0x8a, 0xf7, 0x0b, 0xc6, 0x4b, 0x80, 0xc4, 0x40, 0x6c, 0x80, 0xb8, 0xb5, 0x81, 0x5e, 0x90, 0x7b,
+	0x6b, 0x6f, 0x9c, 0x21, 0x79, 0x4f, 0x45, 0x31, 0x3e, 0x87, 0x5a, 0x6c, 0x20, 0x04, 0x4a, 0x1e,
+	0x1d, 0xa1, 0xda, 0x90, 0x7c, 0x26, 0x3a, 0xdc, 0x9c, 0xa2, 0x08, 0x98, 0xef, 0xa9, 0x83, 0x5e,
+	0xbc, 0x9a, 0x1d, 0x68, 0x3e, 0xc2, 0x70, 0x35, 0x9f, 0xa2, 0xdb, 0x26, 0x24, 0x78, 0x09, 0xe6,
+	0xba, 0x10, 0x8a, 0x08, 0x97, 0xe1, 0xfc, 0x1e, 0xbc, 0x9f, 0x73, 0x2c, 0xc1, 0xbc, 0x40, 0xf3,
+	0xcf, 0x12, 0xec, 0xe5, 0x01, 0x54, 0x7a, 0x1f, 0x1a, 0x6c, 0x61, 0xec, 0xad, 0x34, 0xe0, 0xf8,
+	0xe2, 0x06, 0xa8, 0x40, 0xd6, 0xaa, 0xc5, 0xde, 0x61, 0xab, 0x68, 0xe3, 0x97, 0x22, 0x90, 0x55,
+	0xec, 0x9b, 0xe9, 0x81, 0x67, 0xe8, 0xe1, 0xe9, 0x65, 0x4a, 0x5e, 0xab, 0x91, 0x60, 0x13, 0x8d,
+	0x3c, 0x59, 0xd6, 0xc8, 0xbd, 0xcd, 0xab, 0xc9, 0x16, 0x09, 0x5d, 0x12, 0xc9, 0x8b, 0x0c, 0x91,
+	0x1c, 0x6d, 0x9e, 0xe2, 0xca, 0x55, 0xf2, 0xb3, 0x06, 0x07, 0x9d, 0xf1, 0x58, 0xf8, 0x53, 0x8c,
+	0x43, 0x7c, 0x89, 0x03, 0xe6, 0xb1, 0xf9, 0xd7, 0xfe, 0x6b, 0x5f, 0x3c, 0x9b, 0x7d, 0x23, 0x86,
+	0x52, 0x2c, 0x06, 0x54, 0x03, 0x7c, 0x35, 0x99, 0xef, 0x43, 0x06, 0xd7, 0xec, 0xf8, 0x3d, 0x4e,
+	0x5a, 0xcc, 0x4e, 0xaa, 0x2d, 0x25, 0x25, 0x9f, 0x02, 0x41, 0xcf, 0xf5, 0x45, 0x80, 0x23, 0xf4,
+	0xc2, 0xde, 0x98, 0x4f, 0x86, 0xcc, 0xd3, 0x4b, 0x12, 0x74, 0x27, 0x65, 0x79, 0x2e, 0x0d, 0xe4,
+	0x63, 0xb8, 0x33, 0xa5, 0x9c, 0xb9, 0x74, 0x5e, 0xd2, 0x02, 0x5d, 0x96, 0xe8, 0xdb, 0x89, 0x41,
+	0x81, 0x3f, 0x83, 0x46, 0x1a, 0x4c, 0x05, 0x1d, 0x61, 0x88, 0x42, 0xaf, 0x48, 0x21, 0xee, 0xa4,
+	0xf0, 0x0b, 0x13, 0xe9, 0x40, 0x3d, 0x19, 0x70, 0x81, 0x7e, 0x53, 0xf6, 0x7d, 0xdf, 0x8a, 0x66,
+	0x9f, 0xd5, 0x8d, 0x4d, 0x5d, 0xdf, 0x1b, 0xb0, 0xe1, 0x42, 0xfc, 0x69, 0x1f, 0xf2, 0x21, 0x6c,
+	0xcf, 0x8f, 0xac, 0x27, 0xf0, 0xd5, 0x84, 0x09, 0x74, 0xf5, 0x6a, 0xb3, 0x70, 0x58, 0xb5, 0xb7,
+	0xe6, 0x8b, 0xb6, 0x5a, 0x23, 0x6d, 0xa8, 0x04, 0xfe, 0x44, 0x38, 0xa8, 0xd7, 0x64, 0x0a, 0x23,
+	0xd5, 0xf7, 0xf8, 0xf0, 0x5f, 0x48, 0x84, 0xad, 0x90, 0xe6, 0x3f, 0x05, 0xb8, 0x75, 0xce, 0x46,
+	0x9e, 0x40, 0x7d, 0xe2, 0xd1, 0x29, 0x65, 0x9c, 0xf6, 0x79, 0xd4, 0x8b, 0x7a, 0xfb, 0x20, 0x3f,
+	0x98, 0xf5, 0x7d, 0x82, 0x7e, 0x7c, 0xc3, 0x4e, 0x3b, 0x93, 0x47, 0xb0, 0xcd, 0x7d, 0x87, 0x26,
+	0x1f, 0xac, 0x88, 0xf5, 0xcd, 0x35, 0xd1, 0x9e, 0xce, 0xf1, 0x8f, 0x6f, 0xd8, 0x5b, 0xd2, 0x51,
+	0x1d, 0x87, 0xb1, 0x0d, 0xf5, 0x54, 0x1a, 0xe3, 0x00, 0xca, 0x12, 0x77, 0xc1, 0x67, 0xe1, 0x61,
+	0x05, 0x4a, 0xdf, 0xcd, 0xc6, 0x68, 0x7e, 0x04, 0x87, 0x17, 0xd3, 0x30, 0x12, 0x81, 0xf9, 0x57,
+	0x11, 0x76, 0xbb, 0xfe, 0x68, 0xc4, 0xc2, 0x0c, 0xec, 0x35, 0x55, 0xaf, 0x80, 0xaa, 0xe6, 0x07,
+	0xb0, 0x9f, 0x7b, 0xc2, 0xaa, 0x0b, 0x7f, 0x14, 0x41, 0xef, 0x9e, 0xa2, 0x73, 0x16, 0x01, 0x6d,
+	0xa4, 0x2e, 0xf3, 0x30, 0x08, 0xae, 0x1b, 0x70, 0x15, 0x0d, 0xf8, 0xb5, 0x00, 0x46, 0xd6, 0xe9,
+	0xaa, 0xa1, 0x6f, 0x43, 0x8d, 0x4a, 0xb9, 0x50, 0xbe, 0x98, 0x22, 0xf7, 0x96, 0x24, 0x9b, 0xe7,
+	0x69, 0x75, 0x16, 0x6e, 0xd1, 0x78, 0x4c, 0xc2, 0x18, 0xc7, 0xf0, 0xd6, 0xb2, 0x31, 0x63, 0x38,
+	0x36, 0xd2, 0xc3, 0xb1, 0x9a, 0x1a, 0x73, 0x66, 0x5b, 0xdd, 0x64, 0xf2, 0x24, 0x99, 0x31, 0x96,
+	0xcc, 0xbf, 0x35, 0xd8, 0xcb, 0x73, 0x52, 0x1b, 0x5d, 0x47, 0xa4, 0xdc, 0xa9, 0x96, 0x43, 0x1a,
+	0xed, 0xb5, 0x48, 0x53, 0x7a, 0x4d, 0xd2, 0x94, 0x37, 0x26, 0x4d, 0xe5, 0x2a, 0x48, 0x73, 0x33,
+	0x63, 0xc0, 0xfc, 0x90, 0x66, 0x45, 0x35, 0xfb, 0xc7, 0x45, 0xee, 0x51, 0xff, 0x6f, 0xcc, 0xd8,
+	0x87, 0xdd, 0xbc, 0xcc, 0xd1, 0x25, 0xf7, 0x5f, 0x0d, 0xf6, 0x73, 0x11, 0x8a, 0x07, 0x01, 0xbc,
+	0x9d, 0x5c, 0xb2, 0xdd, 0xc4, 0xac, 0xc8, 0xff, 0xc5, 0x06, 0xdb, 0x5c, 0xb9, 0x43, 0x25, 0x26,
+	0xbb, 0xe1, 0x64, 0xe0, 0x8d, 0xdf, 0x8b, 0xb0, 0x93, 0x81, 0xce, 0xbc, 0x62, 0xa5, 0x89, 0x5a,
+	0xcc, 0x27, 0xea, 0xf5, 0xd7, 0x4d, 0xa0, 0xfb, 0xd0, 0x81, 0x4f, 0x7c, 0x31, 0xb4, 0x4e, 0x67,
+	0x63, 0x14, 0x1c, 0xdd, 0x21, 0x0a, 0x6b, 0x40, 0xfb, 0x82, 0x39, 0xd1, 0xdf, 0x0b, 0x81, 0x35,
+	0x46, 0x14, 0x49, 0x4b, 0x7f, 0x3a, 0x1a, 0xb2, 0xf0, 0x74, 0xd2, 0x9f, 0x17, 0xd2, 0x4a, 0x39,
+	0xb5, 0x22, 0xa7, 0x56, 0xe4, 0xd4, 0x5a, 0xfe, 0x5f, 0xa3, 0x5f, 0x91, 0xcb, 0x47, 0xff, 0x0d,
+	0x00, 0xdf, 0xb0, 0xb1, 0x8b, 0xf0, 0x10, 0x00, 0x00,
+}